@@ -1,15 +1,12 @@
 package main
 
 import (
-	"fmt"
-	"rich_go/internal/app"
+	"os"
+
+	"rich_go/internal/cli"
 )
 
 func main() {
-	fmt.Println("欢迎使用 Rich_GO 项目!")
-	
-	// 初始化应用
-	application := app.New()
-	application.Run()
+	// 未指定命令时启动服务，命令列表见 rich_go help
+	os.Exit(cli.Run(os.Args[1:]))
 }
-
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"rich_go/internal/config"
+)
+
+func TestAdminRequiresAuthWhenAuthDisabled(t *testing.T) {
+	cfg := testConfig()
+	cfg.Auth.Enabled = false
+	cfg.Tenancy.Default = "acme"
+	srv := newTestServer(t, cfg)
+
+	tests := []struct {
+		caller, method, path string
+		want                 int
+	}{
+		{"", http.MethodGet, "/api/v1/admin/audit", http.StatusUnauthorized},
+		{"", http.MethodGet, "/api/v1/admin/tenants", http.StatusUnauthorized},
+		{"", http.MethodGet, "/api/v1/admin/jobs", http.StatusUnauthorized},
+		{"", http.MethodPost, "/api/v1/admin/jobs/webhook-purge-deliveries/run", http.StatusUnauthorized},
+		{"admin", http.MethodGet, "/api/v1/admin/audit", http.StatusOK},
+		{"admin", http.MethodGet, "/api/v1/admin/jobs", http.StatusOK},
+		{"acme", http.MethodGet, "/api/v1/admin/tenants", http.StatusForbidden},
+		// 旧路径不再注册
+		{"admin", http.MethodGet, "/admin/jobs", http.StatusNotFound},
+		// 业务接口仍无需认证
+		{"", http.MethodGet, "/api/v1/coupons", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if status, _ := srv.do(t, tt.caller, tt.method, tt.path, nil); status != tt.want {
+			t.Errorf("%s %s 以 %q 调用返回 %d, want %d", tt.method, tt.path, tt.caller, status, tt.want)
+		}
+	}
+}
+
+func TestWarnsWithoutPlatformToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens []config.AuthToken
+		warn   bool
+	}{
+		{"没有 token", nil, true},
+		{"只有绑定租户的 token", []config.AuthToken{{Name: "acme", Token: "acme-token", Tenant: "acme"}}, true},
+		{"有平台 token", []config.AuthToken{{Name: "admin", Token: "admin-token"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			defer log.SetOutput(os.Stderr)
+
+			cfg := testConfig()
+			cfg.Auth.Tokens = tt.tokens
+			if _, err := New(cfg); err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if got := strings.Contains(buf.String(), "/api/v1/admin/"); got != tt.warn {
+				t.Errorf("输出启动警告 %v, want %v: %s", got, tt.warn, buf.String())
+			}
+		})
+	}
+}
@@ -1,35 +1,170 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	"rich_go/internal/router"
 	"rich_go/internal/server"
+	"rich_go/internal/service"
+	"rich_go/internal/tracing"
 )
 
 // App 应用主结构
 type App struct {
 	Name       string
 	Version    string
+	Config     *config.Config
+	Health     *health.Registry
 	HTTPServer *server.HTTPServer
+	GRPCServer *server.GRPCServer // 未启用 gRPC 时为 nil
+	Modules    *module.Manager
+
+	shutdownTracing tracing.ShutdownFunc
+}
+
+// New 按配置创建应用实例：初始化业务模块、执行迁移并创建服务器，不启动监听
+func New(cfg *config.Config) (*App, error) {
+	shutdownTracing, err := tracing.Init(cfg.Tracing, cfg.App.Version)
+	if err != nil {
+		return nil, fmt.Errorf("初始化链路追踪失败: %w", err)
+	}
+
+	healthRegistry := health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	manager, mc, err := Bootstrap(cfg, healthRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if err := manager.Migrate(context.Background()); err != nil {
+		return nil, err
+	}
+	tenants, err := module.Resolve[service.TenantService](mc)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &App{
+		Name:            cfg.App.Name,
+		Version:         cfg.App.Version,
+		Config:          cfg,
+		Health:          healthRegistry,
+		HTTPServer:      server.NewHTTPServer(cfg, healthRegistry, tenants, manager.HTTPRegistrars()...),
+		Modules:         manager,
+		shutdownTracing: shutdownTracing,
+	}
+	if cfg.GRPC.Enabled {
+		a.GRPCServer = server.NewGRPCServer(cfg, healthRegistry, tenants, manager.GRPCRegistrars()...)
+	}
+	if !hasPlatformToken(cfg.Auth.Tokens) {
+		log.Printf("警告: auth.tokens 中没有未绑定租户的 token，%s 下的平台接口（租户、审计日志、定时任务）将拒绝所有请求",
+			strings.Join(router.PlatformPaths(), "、"))
+	}
+	return a, nil
+}
+
+// hasPlatformToken 是否配置了可访问平台接口的 token，绑定租户的 token 无权访问
+func hasPlatformToken(tokens []config.AuthToken) bool {
+	for _, t := range tokens {
+		if t.Tenant == "" && t.Token != "" {
+			return true
+		}
+	}
+	return false
 }
 
-// New 创建新的应用实例
-func New() *App {
-	return &App{
-		Name:       "Rich_GO",
-		Version:    "1.0.0",
-		HTTPServer: server.NewHTTPServer("8080"),
+// Bootstrap 按依赖顺序初始化业务模块（Repository、Service 层及健康检查），不执行迁移、不创建服务器
+// 命令行工具通过返回的 module.Context 使用与服务器相同的服务层
+func Bootstrap(cfg *config.Config, healthRegistry *health.Registry) (*module.Manager, *module.Context, error) {
+	manager, err := module.NewManager(modules()...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("注册模块失败: %w", err)
 	}
+	mc := module.NewContext(cfg, healthRegistry)
+	if err := manager.Init(mc); err != nil {
+		return nil, nil, err
+	}
+	return manager, mc, nil
 }
 
-// Run 运行应用
-func (a *App) Run() {
+// Run 运行应用，收到 SIGINT/SIGTERM 后优雅关闭并返回 nil
+// 服务器监听或运行失败时同样关闭应用，并返回该错误
+func (a *App) Run() error {
 	fmt.Printf("应用启动中... [%s v%s]\n", a.Name, a.Version)
-	fmt.Printf("HTTP 服务器启动在端口: %s\n", "8080")
-	fmt.Println("访问 http://localhost:8080/health 查看健康状态")
-	
+	fmt.Printf("HTTP 服务器启动在端口: %d\n", a.Config.Server.Port)
+	fmt.Printf("访问 http://localhost:%d/readyz 查看就绪状态\n", a.Config.Server.Port)
+
+	errCh := make(chan error, 2)
+
 	// 启动 HTTP 服务器
-	if err := a.HTTPServer.Start(); err != nil {
-		panic(fmt.Sprintf("启动 HTTP 服务器失败: %v", err))
+	go func() {
+		if err := a.HTTPServer.Start(); err != nil {
+			errCh <- fmt.Errorf("启动 HTTP 服务器失败: %w", err)
+		}
+	}()
+
+	// 启动 gRPC 服务器
+	if a.GRPCServer != nil {
+		fmt.Printf("gRPC 服务器启动在端口: %d\n", a.Config.GRPC.Port)
+		go func() {
+			if err := a.GRPCServer.Start(); err != nil {
+				errCh <- fmt.Errorf("启动 gRPC 服务器失败: %w", err)
+			}
+		}()
+	}
+
+	// 启动各模块的后台任务
+	a.Modules.StartJobs()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	select {
+	case err := <-errCh:
+		log.Printf("%v，开始关闭", err)
+		a.Shutdown()
+		return err
+	case sig := <-quit:
+		log.Printf("收到信号 %v，开始优雅关闭", sig)
+		a.Shutdown()
+		return nil
 	}
 }
 
+// Shutdown 优雅关闭应用
+// 先将就绪检查置为失败并等待负载均衡摘流，再关闭服务器、停止后台任务并刷新链路追踪
+func (a *App) Shutdown() {
+	a.Health.SetShuttingDown()
+	if delay := a.Config.Health.DrainDelay; delay > 0 {
+		log.Printf("就绪检查已置为失败，等待 %v 摘流", delay)
+		time.Sleep(delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.Config.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.HTTPServer.Shutdown(ctx); err != nil {
+		log.Printf("关闭 HTTP 服务器失败: %v", err)
+	}
+	if a.GRPCServer != nil {
+		if err := a.GRPCServer.Shutdown(ctx); err != nil {
+			log.Printf("关闭 gRPC 服务器失败: %v", err)
+		}
+	}
+	if err := a.Modules.StopJobs(ctx); err != nil {
+		log.Printf("停止后台任务失败: %v", err)
+	}
+	if err := a.shutdownTracing(ctx); err != nil {
+		log.Printf("关闭链路追踪失败: %v", err)
+	}
+	log.Println("应用已关闭")
+}
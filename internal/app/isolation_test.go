@@ -0,0 +1,268 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rich_go/internal/config"
+	"rich_go/internal/event"
+	"rich_go/internal/model"
+	"rich_go/internal/server/handlers"
+	whsign "rich_go/pkg/webhook"
+)
+
+// 测试用 token：admin 为平台调用方，其余绑定同名租户
+var testTokens = map[string]string{"admin": "admin-token", "acme": "acme-token", "globex": "globex-token"}
+
+// testConfig 启用认证和多租户、后台任务快速轮询的配置
+func testConfig() *config.Config {
+	cfg := config.Default()
+	cfg.App.Env = "testing"
+	cfg.GRPC.Enabled = false
+	cfg.Health.DrainDelay = 0
+	cfg.Events.RelayInterval = 10 * time.Millisecond
+	cfg.Webhooks.Interval = 10 * time.Millisecond
+	cfg.Webhooks.AllowPrivateTargets = true // 接收方是本地测试服务器
+	cfg.Auth.Enabled = true
+	for name, token := range testTokens {
+		t := config.AuthToken{Name: name, Token: token}
+		if name != "admin" {
+			t.Tenant = name
+		}
+		cfg.Auth.Tokens = append(cfg.Auth.Tokens, t)
+	}
+	cfg.Tenancy.Enabled = true
+	cfg.Tenancy.Default = ""
+	cfg.Tenancy.Tenants = []config.TenantConfig{{ID: "acme", Name: "Acme"}, {ID: "globex", Name: "Globex"}}
+	return cfg
+}
+
+// testServer 运行后台任务的应用及其 HTTP 测试服务器
+type testServer struct {
+	*httptest.Server
+}
+
+func newTestServer(t *testing.T, cfg *config.Config) *testServer {
+	t.Helper()
+	a, err := New(cfg)
+	if err != nil {
+		t.Fatalf("创建应用: %v", err)
+	}
+	a.Modules.StartJobs()
+	srv := httptest.NewServer(a.HTTPServer.Handler())
+	t.Cleanup(func() {
+		srv.Close()
+		_ = a.Modules.StopJobs(context.Background())
+	})
+	return &testServer{Server: srv}
+}
+
+// do 以 token 对应的调用方发送请求，返回状态码和响应中的 data
+func (s *testServer) do(t *testing.T, caller, method, path string, body interface{}) (int, json.RawMessage) {
+	t.Helper()
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, s.URL+path, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testTokens[caller])
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&envelope)
+	return resp.StatusCode, envelope.Data
+}
+
+// mustDo 同 do，状态码不是 200 时测试失败，结果解析到 v
+func (s *testServer) mustDo(t *testing.T, caller, method, path string, body, v interface{}) {
+	t.Helper()
+	status, data := s.do(t, caller, method, path, body)
+	if status != http.StatusOK {
+		t.Fatalf("%s %s 以 %s 调用返回 %d: %s", method, path, caller, status, data)
+	}
+	if v != nil {
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatalf("解析 %s %s 响应: %v", method, path, err)
+		}
+	}
+}
+
+// receiver 记录 webhook 请求的测试接收方，按路径区分租户并校验签名
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	secrets  map[string]string
+	received map[string][]event.Envelope
+	invalid  int
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{secrets: map[string]string{}, received: map[string][]event.Envelope{}}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		path := strings.TrimPrefix(req.URL.Path, "/")
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		var env event.Envelope
+		if whsign.Verify(r.secrets[path], req.Header, body, time.Minute) != nil || json.Unmarshal(body, &env) != nil {
+			r.invalid++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.received[path] = append(r.received[path], env)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// wait 等待 path 收到 n 个事件
+func (r *receiver) wait(t *testing.T, path string, n int) []event.Envelope {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		got := append([]event.Envelope(nil), r.received[path]...)
+		r.mu.Unlock()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	srv := newTestServer(t, testConfig())
+	recv := newReceiver(t)
+	tenants := []string{"acme", "globex"}
+
+	hooks := map[string]model.Webhook{}
+	coupons := map[string][]model.Coupon{}
+	for _, tenantID := range tenants {
+		secret := tenantID + "-secret-0123456789"
+		recv.mu.Lock()
+		recv.secrets[tenantID] = secret
+		recv.mu.Unlock()
+		var hook handlers.WebhookCreated
+		srv.mustDo(t, tenantID, http.MethodPost, "/api/v1/webhooks", map[string]interface{}{
+			"url": recv.URL + "/" + tenantID, "eventTypes": []string{"coupon.created"}, "secret": secret,
+		}, &hook)
+		hooks[tenantID] = hook.Webhook
+
+		// 两个租户有同名优惠券，另有一张只属于本租户
+		for _, name := range []string{"summer sale", tenantID + " exclusive"} {
+			var c model.Coupon
+			srv.mustDo(t, tenantID, http.MethodPost, "/api/v1/coupons", map[string]interface{}{
+				"name": name, "discountType": "fixed", "discountValue": 10,
+			}, &c)
+			coupons[tenantID] = append(coupons[tenantID], c)
+		}
+	}
+
+	t.Run("仓储", func(t *testing.T) {
+		for i, tenantID := range tenants {
+			other := tenants[1-i]
+			var list handlers.CouponList
+			srv.mustDo(t, tenantID, http.MethodGet, "/api/v1/coupons", nil, &list)
+			if len(list.Coupons) != 2 {
+				t.Errorf("%s 的优惠券列表有 %d 张, want 2", tenantID, len(list.Coupons))
+			}
+			for _, c := range list.Coupons {
+				if c.TenantID != tenantID {
+					t.Errorf("%s 的优惠券列表包含租户 %s 的优惠券 %d", tenantID, c.TenantID, c.ID)
+				}
+			}
+
+			otherPath := fmt.Sprintf("/api/v1/coupons/%d", coupons[other][0].ID)
+			for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+				if status, _ := srv.do(t, tenantID, method, otherPath, map[string]string{"name": "hijacked"}); status != http.StatusNotFound {
+					t.Errorf("%s %s %s 返回 %d, want 404", tenantID, method, otherPath, status)
+				}
+			}
+		}
+	})
+
+	t.Run("搜索", func(t *testing.T) {
+		for _, tenantID := range tenants {
+			var results handlers.SearchResults
+			srv.mustDo(t, tenantID, http.MethodGet, "/api/v1/search?q=summer", nil, &results)
+			if len(results.Hits) != 1 || results.Hits[0].ID != fmt.Sprint(coupons[tenantID][0].ID) {
+				t.Errorf("%s 搜索 summer = %+v, want 只有优惠券 %d", tenantID, results.Hits, coupons[tenantID][0].ID)
+			}
+			srv.mustDo(t, tenantID, http.MethodGet, "/api/v1/search?q=exclusive", nil, &results)
+			if len(results.Hits) != 1 || results.Hits[0].ID != fmt.Sprint(coupons[tenantID][1].ID) {
+				t.Errorf("%s 搜索 exclusive = %+v, want 只有优惠券 %d", tenantID, results.Hits, coupons[tenantID][1].ID)
+			}
+		}
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		for i, tenantID := range tenants {
+			other := tenants[1-i]
+			got := recv.wait(t, tenantID, 2)
+			if len(got) != 2 {
+				t.Fatalf("%s 的接收方收到 %d 个事件, want 2", tenantID, len(got))
+			}
+			for _, env := range got {
+				if env.Tenant != tenantID || env.Type != "coupon.created" {
+					t.Errorf("%s 的接收方收到租户 %s 的 %s 事件", tenantID, env.Tenant, env.Type)
+				}
+			}
+
+			var list handlers.WebhookList
+			srv.mustDo(t, tenantID, http.MethodGet, "/api/v1/webhooks", nil, &list)
+			if len(list.Webhooks) != 1 || list.Webhooks[0].ID != hooks[tenantID].ID {
+				t.Errorf("%s 的 webhook 列表为 %+v, want 只有 %d", tenantID, list.Webhooks, hooks[tenantID].ID)
+			}
+			otherPath := fmt.Sprintf("/api/v1/webhooks/%d/deliveries", hooks[other].ID)
+			if status, _ := srv.do(t, tenantID, http.MethodGet, otherPath, nil); status != http.StatusNotFound {
+				t.Errorf("%s 读取 %s 返回 %d, want 404", tenantID, otherPath, status)
+			}
+		}
+		recv.mu.Lock()
+		defer recv.mu.Unlock()
+		if recv.invalid > 0 {
+			t.Errorf("接收方收到 %d 个签名无效的请求", recv.invalid)
+		}
+	})
+
+	t.Run("审计日志", func(t *testing.T) {
+		for _, tenantID := range tenants {
+			var list handlers.AuditEntryList
+			srv.mustDo(t, "admin", http.MethodGet, "/api/v1/admin/audit?resourceType=coupon&tenant="+tenantID, nil, &list)
+			if len(list.Entries) != 2 {
+				t.Errorf("租户 %s 的审计日志 %d 条, want 2", tenantID, len(list.Entries))
+			}
+			for _, e := range list.Entries {
+				if e.Tenant != tenantID {
+					t.Errorf("按租户 %s 查询到租户 %s 的审计日志 %d", tenantID, e.Tenant, e.ID)
+				}
+			}
+			// 绑定租户的 token 不能访问平台管理接口
+			if status, _ := srv.do(t, tenantID, http.MethodGet, "/api/v1/admin/audit", nil); status != http.StatusForbidden {
+				t.Errorf("%s 读取审计日志返回 %d, want 403", tenantID, status)
+			}
+		}
+	})
+}
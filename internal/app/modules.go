@@ -0,0 +1,29 @@
+package app
+
+import (
+	"rich_go/internal/module"
+	"rich_go/internal/modules/audit"
+	"rich_go/internal/modules/cache"
+	"rich_go/internal/modules/coupon"
+	"rich_go/internal/modules/events"
+	"rich_go/internal/modules/jobs"
+	"rich_go/internal/modules/search"
+	"rich_go/internal/modules/tenant"
+	"rich_go/internal/modules/user"
+	"rich_go/internal/modules/webhook"
+)
+
+// modules 应用包含的业务模块，新增业务域时在此追加，初始化顺序由模块依赖决定
+func modules() []module.Module {
+	return []module.Module{
+		events.New(),
+		audit.New(),
+		tenant.New(),
+		cache.New(),
+		search.New(),
+		jobs.New(),
+		user.New(),
+		coupon.New(),
+		webhook.New(),
+	}
+}
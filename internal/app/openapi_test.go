@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/router"
+)
+
+// TestOpenAPISpecMatchesRoutes 每个注册的路由都有接口描述，每个接口描述都有对应的路由
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	cfg := config.Default()
+	manager, _, err := Bootstrap(cfg, health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL))
+	if err != nil {
+		t.Fatalf("初始化模块: %v", err)
+	}
+	if err := router.ValidateSpec(cfg.API, manager.HTTPRegistrars()...); err != nil {
+		t.Errorf("接口描述与路由不一致:\n%v", err)
+	}
+
+	// 同时比对实际提供的文档与服务器路由
+	a, err := New(cfg)
+	if err != nil {
+		t.Fatalf("创建应用: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	a.HTTPServer.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, router.OpenAPIPath, nil))
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("解析 %s: %v", router.OpenAPIPath, err)
+	}
+	documented := map[string]bool{}
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	param := regexp.MustCompile(`/:(\w+)`)
+	ignored := map[string]bool{"/metrics": true, router.OpenAPIPath: true, router.DocsPath: true}
+	for _, r := range a.HTTPServer.Routes() {
+		if ignored[r.Path] {
+			continue
+		}
+		key := r.Method + " " + param.ReplaceAllString(r.Path, "/{$1}")
+		if !documented[key] {
+			t.Errorf("路由 %s 不在文档中", key)
+		}
+		delete(documented, key)
+	}
+	for key := range documented {
+		t.Errorf("文档中的 %s 没有对应的路由", key)
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// 请求头中的上游 trace，span ID 为 00f067aa0ba902b7
+const (
+	upstreamTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	upstreamParent  = "00-" + upstreamTraceID + "-00f067aa0ba902b7-01"
+)
+
+func TestSpanPropagation(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		spans  []string // 由外到内的 span 名称，每个 span 的父 span 为前一个
+	}{
+		{"用户列表", http.MethodGet, "/api/v1/users", "",
+			[]string{"GET /api/v1/users", "UserService.ListUsers", "UserRepository.FindAll"}},
+		{"优惠券详情", http.MethodGet, "/api/v1/coupons/1", "",
+			[]string{"GET /api/v1/coupons/:id", "CouponService.GetCoupon", "CouponRepository.FindByID"}},
+		{"创建优惠券", http.MethodPost, "/api/v1/coupons", `{"name":"summer","discountType":"fixed","discountValue":10}`,
+			[]string{"POST /api/v1/coupons", "CouponService.CreateCoupon", "CouponRepository.Create"}},
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	a, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("创建应用: %v", err)
+	}
+	handler := a.HTTPServer.Handler()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := len(recorder.Ended())
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+testTokens["acme"])
+			req.Header.Set("traceparent", upstreamParent)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if got := w.Header().Get("X-Trace-Id"); got != upstreamTraceID {
+				t.Errorf("X-Trace-Id = %q, want %s", got, upstreamTraceID)
+			}
+
+			byName := make(map[string]sdktrace.ReadOnlySpan)
+			for _, s := range recorder.Ended()[start:] {
+				if _, ok := byName[s.Name()]; !ok {
+					byName[s.Name()] = s
+				}
+			}
+			wantParent := "00f067aa0ba902b7"
+			for _, name := range tt.spans {
+				s, ok := byName[name]
+				if !ok {
+					t.Fatalf("缺少 span %q，已结束的 span 为 %v", name, names(byName))
+				}
+				if got := s.SpanContext().TraceID().String(); got != upstreamTraceID {
+					t.Errorf("span %q 的 trace ID = %s, want %s", name, got, upstreamTraceID)
+				}
+				if got := s.Parent().SpanID().String(); got != wantParent {
+					t.Errorf("span %q 的父 span = %s, want %s", name, got, wantParent)
+				}
+				wantParent = s.SpanContext().SpanID().String()
+			}
+		})
+	}
+}
+
+func names(spans map[string]sdktrace.ReadOnlySpan) []string {
+	var out []string
+	for name := range spans {
+		out = append(out, name)
+	}
+	return out
+}
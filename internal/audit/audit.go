@@ -0,0 +1,156 @@
+// Package audit 审计日志
+// 业务服务在写操作的事务中调用 Recorder 记录调用方、操作、资源和字段级变更，
+// 记录只追加并按哈希链接，可通过 Verify 检查是否被篡改
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+)
+
+// 资源类型
+const (
+	ResourceUser   = "user"
+	ResourceCoupon = "coupon"
+	ResourceTenant = "tenant"
+)
+
+// Change 一次变更，创建时 Before 为 nil，删除时 After 为 nil
+type Change struct {
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       interface{}
+	After        interface{}
+}
+
+// Recorder 记录审计日志，在 Transactor.WithinTx 中调用时随事务提交
+type Recorder interface {
+	Record(ctx context.Context, change Change) error
+}
+
+// repositoryRecorder 写入审计日志仓储的 Recorder
+type repositoryRecorder struct {
+	repo repository.AuditRepository
+}
+
+// NewRecorder 创建写入审计日志仓储的 Recorder
+func NewRecorder(repo repository.AuditRepository) Recorder {
+	return &repositoryRecorder{repo: repo}
+}
+
+func (r *repositoryRecorder) Record(ctx context.Context, change Change) error {
+	changes, err := Diff(change.Before, change.After)
+	if err != nil {
+		return err
+	}
+	req := RequestFromContext(ctx)
+	return r.repo.Append(ctx, &model.AuditEntry{
+		Timestamp:    time.Now().UTC(),
+		Tenant:       tenant.ID(ctx),
+		Actor:        req.Actor,
+		Action:       change.Action,
+		ResourceType: change.ResourceType,
+		ResourceID:   change.ResourceID,
+		Changes:      changes,
+		RequestID:    req.RequestID,
+		ClientIP:     req.ClientIP,
+	})
+}
+
+// Diff 按 JSON 字段比较两个快照，返回取值不同的字段，按字段名排序
+// 快照为 nil 时其所有字段视为 null；json:"-" 的字段不参与比较，不会写入审计日志
+func Diff(before, after interface{}) ([]model.AuditChange, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	null := json.RawMessage("null")
+	changes := make([]model.AuditChange, 0)
+	for _, name := range names {
+		bv, ok := b[name]
+		if !ok {
+			bv = null
+		}
+		av, ok := a[name]
+		if !ok {
+			av = null
+		}
+		if !bytes.Equal(bv, av) {
+			changes = append(changes, model.AuditChange{Field: name, Before: bv, After: av})
+		}
+	}
+	return changes, nil
+}
+
+// fields 将快照序列化为 字段名 -> JSON 值
+func fields(v interface{}) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("序列化审计快照失败: %w", err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("审计快照必须是 JSON 对象: %w", err)
+	}
+	return m, nil
+}
+
+// 哈希链校验失败原因
+const (
+	ReasonIDGap            = "id_gap"             // 记录 ID 不连续，有记录被删除
+	ReasonPrevHashMismatch = "prev_hash_mismatch" // prevHash 与上一条记录不一致
+	ReasonHashMismatch     = "hash_mismatch"      // 记录内容与哈希不一致
+)
+
+// Verification 哈希链校验结果
+type Verification struct {
+	Valid    bool   `json:"valid"`
+	Entries  int    `json:"entries"`
+	BrokenAt uint64 `json:"brokenAt,omitempty"` // 第一条校验失败的记录 ID
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Verify 按追加顺序校验哈希链，entries 须为全部记录，记录 ID 从 1 开始连续递增
+func Verify(entries []*model.AuditEntry) Verification {
+	prev := ""
+	for i, e := range entries {
+		switch {
+		case e.ID != uint64(i)+1:
+			return Verification{Entries: len(entries), BrokenAt: e.ID, Reason: ReasonIDGap}
+		case e.PrevHash != prev:
+			return Verification{Entries: len(entries), BrokenAt: e.ID, Reason: ReasonPrevHashMismatch}
+		case e.Hash != e.ComputeHash():
+			return Verification{Entries: len(entries), BrokenAt: e.ID, Reason: ReasonHashMismatch}
+		}
+		prev = e.Hash
+	}
+	return Verification{Valid: true, Entries: len(entries)}
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+)
+
+// recordChain 通过 Recorder 写入 n 条记录，返回按追加顺序的全部记录
+func recordChain(t *testing.T, n int) []*model.AuditEntry {
+	t.Helper()
+	repo := repository.NewAuditRepository()
+	recorder := NewRecorder(repo)
+	ctx := WithRequest(tenant.WithID(context.Background(), "acme"), Request{Actor: "alice", RequestID: "req-1"})
+	for i := 0; i < n; i++ {
+		err := recorder.Record(ctx, Change{
+			Action:       model.AuditActionUpdate,
+			ResourceType: ResourceCoupon,
+			ResourceID:   "1",
+			Before:       map[string]int{"discountValue": i},
+			After:        map[string]int{"discountValue": i + 1},
+		})
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	entries, err := repo.FindAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		tamper       func(entries []*model.AuditEntry) []*model.AuditEntry
+		wantValid    bool
+		wantBrokenAt uint64
+		wantReason   string
+	}{
+		{"未修改", func(e []*model.AuditEntry) []*model.AuditEntry { return e }, true, 0, ""},
+		{"没有记录", func([]*model.AuditEntry) []*model.AuditEntry { return nil }, true, 0, ""},
+		{"修改记录内容", func(e []*model.AuditEntry) []*model.AuditEntry {
+			e[1].Actor = "mallory"
+			return e
+		}, false, 2, ReasonHashMismatch},
+		{"修改字段变更", func(e []*model.AuditEntry) []*model.AuditEntry {
+			e[2].Changes[0].After = json.RawMessage("100")
+			return e
+		}, false, 3, ReasonHashMismatch},
+		{"修改内容并重新计算哈希", func(e []*model.AuditEntry) []*model.AuditEntry {
+			e[1].Actor = "mallory"
+			e[1].Hash = e[1].ComputeHash()
+			return e
+		}, false, 3, ReasonPrevHashMismatch},
+		{"删除中间的记录", func(e []*model.AuditEntry) []*model.AuditEntry {
+			return append(e[:1:1], e[2:]...)
+		}, false, 3, ReasonIDGap},
+		{"删除后重新编号", func(e []*model.AuditEntry) []*model.AuditEntry {
+			e = append(e[:1:1], e[2:]...)
+			for i, entry := range e {
+				entry.ID = uint64(i) + 1
+			}
+			return e
+		}, false, 2, ReasonPrevHashMismatch},
+		{"调换记录顺序", func(e []*model.AuditEntry) []*model.AuditEntry {
+			e[1], e[2] = e[2], e[1]
+			return e
+		}, false, 3, ReasonIDGap},
+		{"修改第一条记录的 prevHash", func(e []*model.AuditEntry) []*model.AuditEntry {
+			e[0].PrevHash = "0000"
+			return e
+		}, false, 1, ReasonPrevHashMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.tamper(recordChain(t, 4))
+			got := Verify(entries)
+			if got.Valid != tt.wantValid || got.BrokenAt != tt.wantBrokenAt || got.Reason != tt.wantReason {
+				t.Errorf("Verify = %+v, want valid=%v brokenAt=%d reason=%q", got, tt.wantValid, tt.wantBrokenAt, tt.wantReason)
+			}
+			if got.Entries != len(entries) {
+				t.Errorf("Entries = %d, want %d", got.Entries, len(entries))
+			}
+		})
+	}
+}
+
+func TestRecordLinksEntries(t *testing.T) {
+	entries := recordChain(t, 3)
+	for i, e := range entries {
+		if e.ID != uint64(i)+1 {
+			t.Errorf("第 %d 条记录 ID = %d, want %d", i, e.ID, i+1)
+		}
+		if i > 0 && e.PrevHash != entries[i-1].Hash {
+			t.Errorf("记录 %d 的 prevHash 未链接到上一条记录", e.ID)
+		}
+		if e.Tenant != "acme" || e.Actor != "alice" || e.RequestID != "req-1" {
+			t.Errorf("记录 %d 的请求信息为 tenant=%q actor=%q requestId=%q", e.ID, e.Tenant, e.Actor, e.RequestID)
+		}
+	}
+	if entries[0].PrevHash != "" {
+		t.Errorf("第一条记录的 prevHash = %q, want 空", entries[0].PrevHash)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type snapshot struct {
+		Name   string `json:"name"`
+		Value  int    `json:"value"`
+		Secret string `json:"-"`
+	}
+	tests := []struct {
+		name          string
+		before, after interface{}
+		want          map[string][2]string // 字段 -> [before, after]
+	}{
+		{"创建", nil, snapshot{Name: "a", Value: 1}, map[string][2]string{"name": {"null", `"a"`}, "value": {"null", "1"}}},
+		{"删除", snapshot{Name: "a", Value: 1}, nil, map[string][2]string{"name": {`"a"`, "null"}, "value": {"1", "null"}}},
+		{"只记录变化的字段", snapshot{Name: "a", Value: 1}, snapshot{Name: "a", Value: 2}, map[string][2]string{"value": {"1", "2"}}},
+		{"忽略 json:\"-\" 字段", snapshot{Secret: "x"}, snapshot{Secret: "y"}, map[string][2]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			got := make(map[string][2]string, len(changes))
+			for _, c := range changes {
+				got[c.Field] = [2]string{string(c.Before), string(c.After)}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Diff = %v, want %v", got, tt.want)
+			}
+			for field, want := range tt.want {
+				if got[field] != want {
+					t.Errorf("字段 %s 的变更为 %v, want %v", field, got[field], want)
+				}
+			}
+		})
+	}
+}
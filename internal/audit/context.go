@@ -0,0 +1,31 @@
+package audit
+
+import "context"
+
+// 非请求触发的变更（后台任务等）使用的调用方名称
+const (
+	ActorSystem    = "system"
+	ActorAnonymous = "anonymous" // 未启用认证时的调用方
+)
+
+// Request 发起变更的请求信息，由 HTTP 中间件和 gRPC 拦截器写入 context
+type Request struct {
+	Actor     string
+	RequestID string
+	ClientIP  string
+}
+
+type requestKey struct{}
+
+// WithRequest 将请求信息写入 context
+func WithRequest(ctx context.Context, r Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// RequestFromContext 从 context 中获取请求信息，不在请求中时调用方为 system
+func RequestFromContext(ctx context.Context) Request {
+	if r, ok := ctx.Value(requestKey{}).(Request); ok {
+		return r
+	}
+	return Request{Actor: ActorSystem}
+}
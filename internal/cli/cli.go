@@ -0,0 +1,173 @@
+// Package cli 命令行入口：启动服务以及迁移、数据管理、配置检查等运维命令
+// 各命令与服务器使用相同的配置加载。当前仓储为内存实现，命令进程内构造的服务层看不到运行中服务的数据，
+// 因此数据命令不在进程内调用服务层，而是通过 pkg/client 调用运行中的服务，由服务端的同一服务层处理；
+// 代价是执行数据命令时服务必须在运行，并需要 API token
+package cli
+
+import (
+	stderrors "errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"rich_go/internal/config"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+)
+
+// 退出码
+const (
+	exitOK    = 0
+	exitError = 1 // 命令执行失败
+	exitUsage = 2 // 命令或参数错误
+)
+
+const usage = `用法: rich_go [-config 文件] <命令> [参数]
+
+命令:
+  serve                                    启动 HTTP 与 gRPC 服务（未指定命令时的默认命令）
+  migrate up|down|status                   执行、回滚或查看各模块的迁移（serve 启动时也会执行迁移）
+  user create|list|disable                 管理用户
+  coupon create|import|export|deactivate   管理优惠券
+  config validate|print                    校验配置或输出生效的配置
+  routes                                   列出已注册的 HTTP 路由
+
+user、coupon 通过 HTTP API 管理运行中服务的数据（仓储为内存实现，命令进程无法直接访问），-server 指定服务地址（默认为本机的 server.port），
+-token 指定 API token（默认使用环境变量 RICH_GO_TOKEN），-tenant 指定租户。
+使用 "rich_go <命令> <子命令> -h" 查看参数。
+`
+
+// command 命令，args 为命令名之后的参数
+type command func(cfg *config.Config, args []string) error
+
+// commands 顶层命令
+var commands = map[string]command{
+	"serve":   runServe,
+	"migrate": runMigrate,
+	"user":    runUser,
+	"coupon":  runCoupon,
+	"config":  runConfig,
+	"routes":  runRoutes,
+}
+
+// usageError 命令或参数错误，以退出码 2 退出，usage 为空时输出顶层用法
+type usageError struct {
+	msg   string
+	usage string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Run 解析参数并执行命令，返回进程退出码
+func Run(args []string) int {
+	fs := flag.NewFlagSet("rich_go", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configPath := fs.String("config", "", "配置文件路径，默认依次使用环境变量 "+config.EnvConfigPath+" 和 "+config.DefaultPath)
+	if err := fs.Parse(args); err != nil {
+		if stderrors.Is(err, flag.ErrHelp) {
+			fmt.Print(usage)
+			return exitOK
+		}
+		return fail(usagef("%v", err))
+	}
+
+	name, rest := "serve", fs.Args()
+	if len(rest) > 0 {
+		name, rest = rest[0], rest[1:]
+	}
+	if name == "help" {
+		fmt.Print(usage)
+		return exitOK
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		return fail(usagef("未知命令 %q", name))
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fail(err)
+	}
+	return fail(cmd(cfg, rest))
+}
+
+// fail 输出错误并返回对应的退出码，err 为 nil 时返回 0
+func fail(err error) int {
+	var ue *usageError
+	switch {
+	case err == nil, stderrors.Is(err, flag.ErrHelp):
+		return exitOK
+	case stderrors.As(err, &ue):
+		help := ue.usage
+		if help == "" {
+			help = usage
+		}
+		fmt.Fprintf(os.Stderr, "错误: %s\n\n%s", ue.msg, help)
+		return exitUsage
+	default:
+		fmt.Fprintf(os.Stderr, "错误: %s\n", errorMessage(err))
+		return exitError
+	}
+}
+
+// errorMessage 业务错误输出本地化消息和错误码，其他错误输出原始消息
+func errorMessage(err error) string {
+	if be, ok := errors.AsBusinessError(err); ok {
+		msg, _ := be.Localize(i18n.DefaultLanguage)
+		return fmt.Sprintf("%s (code %d)", msg, be.Code)
+	}
+	return err.Error()
+}
+
+// subcommands 按第一个参数分发子命令
+func subcommands(name string, args []string, subs map[string]func(args []string) error) error {
+	names := make([]string, 0, len(subs))
+	for sub := range subs {
+		names = append(names, sub)
+	}
+	sort.Strings(names)
+
+	if len(args) == 0 {
+		return usagef("%s 需要子命令: %s", name, strings.Join(names, "|"))
+	}
+	sub, ok := subs[args[0]]
+	if !ok {
+		return usagef("%s 没有子命令 %q，可用: %s", name, args[0], strings.Join(names, "|"))
+	}
+	return sub(args[1:])
+}
+
+// parseFlags 解析子命令参数，-h 时输出参数说明，nargs 为必需的位置参数个数
+func parseFlags(fs *flag.FlagSet, args []string, nargs int, argsUsage string) error {
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	help := func() string {
+		var b strings.Builder
+		fmt.Fprintf(&b, "用法: rich_go %s [参数] %s\n", fs.Name(), argsUsage)
+		fs.SetOutput(&b)
+		fs.PrintDefaults()
+		fs.SetOutput(io.Discard)
+		return b.String()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if stderrors.Is(err, flag.ErrHelp) {
+			fmt.Print(help())
+			return err
+		}
+		return &usageError{msg: err.Error(), usage: help()}
+	}
+	if fs.NArg() != nargs {
+		return &usageError{msg: fmt.Sprintf("需要 %d 个参数 %s", nargs, argsUsage), usage: help()}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rich_go/internal/app"
+	"rich_go/internal/config"
+	"rich_go/pkg/client"
+	"rich_go/pkg/errors"
+)
+
+// newServer 运行真实应用的测试服务器，token acme-token 绑定租户 acme
+func newServer(t *testing.T) (*config.Config, *httptest.Server) {
+	t.Helper()
+	t.Setenv(envToken, "")
+	cfg := config.Default()
+	cfg.App.Env = "testing"
+	cfg.GRPC.Enabled = false
+	cfg.Auth.Enabled = true
+	cfg.Auth.Tokens = []config.AuthToken{{Name: "acme-ops", Token: "acme-token", Tenant: "acme"}}
+	cfg.Tenancy.Enabled = true
+	cfg.Tenancy.Default = ""
+	cfg.Tenancy.Tenants = []config.TenantConfig{{ID: "acme", Name: "Acme"}}
+
+	a, err := app.New(cfg)
+	if err != nil {
+		t.Fatalf("创建应用: %v", err)
+	}
+	srv := httptest.NewServer(a.HTTPServer.Handler())
+	t.Cleanup(srv.Close)
+	return cfg, srv
+}
+
+// remoteArgs 在子命令参数前加上服务地址和 token
+func remoteArgs(srv *httptest.Server, sub string, args ...string) []string {
+	return append([]string{sub, "-server", srv.URL, "-token", "acme-token"}, args...)
+}
+
+func apiClient(t *testing.T, srv *httptest.Server) *client.Client {
+	t.Helper()
+	c, err := client.New(srv.URL, client.WithAuth(client.BearerToken("acme-token")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCouponCommandsUseRunningServer(t *testing.T) {
+	cfg, srv := newServer(t)
+	c := apiClient(t, srv)
+	ctx := context.Background()
+
+	if err := runCoupon(cfg, remoteArgs(srv, "create", "-name", "summer", "-discount-type", "fixed", "-discount-value", "10")); err != nil {
+		t.Fatalf("coupon create: %v", err)
+	}
+	coupons, err := c.Coupons.List(ctx)
+	if err != nil || len(coupons) != 1 || coupons[0].Name != "summer" {
+		t.Fatalf("服务中的优惠券为 %+v, %v, want 只有 summer", coupons, err)
+	}
+
+	if err := runCoupon(cfg, remoteArgs(srv, "deactivate", fmt.Sprint(coupons[0].ID))); err != nil {
+		t.Fatalf("coupon deactivate: %v", err)
+	}
+	if got, _ := c.Coupons.Get(ctx, coupons[0].ID); got.Status != client.CouponInactive {
+		t.Errorf("deactivate 后状态为 %s, want inactive", got.Status)
+	}
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "coupons.csv")
+	if err := os.WriteFile(input, []byte("name,discountType,discountValue\nwinter,fixed,5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runCoupon(cfg, remoteArgs(srv, "import", input)); err != nil {
+		t.Fatalf("coupon import: %v", err)
+	}
+
+	output := filepath.Join(dir, "export.csv")
+	if err := runCoupon(cfg, remoteArgs(srv, "export", "-status", "active", "-o", output)); err != nil {
+		t.Fatalf("coupon export: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "winter") || strings.Contains(string(data), "summer") {
+		t.Errorf("导出内容为 %q, want 只有启用的 winter", data)
+	}
+}
+
+func TestCouponImportRejected(t *testing.T) {
+	cfg, srv := newServer(t)
+	input := filepath.Join(t.TempDir(), "coupons.csv")
+	if err := os.WriteFile(input, []byte("name,discountType,discountValue\nwinter,fixed,5\nspring,percent,150\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runCoupon(cfg, remoteArgs(srv, "import", input)); err == nil {
+		t.Error("coupon import 成功, want 第 3 行失败的错误")
+	}
+	if coupons, _ := apiClient(t, srv).Coupons.List(context.Background()); len(coupons) != 0 {
+		t.Errorf("atomic 导入失败后服务中有 %d 张优惠券, want 0", len(coupons))
+	}
+}
+
+func TestUserCommandsUseRunningServer(t *testing.T) {
+	cfg, srv := newServer(t)
+	c := apiClient(t, srv)
+	ctx := context.Background()
+
+	if err := runUser(cfg, remoteArgs(srv, "create", "-name", "alice", "-email", "alice@example.com")); err != nil {
+		t.Fatalf("user create: %v", err)
+	}
+	users, err := c.Users.List(ctx)
+	if err != nil || len(users) != 1 {
+		t.Fatalf("服务中的用户为 %+v, %v, want 1 个", users, err)
+	}
+	if err := runUser(cfg, remoteArgs(srv, "disable", fmt.Sprint(users[0].ID))); err != nil {
+		t.Fatalf("user disable: %v", err)
+	}
+	if got, _ := c.Users.Get(ctx, users[0].ID); got.Status != client.UserInactive {
+		t.Errorf("disable 后状态为 %s, want inactive", got.Status)
+	}
+	if err := runUser(cfg, remoteArgs(srv, "list")); err != nil {
+		t.Errorf("user list: %v", err)
+	}
+}
+
+func TestDataCommandErrors(t *testing.T) {
+	cfg, srv := newServer(t)
+
+	err := runCoupon(cfg, []string{"create", "-server", srv.URL, "-name", "summer", "-discount-type", "fixed", "-discount-value", "10"})
+	if be, ok := errors.AsBusinessError(err); !ok || be.Code != errors.CodeUnauthorized {
+		t.Errorf("未指定 token 时错误为 %v, want 未认证", err)
+	}
+
+	t.Setenv(envToken, "acme-token")
+	err = runCoupon(cfg, []string{"create", "-server", srv.URL, "-name", "summer", "-discount-type", "percent", "-discount-value", "150"})
+	if be, ok := errors.AsBusinessError(err); !ok || be.Code != errors.CodeInvalidParam {
+		t.Errorf("折扣超过 100 时错误为 %v, want 校验错误", err)
+	}
+
+	if code := fail(runUser(cfg, remoteArgs(srv, "disable", "abc"))); code != exitUsage {
+		t.Errorf("无效 ID 的退出码为 %d, want %d", code, exitUsage)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	cfg := config.Default()
+	for _, sub := range []string{"up", "down", "status"} {
+		if err := runMigrate(cfg, []string{sub}); err != nil {
+			t.Errorf("migrate %s: %v", sub, err)
+		}
+	}
+	var ue *usageError
+	if err := runMigrate(cfg, []string{"redo"}); !stderrors.As(err, &ue) {
+		t.Errorf("migrate redo 错误为 %v, want 未知子命令", err)
+	}
+}
+
+func TestServePortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	cfg := config.Default()
+	cfg.App.Env = "testing"
+	cfg.GRPC.Enabled = false
+	cfg.Health.DrainDelay = 0
+	cfg.Server.Port = ln.Addr().(*net.TCPAddr).Port
+
+	err = runServe(cfg, nil)
+	if err == nil || !strings.Contains(err.Error(), "address already in use") {
+		t.Fatalf("端口被占用时 serve 错误为 %v, want 监听失败", err)
+	}
+	if code := fail(err); code != exitError {
+		t.Errorf("退出码为 %d, want %d", code, exitError)
+	}
+}
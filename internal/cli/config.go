@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"rich_go/internal/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted 输出配置时替换敏感字段
+const redacted = "******"
+
+// runConfig 校验配置或输出生效的配置，配置在执行命令前已加载并校验
+func runConfig(cfg *config.Config, args []string) error {
+	return subcommands("config", args, map[string]func(args []string) error{
+		"validate": func(args []string) error {
+			if err := parseFlags(flag.NewFlagSet("config validate", flag.ContinueOnError), args, 0, ""); err != nil {
+				return err
+			}
+			fmt.Println("配置有效")
+			return nil
+		},
+		"print": func(args []string) error {
+			fs := flag.NewFlagSet("config print", flag.ContinueOnError)
+			showSecrets := fs.Bool("show-secrets", false, "输出 token 原文")
+			if err := parseFlags(fs, args, 0, ""); err != nil {
+				return err
+			}
+			out := *cfg
+			if !*showSecrets {
+				out.Auth.Tokens = make([]config.AuthToken, len(cfg.Auth.Tokens))
+				for i, t := range cfg.Auth.Tokens {
+					t.Token = redacted
+					out.Auth.Tokens[i] = t
+				}
+			}
+			enc := yaml.NewEncoder(os.Stdout)
+			enc.SetIndent(2)
+			if err := enc.Encode(&out); err != nil {
+				return err
+			}
+			return enc.Close()
+		},
+	})
+}
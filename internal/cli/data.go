@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"rich_go/internal/config"
+	"rich_go/internal/service"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/client"
+	"rich_go/pkg/i18n"
+)
+
+// runUser 管理用户
+func runUser(cfg *config.Config, args []string) error {
+	return subcommands("user", args, map[string]func(args []string) error{
+		"create": func(args []string) error {
+			fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			var req client.CreateUserRequest
+			fs.StringVar(&req.Name, "name", "", "姓名")
+			fs.StringVar(&req.Email, "email", "", "邮箱")
+			if err := parseFlags(fs, args, 0, ""); err != nil {
+				return err
+			}
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			created, err := c.Users.Create(context.Background(), &req)
+			if err != nil {
+				return err
+			}
+			return printJSON(created)
+		},
+		"list": func(args []string) error {
+			fs := flag.NewFlagSet("user list", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			asJSON := fs.Bool("json", false, "以 JSON 输出")
+			if err := parseFlags(fs, args, 0, ""); err != nil {
+				return err
+			}
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			list, err := c.Users.List(context.Background())
+			if err != nil {
+				return err
+			}
+			if *asJSON {
+				return printJSON(list)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tEMAIL\tSTATUS")
+			for _, u := range list {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", u.ID, u.Name, u.Email, u.Status)
+			}
+			return w.Flush()
+		},
+		"disable": func(args []string) error {
+			fs := flag.NewFlagSet("user disable", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			if err := parseFlags(fs, args, 1, "<id>"); err != nil {
+				return err
+			}
+			id, err := parseID(fs.Arg(0))
+			if err != nil {
+				return err
+			}
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			updated, err := c.Users.Update(context.Background(), id, &client.UpdateUserRequest{Status: client.UserInactive})
+			if err != nil {
+				return err
+			}
+			return printJSON(updated)
+		},
+	})
+}
+
+// runCoupon 管理优惠券
+func runCoupon(cfg *config.Config, args []string) error {
+	return subcommands("coupon", args, map[string]func(args []string) error{
+		"create": func(args []string) error {
+			fs := flag.NewFlagSet("coupon create", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			var req client.CreateCouponRequest
+			fs.StringVar(&req.Name, "name", "", "名称")
+			fs.StringVar(&req.Description, "description", "", "描述")
+			fs.StringVar(&req.DiscountType, "discount-type", "", "折扣类型: fixed, percent")
+			fs.Float64Var(&req.DiscountValue, "discount-value", 0, "折扣值，percent 时不超过 100")
+			fs.Float64Var(&req.MinAmount, "min-amount", 0, "最低消费金额")
+			fs.StringVar(&req.Status, "status", "", "状态: active, inactive，默认 active")
+			if err := parseFlags(fs, args, 0, ""); err != nil {
+				return err
+			}
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			created, err := c.Coupons.Create(context.Background(), &req)
+			if err != nil {
+				return err
+			}
+			return printJSON(created)
+		},
+		"import": func(args []string) error {
+			fs := flag.NewFlagSet("coupon import", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			format := fs.String("format", "", "数据格式: csv, ndjson，默认按文件扩展名判断")
+			var opts client.ImportOptions
+			fs.StringVar(&opts.Mode, "mode", client.ImportAtomic, "atomic: 任一行失败则不写入; best_effort: 跳过失败的行")
+			fs.BoolVar(&opts.DryRun, "dry-run", false, "只校验不写入")
+			if err := parseFlags(fs, args, 1, "<文件|->"); err != nil {
+				return err
+			}
+			path := fs.Arg(0)
+			f, err := dataFormat(*format, path, "")
+			if err != nil {
+				return err
+			}
+			in, err := openInput(path)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			result, err := c.Coupons.Import(context.Background(), in, f, opts)
+			if err != nil {
+				return err
+			}
+			if err := printJSON(result); err != nil {
+				return err
+			}
+			if result.Failed > 0 {
+				messageID := service.ImportMessageID(&service.ImportResult{Mode: result.Mode, DryRun: result.DryRun, Failed: result.Failed})
+				return fmt.Errorf("%s，%d 行失败", i18n.Translate(i18n.DefaultLanguage, messageID, nil), result.Failed)
+			}
+			return nil
+		},
+		"export": func(args []string) error {
+			fs := flag.NewFlagSet("coupon export", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			output := fs.String("o", "-", "输出文件，- 表示标准输出")
+			format := fs.String("format", "", "数据格式: csv, ndjson，默认按文件扩展名判断，无法判断时为 csv")
+			var filter client.CouponFilter
+			fs.StringVar(&filter.Name, "name", "", "名称包含，不区分大小写")
+			fs.StringVar(&filter.Status, "status", "", "状态: active, inactive")
+			fs.StringVar(&filter.DiscountType, "discount-type", "", "折扣类型: fixed, percent")
+			if err := parseFlags(fs, args, 0, ""); err != nil {
+				return err
+			}
+			f, err := dataFormat(*format, *output, bulk.CSV)
+			if err != nil {
+				return err
+			}
+
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			out, err := createOutput(*output)
+			if err != nil {
+				return err
+			}
+			err = c.Coupons.Export(context.Background(), out, f, filter)
+			if cerr := out.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+			if *output != "-" {
+				fmt.Fprintf(os.Stderr, "已导出到 %s\n", *output)
+			}
+			return nil
+		},
+		"deactivate": func(args []string) error {
+			fs := flag.NewFlagSet("coupon deactivate", flag.ContinueOnError)
+			r := remoteFlags(fs, cfg)
+			if err := parseFlags(fs, args, 1, "<id>"); err != nil {
+				return err
+			}
+			id, err := parseID(fs.Arg(0))
+			if err != nil {
+				return err
+			}
+			c, err := r.client()
+			if err != nil {
+				return err
+			}
+			updated, err := c.Coupons.Update(context.Background(), id, &client.UpdateCouponRequest{Status: client.CouponInactive})
+			if err != nil {
+				return err
+			}
+			return printJSON(updated)
+		},
+	})
+}
+
+// dataFormat 按 -format 或文件扩展名确定格式，都无法确定时使用 fallback，fallback 为空时返回错误
+func dataFormat(name, path string, fallback bulk.Format) (bulk.Format, error) {
+	if name != "" {
+		f, err := bulk.ParseFormat(name)
+		if err != nil {
+			return "", usagef("不支持的数据格式 %q，请使用 csv 或 ndjson", name)
+		}
+		return f, nil
+	}
+	if f, err := bulk.ParseFormat(strings.TrimPrefix(filepath.Ext(path), ".")); err == nil {
+		return f, nil
+	}
+	if fallback == "" {
+		return "", usagef("无法从 %q 判断数据格式，请通过 -format 指定 csv 或 ndjson", path)
+	}
+	return fallback, nil
+}
+
+// openInput 打开输入文件，- 表示标准输入
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// createOutput 创建输出文件，- 表示标准输出
+func createOutput(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"rich_go/internal/app"
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	"rich_go/internal/server"
+	"rich_go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// runServe 启动服务，直到收到 SIGINT/SIGTERM
+func runServe(cfg *config.Config, args []string) error {
+	if err := parseFlags(flag.NewFlagSet("serve", flag.ContinueOnError), args, 0, ""); err != nil {
+		return err
+	}
+	fmt.Println("欢迎使用 Rich_GO 项目!")
+	application, err := app.New(cfg)
+	if err != nil {
+		return err
+	}
+	return application.Run()
+}
+
+// runMigrate 执行、回滚或查看各模块的迁移，只初始化模块，不解析租户
+// up 按依赖顺序执行迁移，down 按依赖的逆序回滚，与 serve 启动时使用相同的模块和配置
+func runMigrate(cfg *config.Config, args []string) error {
+	migrate := func(name string, run func(ctx context.Context, m *module.Manager) error) func(args []string) error {
+		return func(args []string) error {
+			if err := parseFlags(flag.NewFlagSet("migrate "+name, flag.ContinueOnError), args, 0, ""); err != nil {
+				return err
+			}
+			manager, _, err := bootstrap(cfg)
+			if err != nil {
+				return err
+			}
+			return run(context.Background(), manager)
+		}
+	}
+
+	return subcommands("migrate", args, map[string]func(args []string) error{
+		"up": migrate("up", func(ctx context.Context, m *module.Manager) error {
+			if err := m.Migrate(ctx); err != nil {
+				return err
+			}
+			fmt.Printf("已执行 %d 个模块的迁移\n", len(m.Migrations()))
+			return nil
+		}),
+		"down": migrate("down", func(ctx context.Context, m *module.Manager) error {
+			if err := m.Rollback(ctx); err != nil {
+				return err
+			}
+			fmt.Printf("已回滚 %d 个模块的迁移\n", len(m.Migrations()))
+			return nil
+		}),
+		"status": migrate("status", func(ctx context.Context, m *module.Manager) error {
+			migrations := m.Migrations()
+			if len(migrations) == 0 {
+				fmt.Println("没有模块定义迁移")
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ORDER\tMODULE")
+			for i, name := range migrations {
+				fmt.Fprintf(w, "%d\t%s\n", i+1, name)
+			}
+			return w.Flush()
+		}),
+	})
+}
+
+// runRoutes 列出已注册的 HTTP 路由，自定义方法（如 :batch）按实际路径列出
+func runRoutes(cfg *config.Config, args []string) error {
+	if err := parseFlags(flag.NewFlagSet("routes", flag.ContinueOnError), args, 0, ""); err != nil {
+		return err
+	}
+	// 创建服务器时 Gin 会在调试模式下逐条打印路由
+	gin.DefaultWriter = io.Discard
+
+	healthRegistry := health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	manager, mc, err := app.Bootstrap(cfg, healthRegistry)
+	if err != nil {
+		return err
+	}
+	tenants, err := module.Resolve[service.TenantService](mc)
+	if err != nil {
+		return err
+	}
+	routes := server.NewHTTPServer(cfg, healthRegistry, tenants, manager.HTTPRegistrars()...).Routes()
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tHANDLER")
+	for _, r := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Method, r.Path, r.Handler)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"strconv"
+
+	"rich_go/internal/app"
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	"rich_go/pkg/client"
+	"rich_go/pkg/i18n"
+)
+
+// envToken 数据命令未指定 -token 时使用的 API token 环境变量
+const envToken = "RICH_GO_TOKEN"
+
+// bootstrap 初始化业务模块，不执行迁移
+func bootstrap(cfg *config.Config) (*module.Manager, *module.Context, error) {
+	return app.Bootstrap(cfg, health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL))
+}
+
+// remote 数据命令调用运行中服务的参数
+// 数据命令通过 HTTP API 管理服务中的数据，校验规则、配额、审计日志（调用方记为 token 名称）和领域事件与其他调用方一致
+type remote struct {
+	server string
+	token  string
+	tenant string
+	header string
+}
+
+// remoteFlags 注册数据命令共用的 -server、-token、-tenant 参数
+func remoteFlags(fs *flag.FlagSet, cfg *config.Config) *remote {
+	r := &remote{header: cfg.Tenancy.Header}
+	fs.StringVar(&r.server, "server", "http://localhost:"+strconv.Itoa(cfg.Server.Port), "服务地址，默认为本机的 server.port")
+	fs.StringVar(&r.token, "token", "", "API token，默认使用环境变量 "+envToken)
+	fs.StringVar(&r.tenant, "tenant", "", "租户 ID，默认使用 token 绑定的租户或服务端的 tenancy.default")
+	return r
+}
+
+// client 创建访问服务的客户端
+func (r *remote) client() (*client.Client, error) {
+	opts := []client.Option{client.WithLanguage(i18n.DefaultLanguage), client.WithUserAgent("rich_go-cli")}
+	token := r.token
+	if token == "" {
+		token = os.Getenv(envToken)
+	}
+	if token != "" {
+		opts = append(opts, client.WithAuth(client.BearerToken(token)))
+	}
+	if r.tenant != "" {
+		opts = append(opts, client.WithTenant(r.tenant, r.header))
+	}
+	return client.New(r.server, opts...)
+}
+
+// parseID 解析位置参数中的记录 ID
+func parseID(s string) (uint, error) {
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, usagef("无效的 ID %q", s)
+	}
+	return uint(id), nil
+}
+
+// printJSON 以缩进的 JSON 输出到标准输出
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
@@ -0,0 +1,451 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath 默认配置文件路径
+const DefaultPath = "configs/config.yaml"
+
+// EnvConfigPath 指定配置文件路径的环境变量
+const EnvConfigPath = "RICH_GO_CONFIG"
+
+// Config 应用配置
+type Config struct {
+	App      AppConfig      `yaml:"app"`
+	Server   ServerConfig   `yaml:"server"`
+	GRPC     GRPCConfig     `yaml:"grpc"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Health   HealthConfig   `yaml:"health"`
+	API      APIConfig      `yaml:"api"`
+	Events   EventsConfig   `yaml:"events"`
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+	Jobs     JobsConfig     `yaml:"jobs"`
+	Cache    CacheConfig    `yaml:"cache"`
+	Tenancy  TenancyConfig  `yaml:"tenancy"`
+}
+
+// AppConfig 应用基础配置
+type AppConfig struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Env     string `yaml:"env"` // development, production, testing
+}
+
+// ServerConfig HTTP 服务器配置
+type ServerConfig struct {
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // 优雅关闭等待进行中请求的最长时间
+	RequestTimeout  time.Duration `yaml:"request_timeout"`  // 单个请求的处理时限，0 表示不限制
+	MaxBodyBytes    int64         `yaml:"max_body_bytes"`   // 请求体大小上限，0 表示不限制
+
+	CORS     CORSConfig     `yaml:"cors"`
+	Security SecurityConfig `yaml:"security"`
+}
+
+// CORSConfig 跨域配置
+type CORSConfig struct {
+	Enabled          bool          `yaml:"enabled"`
+	AllowOrigins     []string      `yaml:"allow_origins"` // 支持 "*" 和 "https://*.example.com"
+	AllowMethods     []string      `yaml:"allow_methods"`
+	AllowHeaders     []string      `yaml:"allow_headers"`
+	ExposeHeaders    []string      `yaml:"expose_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"` // 预检结果缓存时间
+}
+
+// SecurityConfig 安全响应头配置
+type SecurityConfig struct {
+	Enabled               bool          `yaml:"enabled"`
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age"` // 仅在 HTTPS 请求上输出，0 表示不输出
+	HSTSIncludeSubdomains bool          `yaml:"hsts_include_subdomains"`
+	FrameOptions          string        `yaml:"frame_options"` // DENY 或 SAMEORIGIN
+	ReferrerPolicy        string        `yaml:"referrer_policy"`
+	ContentSecurityPolicy string        `yaml:"content_security_policy"`      // API 响应使用
+	DocsContentSecurity   string        `yaml:"docs_content_security_policy"` // 文档页面使用，需允许加载 Redoc 脚本
+}
+
+// GRPCConfig gRPC 服务器配置
+type GRPCConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	Host           string        `yaml:"host"`
+	Port           int           `yaml:"port"`
+	Reflection     bool          `yaml:"reflection"`      // 是否注册 server reflection
+	HealthService  bool          `yaml:"health_service"`  // 是否注册 grpc.health.v1
+	DefaultTimeout time.Duration `yaml:"default_timeout"` // 客户端未设置截止时间时使用
+	MaxTimeout     time.Duration `yaml:"max_timeout"`     // 客户端截止时间的上限
+}
+
+// AuthConfig 认证配置，HTTP 与 gRPC 共用
+type AuthConfig struct {
+	Enabled     bool        `yaml:"enabled"`
+	Tokens      []AuthToken `yaml:"tokens"`
+	SkipPaths   []string    `yaml:"skip_paths"`   // 免认证的 HTTP 路径
+	SkipMethods []string    `yaml:"skip_methods"` // 免认证的 gRPC 方法，以 "/" 结尾表示整个服务
+}
+
+// AuthToken 静态 API token
+type AuthToken struct {
+	Name   string `yaml:"name"`
+	Token  string `yaml:"token"`
+	Tenant string `yaml:"tenant"` // 非空时 token 只能访问该租户，且不能访问平台管理接口
+}
+
+// LogConfig 日志配置
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+	Output string `yaml:"output"`
+}
+
+// TracingConfig 链路追踪配置
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	ServiceName string  `yaml:"service_name"`
+	Exporter    string  `yaml:"exporter"`     // stdout, file, none
+	FilePath    string  `yaml:"file_path"`    // exporter 为 file 时的输出文件
+	SampleRatio float64 `yaml:"sample_ratio"` // 0.0 - 1.0
+}
+
+// HealthConfig 健康检查配置
+type HealthConfig struct {
+	CheckTimeout time.Duration `yaml:"check_timeout"` // 单个检查项超时
+	CacheTTL     time.Duration `yaml:"cache_ttl"`     // 检查结果缓存时间
+	DrainDelay   time.Duration `yaml:"drain_delay"`   // 就绪检查置为失败后，等待负载均衡摘流的时间
+}
+
+// APIConfig HTTP API 版本配置
+type APIConfig struct {
+	Versions map[string]APIVersionConfig `yaml:"versions"` // 键为版本名，例如 v1
+}
+
+// APIVersionConfig 单个 API 版本的弃用计划
+type APIVersionConfig struct {
+	Deprecated   bool      `yaml:"deprecated"`
+	DeprecatedAt time.Time `yaml:"deprecated_at"` // 弃用生效时间，输出 Deprecation 响应头
+	Sunset       time.Time `yaml:"sunset"`        // 计划下线时间，输出 Sunset 响应头
+	Successor    string    `yaml:"successor"`     // 替代版本，例如 v2
+}
+
+// EventsConfig 领域事件配置
+type EventsConfig struct {
+	RelayInterval time.Duration `yaml:"relay_interval"` // outbox 轮询间隔
+	BatchSize     int           `yaml:"batch_size"`     // 每次最多投递的事件数
+	MaxAttempts   int           `yaml:"max_attempts"`   // 最大投递次数，0 表示不限制
+	MaxBackoff    time.Duration `yaml:"max_backoff"`    // 失败重试的最大间隔
+	Publisher     string        `yaml:"publisher"`      // none, file
+	FilePath      string        `yaml:"file_path"`      // publisher 为 file 时的输出文件（NDJSON）
+}
+
+// WebhooksConfig webhook 投递配置
+type WebhooksConfig struct {
+	Interval    time.Duration `yaml:"interval"`     // 投递任务轮询间隔
+	BatchSize   int           `yaml:"batch_size"`   // 每次最多发送的投递数
+	MaxAttempts int           `yaml:"max_attempts"` // 最大尝试次数，超过后进入死信列表
+	MaxBackoff  time.Duration `yaml:"max_backoff"`  // 失败重试的最大间隔
+	Timeout     time.Duration `yaml:"timeout"`      // 单次请求超时
+	Retention   time.Duration `yaml:"retention"`    // 投递成功的记录保留时间，过期后由定时任务清理
+	// AllowPrivateTargets 允许回调 URL 指向环回、私有和链路本地地址，仅用于本地开发和测试
+	AllowPrivateTargets bool `yaml:"allow_private_targets"`
+}
+
+// JobsConfig 定时任务配置
+type JobsConfig struct {
+	Workers        int           `yaml:"workers"`         // 同时运行的任务数上限
+	DefaultTimeout time.Duration `yaml:"default_timeout"` // 任务未设置超时时使用
+	Leader         bool          `yaml:"leader"`          // 是否执行只在主副本运行的任务，多副本部署时只在一个副本上开启
+}
+
+// CacheConfig 用户、优惠券按 ID 查询的读缓存配置
+type CacheConfig struct {
+	Enabled     bool          `yaml:"enabled"`
+	Backend     string        `yaml:"backend"`      // memory: 进程内 LRU
+	Capacity    int           `yaml:"capacity"`     // memory 后端的最大条目数
+	TTL         time.Duration `yaml:"ttl"`          // 缓存有效期
+	NegativeTTL time.Duration `yaml:"negative_ttl"` // 记录不存在时的缓存有效期，0 表示不缓存
+}
+
+// TenancyConfig 多租户配置
+// 租户依次取自 token 绑定的租户、请求头、子域名和 default，未启用时所有请求属于 default 租户
+type TenancyConfig struct {
+	Enabled    bool           `yaml:"enabled"`
+	Header     string         `yaml:"header"`      // 指定租户的请求头，gRPC 使用同名 metadata
+	BaseDomain string         `yaml:"base_domain"` // 非空时从 <tenant>.<base_domain> 形式的 Host 解析租户
+	Default    string         `yaml:"default"`     // 未指定租户时使用，为空时这类请求不能访问租户数据
+	Tenants    []TenantConfig `yaml:"tenants"`     // 启动时创建的租户
+}
+
+// TenantConfig 启动时创建的租户
+type TenantConfig struct {
+	ID         string `yaml:"id"`
+	Name       string `yaml:"name"`
+	Currency   string `yaml:"currency"` // ISO 4217，默认 CNY
+	Locale     string `yaml:"locale"`   // 请求未指定语言时使用，默认 zh-CN
+	MaxUsers   int    `yaml:"max_users"`
+	MaxCoupons int    `yaml:"max_coupons"`
+}
+
+// Default 返回默认配置
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			Name:    "Rich_GO",
+			Version: "1.0.0",
+			Env:     "development",
+		},
+		Server: ServerConfig{
+			Host:            "0.0.0.0",
+			Port:            8080,
+			ReadTimeout:     30 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
+			RequestTimeout:  30 * time.Second,
+			MaxBodyBytes:    1 << 20,
+			CORS: CORSConfig{
+				Enabled:       false,
+				AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+				AllowHeaders:  []string{"Authorization", "Content-Type", "Accept-Language", "traceparent", "X-Request-Id", "X-Tenant-Id"},
+				ExposeHeaders: []string{"X-Trace-Id", "X-Request-Id", "X-API-Version", "Deprecation", "Sunset", "Link"},
+				MaxAge:        10 * time.Minute,
+			},
+			Security: SecurityConfig{
+				Enabled:               true,
+				HSTSMaxAge:            365 * 24 * time.Hour,
+				FrameOptions:          "DENY",
+				ReferrerPolicy:        "no-referrer",
+				ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+				DocsContentSecurity: "default-src 'self'; script-src 'self' https://cdn.redoc.ly; " +
+					"style-src 'self' 'unsafe-inline' https://fonts.googleapis.com; font-src 'self' data: https://fonts.gstatic.com; " +
+					"img-src 'self' data: https:; worker-src 'self' blob:; frame-ancestors 'none'",
+			},
+		},
+		GRPC: GRPCConfig{
+			Enabled:        true,
+			Host:           "0.0.0.0",
+			Port:           9090,
+			Reflection:     true,
+			HealthService:  true,
+			DefaultTimeout: 30 * time.Second,
+			MaxTimeout:     2 * time.Minute,
+		},
+		Auth: AuthConfig{
+			Enabled:   false,
+			SkipPaths: []string{"/health", "/livez", "/readyz", "/metrics", "/openapi.json", "/docs"},
+			SkipMethods: []string{
+				"/grpc.health.v1.Health/",
+				"/grpc.reflection.v1.ServerReflection/",
+				"/grpc.reflection.v1alpha.ServerReflection/",
+			},
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+			Output: "stdout",
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			ServiceName: "rich_go",
+			Exporter:    "stdout",
+			FilePath:    "logs/traces.json",
+			SampleRatio: 1.0,
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2 * time.Second,
+			CacheTTL:     1 * time.Second,
+			DrainDelay:   5 * time.Second,
+		},
+		Events: EventsConfig{
+			RelayInterval: 500 * time.Millisecond,
+			BatchSize:     100,
+			MaxAttempts:   10,
+			MaxBackoff:    time.Minute,
+			Publisher:     "none",
+			FilePath:      "logs/events.ndjson",
+		},
+		Webhooks: WebhooksConfig{
+			Interval:    time.Second,
+			BatchSize:   50,
+			MaxAttempts: 8,
+			MaxBackoff:  10 * time.Minute,
+			Timeout:     10 * time.Second,
+			Retention:   7 * 24 * time.Hour,
+		},
+		Jobs: JobsConfig{
+			Workers:        4,
+			DefaultTimeout: 5 * time.Minute,
+			Leader:         true,
+		},
+		Cache: CacheConfig{
+			Enabled:     true,
+			Backend:     "memory",
+			Capacity:    10000,
+			TTL:         5 * time.Minute,
+			NegativeTTL: 30 * time.Second,
+		},
+		Tenancy: TenancyConfig{
+			Enabled: false,
+			Header:  "X-Tenant-Id",
+			Default: "default",
+			Tenants: []TenantConfig{{ID: "default", Name: "默认租户"}},
+		},
+	}
+}
+
+// Load 从文件加载配置，未设置的字段使用默认值
+// path 为空时依次使用环境变量 RICH_GO_CONFIG 和 DefaultPath；默认路径文件不存在时返回默认配置
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	explicit := path != ""
+	if !explicit {
+		if env := os.Getenv(EnvConfigPath); env != "" {
+			path = env
+			explicit = true
+		} else {
+			path = DefaultPath
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate 校验配置
+func (c *Config) Validate() error {
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		return fmt.Errorf("无效的服务端口: %d", c.Server.Port)
+	}
+	if c.Server.RequestTimeout < 0 || c.Server.MaxBodyBytes < 0 {
+		return errors.New("request_timeout 和 max_body_bytes 不能为负数")
+	}
+	if c.Server.CORS.Enabled && len(c.Server.CORS.AllowOrigins) == 0 {
+		return errors.New("启用 CORS 时必须配置 allow_origins")
+	}
+	if c.Server.CORS.AllowCredentials && slices.Contains(c.Server.CORS.AllowOrigins, "*") {
+		return errors.New(`CORS 允许携带凭证时 allow_origins 不能包含 "*"`)
+	}
+	if c.GRPC.Enabled && (c.GRPC.Port <= 0 || c.GRPC.Port > 65535) {
+		return fmt.Errorf("无效的 gRPC 端口: %d", c.GRPC.Port)
+	}
+	if c.Auth.Enabled && len(c.Auth.Tokens) == 0 {
+		return errors.New("启用认证时必须配置至少一个 token")
+	}
+	switch c.Tracing.Exporter {
+	case "stdout", "file", "none":
+	default:
+		return fmt.Errorf("不支持的 tracing exporter: %s", c.Tracing.Exporter)
+	}
+	if c.Tracing.Exporter == "file" && c.Tracing.FilePath == "" {
+		return errors.New("tracing exporter 为 file 时必须配置 file_path")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("无效的采样率: %v", c.Tracing.SampleRatio)
+	}
+	switch c.Events.Publisher {
+	case "none", "file":
+	default:
+		return fmt.Errorf("不支持的事件 publisher: %s", c.Events.Publisher)
+	}
+	if c.Events.Publisher == "file" && c.Events.FilePath == "" {
+		return errors.New("事件 publisher 为 file 时必须配置 file_path")
+	}
+	if c.Events.RelayInterval <= 0 || c.Events.BatchSize <= 0 || c.Events.MaxAttempts < 0 {
+		return errors.New("events 的 relay_interval 和 batch_size 必须为正数，max_attempts 不能为负数")
+	}
+	if c.Webhooks.Interval <= 0 || c.Webhooks.BatchSize <= 0 || c.Webhooks.MaxAttempts <= 0 || c.Webhooks.Timeout <= 0 {
+		return errors.New("webhooks 的 interval、batch_size、max_attempts 和 timeout 必须为正数")
+	}
+	if c.Webhooks.Retention < 0 {
+		return errors.New("webhooks.retention 不能为负数")
+	}
+	if c.Jobs.Workers <= 0 || c.Jobs.DefaultTimeout <= 0 {
+		return errors.New("jobs 的 workers 和 default_timeout 必须为正数")
+	}
+	if c.Cache.Enabled {
+		if c.Cache.Backend != "memory" {
+			return fmt.Errorf("不支持的缓存 backend: %s", c.Cache.Backend)
+		}
+		if c.Cache.Capacity <= 0 || c.Cache.TTL <= 0 || c.Cache.NegativeTTL < 0 {
+			return errors.New("cache 的 capacity 和 ttl 必须为正数，negative_ttl 不能为负数")
+		}
+	}
+	if err := c.Tenancy.validate(c.Auth); err != nil {
+		return err
+	}
+	for name, v := range c.API.Versions {
+		if !v.Sunset.IsZero() && !v.DeprecatedAt.IsZero() && v.Sunset.Before(v.DeprecatedAt) {
+			return fmt.Errorf("API 版本 %s 的下线时间早于弃用时间", name)
+		}
+	}
+	return nil
+}
+
+// Addr 返回 HTTP 监听地址
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
+}
+
+// Addr 返回 gRPC 监听地址
+func (g GRPCConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", g.Host, g.Port)
+}
+
+// validate 校验多租户配置，default 和 token 绑定的租户须在 tenants 中
+func (t TenancyConfig) validate(auth AuthConfig) error {
+	if !t.Enabled && t.Default == "" {
+		return errors.New("未启用多租户时必须配置 tenancy.default")
+	}
+	ids := make(map[string]bool, len(t.Tenants))
+	for _, tc := range t.Tenants {
+		if tc.ID == "" || tc.Name == "" {
+			return errors.New("tenancy.tenants 的 id 和 name 不能为空")
+		}
+		if ids[tc.ID] {
+			return fmt.Errorf("重复的租户: %s", tc.ID)
+		}
+		if tc.MaxUsers < 0 || tc.MaxCoupons < 0 {
+			return fmt.Errorf("租户 %s 的 max_users 和 max_coupons 不能为负数", tc.ID)
+		}
+		ids[tc.ID] = true
+	}
+	if t.Default != "" && !ids[t.Default] {
+		return fmt.Errorf("tenancy.default 租户 %s 不在 tenancy.tenants 中", t.Default)
+	}
+	for _, token := range auth.Tokens {
+		if token.Tenant == "" {
+			continue
+		}
+		if !t.Enabled {
+			return fmt.Errorf("token %s 绑定了租户，需要启用多租户", token.Name)
+		}
+		if !ids[token.Tenant] {
+			return fmt.Errorf("token %s 绑定的租户 %s 不在 tenancy.tenants 中", token.Name, token.Tenant)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// AllEvents 订阅全部事件类型
+const AllEvents = "*"
+
+// DefaultAsyncBuffer 异步订阅者的默认队列长度
+const DefaultAsyncBuffer = 256
+
+// ErrBusClosed 总线已关闭
+var ErrBusClosed = errors.New("event bus closed")
+
+// Handler 事件处理函数
+type Handler func(ctx context.Context, env Envelope) error
+
+// Bus 进程内事件总线
+// 同步订阅者在 Publish 中依次执行，任一返回错误时 Publish 返回错误，事件会由 Relay 重新投递；
+// 异步订阅者在独立的 goroutine 中按顺序处理，错误只记录日志，不影响投递结果
+type Bus struct {
+	mu     sync.RWMutex
+	sync   map[string][]Handler
+	async  map[string][]*asyncSubscriber
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type asyncSubscriber struct {
+	name  string
+	queue chan queued
+}
+
+type queued struct {
+	ctx context.Context
+	env Envelope
+}
+
+// NewBus 创建事件总线
+func NewBus() *Bus {
+	return &Bus{
+		sync:  make(map[string][]Handler),
+		async: make(map[string][]*asyncSubscriber),
+	}
+}
+
+// Subscribe 注册同步订阅者，eventType 为 AllEvents 时订阅全部事件
+// 重新投递时同步订阅者可能重复收到事件，需保证幂等
+func (b *Bus) Subscribe(eventType string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sync[eventType] = append(b.sync[eventType], h)
+}
+
+// SubscribeAsync 注册异步订阅者，name 用于日志；buffer 为队列长度，<=0 时使用 DefaultAsyncBuffer
+// 队列满时 Publish 阻塞等待，直到入队或 ctx 取消
+func (b *Bus) SubscribeAsync(eventType, name string, h Handler, buffer int) {
+	if buffer <= 0 {
+		buffer = DefaultAsyncBuffer
+	}
+	sub := &asyncSubscriber{name: name, queue: make(chan queued, buffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.async[eventType] = append(b.async[eventType], sub)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for q := range sub.queue {
+			if err := safeCall(h, q.ctx, q.env); err != nil {
+				log.Printf("异步事件订阅者 %s 处理 %s(%s) 失败: %v", sub.name, q.env.Type, q.env.ID, err)
+			}
+		}
+	}()
+}
+
+// Publish 将事件分发给订阅者
+func (b *Bus) Publish(ctx context.Context, env Envelope) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrBusClosed
+	}
+
+	var errs []error
+	for _, h := range concat(b.sync[env.Type], b.sync[AllEvents]) {
+		if err := safeCall(h, ctx, env); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("同步订阅者处理事件 %s 失败: %w", env.Type, errors.Join(errs...))
+	}
+
+	// 异步订阅者不应随投递请求一同取消
+	asyncCtx := context.WithoutCancel(ctx)
+	for _, sub := range concat(b.async[env.Type], b.async[AllEvents]) {
+		select {
+		case sub.queue <- queued{ctx: asyncCtx, env: env}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Close 停止接收事件，等待异步订阅者处理完队列中的事件
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, subs := range b.async {
+		for _, sub := range subs {
+			close(sub.queue)
+		}
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+// safeCall 调用订阅者，panic 转换为错误
+func safeCall(h Handler, ctx context.Context, env Envelope) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("订阅者 panic: %v", r)
+		}
+	}()
+	return h(ctx, env)
+}
+
+func concat[T any](a, b []T) []T {
+	if len(b) == 0 {
+		return a
+	}
+	return append(append(make([]T, 0, len(a)+len(b)), a...), b...)
+}
@@ -0,0 +1,113 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testEnvelope(t *testing.T, typ string) Envelope {
+	t.Helper()
+	return Envelope{ID: newID(), Type: typ, OccurredAt: time.Now().UTC()}
+}
+
+func TestBusSyncSubscribers(t *testing.T) {
+	bus := NewBus()
+	defer bus.Close()
+
+	var got []string
+	bus.Subscribe("user.created", func(ctx context.Context, env Envelope) error {
+		got = append(got, "typed:"+env.Type)
+		return nil
+	})
+	bus.Subscribe(AllEvents, func(ctx context.Context, env Envelope) error {
+		got = append(got, "all:"+env.Type)
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), testEnvelope(t, "user.created")); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if err := bus.Publish(context.Background(), testEnvelope(t, "coupon.deleted")); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	// 同步订阅者在 Publish 返回前按注册顺序执行
+	want := []string{"typed:user.created", "all:user.created", "all:coupon.deleted"}
+	if len(got) != len(want) {
+		t.Fatalf("订阅者收到 %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("第 %d 次调用 %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBusSyncSubscriberErrorFailsPublish(t *testing.T) {
+	bus := NewBus()
+	defer bus.Close()
+
+	errBoom := errors.New("boom")
+	calls := 0
+	bus.Subscribe(AllEvents, func(ctx context.Context, env Envelope) error { return errBoom })
+	bus.Subscribe(AllEvents, func(ctx context.Context, env Envelope) error { panic("oops") })
+	bus.Subscribe(AllEvents, func(ctx context.Context, env Envelope) error {
+		calls++
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), testEnvelope(t, "user.created"))
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Publish = %v, want 包含 %v", err, errBoom)
+	}
+	// 一个订阅者失败或 panic 不影响其他订阅者
+	if calls != 1 {
+		t.Errorf("其余订阅者调用 %d 次, want 1", calls)
+	}
+}
+
+func TestBusAsyncSubscribers(t *testing.T) {
+	bus := NewBus()
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []string
+	bus.SubscribeAsync(AllEvents, "slow", func(ctx context.Context, env Envelope) error {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, env.ID)
+		return errors.New("异步订阅者的错误只记录日志")
+	}, 0)
+
+	// 异步订阅者未处理完时 Publish 已返回且不受其错误影响
+	ctx, cancel := context.WithCancel(context.Background())
+	var ids []string
+	for range 3 {
+		env := testEnvelope(t, "user.created")
+		ids = append(ids, env.ID)
+		if err := bus.Publish(ctx, env); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	// 发布方取消不影响已入队的事件
+	cancel()
+	close(release)
+
+	// Close 等待队列中的事件处理完
+	bus.Close()
+	if len(got) != len(ids) {
+		t.Fatalf("异步订阅者处理 %d 个事件, want %d", len(got), len(ids))
+	}
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Errorf("第 %d 个事件为 %s, want %s（按发布顺序处理）", i, got[i], ids[i])
+		}
+	}
+
+	if err := bus.Publish(context.Background(), testEnvelope(t, "user.created")); !errors.Is(err, ErrBusClosed) {
+		t.Errorf("关闭后 Publish = %v, want %v", err, ErrBusClosed)
+	}
+}
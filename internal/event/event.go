@@ -0,0 +1,105 @@
+// Package event 领域事件
+// 服务层在写入业务数据的同一事务中将事件记录到 outbox，由 Relay 投递到进程内总线和外部发布器，保证至少投递一次
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"rich_go/internal/model"
+)
+
+// 事件类型
+const (
+	TypeUserCreated   = "user.created"
+	TypeUserUpdated   = "user.updated"
+	TypeUserDeleted   = "user.deleted"
+	TypeCouponCreated = "coupon.created"
+	TypeCouponUpdated = "coupon.updated"
+	TypeCouponDeleted = "coupon.deleted"
+)
+
+// Event 领域事件
+type Event interface {
+	EventType() string
+}
+
+// UserCreated 用户已创建
+type UserCreated struct {
+	User model.User `json:"user"`
+}
+
+// UserUpdated 用户已更新，User 为更新后的数据
+type UserUpdated struct {
+	User model.User `json:"user"`
+}
+
+// UserDeleted 用户已删除
+type UserDeleted struct {
+	UserID uint `json:"userId"`
+}
+
+// CouponCreated 优惠券已创建
+type CouponCreated struct {
+	Coupon model.Coupon `json:"coupon"`
+}
+
+// CouponUpdated 优惠券已更新，Coupon 为更新后的数据，状态变更（如停用）也通过该事件通知
+type CouponUpdated struct {
+	Coupon model.Coupon `json:"coupon"`
+}
+
+// CouponDeleted 优惠券已删除
+type CouponDeleted struct {
+	CouponID uint `json:"couponId"`
+}
+
+func (UserCreated) EventType() string   { return TypeUserCreated }
+func (UserUpdated) EventType() string   { return TypeUserUpdated }
+func (UserDeleted) EventType() string   { return TypeUserDeleted }
+func (CouponCreated) EventType() string { return TypeCouponCreated }
+func (CouponUpdated) EventType() string { return TypeCouponUpdated }
+func (CouponDeleted) EventType() string { return TypeCouponDeleted }
+
+// Envelope 投递给订阅者和发布器的事件信封
+// 至少投递一次意味着同一事件可能重复到达，消费方应按 ID 去重
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Tenant     string          `json:"tenant,omitempty"` // 事件所属的租户，webhook 只投递给同一租户的订阅
+	TraceID    string          `json:"traceId,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// NewEnvelope 序列化事件并生成信封
+func NewEnvelope(ev Event, traceID string) (Envelope, error) {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("序列化事件 %s 失败: %w", ev.EventType(), err)
+	}
+	return Envelope{
+		ID:         newID(),
+		Type:       ev.EventType(),
+		OccurredAt: time.Now().UTC(),
+		TraceID:    traceID,
+		Payload:    payload,
+	}, nil
+}
+
+// Decode 将 Payload 反序列化为具体事件，例如 env.Decode(&event.UserCreated{})
+func (e Envelope) Decode(ev Event) error {
+	if ev.EventType() != e.Type {
+		return fmt.Errorf("事件类型不匹配: 期望 %s，实际 %s", ev.EventType(), e.Type)
+	}
+	return json.Unmarshal(e.Payload, ev)
+}
+
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
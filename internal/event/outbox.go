@@ -0,0 +1,182 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"rich_go/internal/metrics"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+	"rich_go/internal/tracing"
+)
+
+// Recorder 记录领域事件，在 Transactor.WithinTx 中调用时随事务提交写入 outbox
+type Recorder interface {
+	Record(ctx context.Context, events ...Event) error
+}
+
+// outboxRecorder 将事件写入 outbox 的 Recorder
+type outboxRecorder struct {
+	outbox repository.OutboxRepository
+}
+
+// NewOutboxRecorder 创建写入 outbox 的 Recorder
+func NewOutboxRecorder(outbox repository.OutboxRepository) Recorder {
+	return &outboxRecorder{outbox: outbox}
+}
+
+func (r *outboxRecorder) Record(ctx context.Context, events ...Event) error {
+	traceID := tracing.TraceID(ctx)
+	records := make([]*repository.OutboxRecord, 0, len(events))
+	for _, ev := range events {
+		env, err := NewEnvelope(ev, traceID)
+		if err != nil {
+			return err
+		}
+		env.Tenant = tenant.ID(ctx)
+		payload, err := json.Marshal(env)
+		if err != nil {
+			return fmt.Errorf("序列化事件信封失败: %w", err)
+		}
+		records = append(records, &repository.OutboxRecord{
+			EventID:   env.ID,
+			Type:      env.Type,
+			Payload:   payload,
+			CreatedAt: env.OccurredAt,
+		})
+	}
+	return r.outbox.Append(ctx, records...)
+}
+
+// RelayOptions 投递任务参数
+type RelayOptions struct {
+	Interval    time.Duration // 轮询间隔
+	BatchSize   int           // 每次最多读取的事件数
+	MaxAttempts int           // 最大投递次数，超过后放弃并记录日志，0 表示不限制
+	MaxBackoff  time.Duration // 失败重试的最大间隔
+}
+
+// drainTimeout 退出前投递剩余事件的最长时间
+const drainTimeout = 5 * time.Second
+
+// Relay 从 outbox 读取事件并投递给所有发布器，全部成功后才标记完成
+type Relay struct {
+	outbox     repository.OutboxRepository
+	publishers []Publisher
+	opts       RelayOptions
+}
+
+// NewRelay 创建投递任务
+func NewRelay(outbox repository.OutboxRepository, opts RelayOptions, publishers ...Publisher) *Relay {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Minute
+	}
+	return &Relay{outbox: outbox, publishers: publishers, opts: opts}
+}
+
+// Run 按间隔轮询 outbox，ctx 取消后尽力投递剩余事件再返回
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), drainTimeout)
+			defer cancel()
+			_, err := r.Flush(drainCtx)
+			return err
+		case <-ticker.C:
+			if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("读取 outbox 失败: %v", err)
+			}
+		}
+	}
+}
+
+// Flush 投递所有到期的事件，返回投递成功的数量
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		records, err := r.outbox.FetchPending(ctx, time.Now(), r.opts.BatchSize)
+		if err != nil {
+			return delivered, err
+		}
+		if len(records) == 0 {
+			return delivered, nil
+		}
+		for _, rec := range records {
+			if ctx.Err() != nil {
+				return delivered, ctx.Err()
+			}
+			if r.deliver(ctx, rec) {
+				delivered++
+			}
+		}
+		// 失败的事件已推迟到下次重试时间，不会在本轮被重复读取
+		if len(records) < r.opts.BatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// deliver 投递单个事件并更新 outbox 状态
+func (r *Relay) deliver(ctx context.Context, rec *repository.OutboxRecord) bool {
+	err := r.publish(ctx, rec)
+	if err == nil {
+		metrics.ObserveEventDelivery(rec.Type, "delivered")
+		if err := r.outbox.MarkDelivered(ctx, rec.ID); err != nil {
+			log.Printf("标记事件 %s 投递成功失败: %v", rec.EventID, err)
+		}
+		return true
+	}
+
+	attempts := rec.Attempts + 1
+	if r.opts.MaxAttempts > 0 && attempts >= r.opts.MaxAttempts {
+		metrics.ObserveEventDelivery(rec.Type, "dead")
+		log.Printf("事件 %s(%s) 投递 %d 次仍失败，放弃投递: %v", rec.Type, rec.EventID, attempts, err)
+		if err := r.outbox.MarkDead(ctx, rec.ID, err.Error()); err != nil {
+			log.Printf("标记事件 %s 放弃投递失败: %v", rec.EventID, err)
+		}
+		return false
+	}
+
+	metrics.ObserveEventDelivery(rec.Type, "failed")
+	next := time.Now().Add(r.backoff(attempts))
+	if err := r.outbox.MarkFailed(ctx, rec.ID, err.Error(), next); err != nil {
+		log.Printf("记录事件 %s 投递失败: %v", rec.EventID, err)
+	}
+	return false
+}
+
+func (r *Relay) publish(ctx context.Context, rec *repository.OutboxRecord) error {
+	var env Envelope
+	if err := json.Unmarshal(rec.Payload, &env); err != nil {
+		return fmt.Errorf("解析事件信封失败: %w", err)
+	}
+	for _, p := range r.publishers {
+		if err := p.Publish(ctx, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backoff 指数退避并加入随机抖动，不超过 MaxBackoff
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.opts.Interval << min(attempts-1, 20)
+	if d <= 0 || d > r.opts.MaxBackoff {
+		d = r.opts.MaxBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
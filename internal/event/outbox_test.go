@@ -0,0 +1,160 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+)
+
+// readEvents 读取文件发布器写入的事件
+func readEvents(t *testing.T, path string) []Envelope {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var events []Envelope
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var env Envelope
+		if err := json.Unmarshal(sc.Bytes(), &env); err != nil {
+			t.Fatalf("解析事件 %q: %v", sc.Text(), err)
+		}
+		events = append(events, env)
+	}
+	return events
+}
+
+// flushUntilEmpty 反复投递直到 outbox 中没有待投递事件，返回投递轮数
+func flushUntilEmpty(t *testing.T, relay *Relay, outbox repository.OutboxRepository) int {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for rounds := 1; ; rounds++ {
+		if _, err := relay.Flush(context.Background()); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+		if n, _ := outbox.Pending(context.Background()); n == 0 {
+			return rounds
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("outbox 中的事件未投递完")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRelayAtLeastOnce(t *testing.T) {
+	outbox := repository.NewOutboxRepository()
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	file, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// 总线的同步订阅者第一次处理失败
+	bus := NewBus()
+	defer bus.Close()
+	var received []Envelope
+	bus.Subscribe(AllEvents, func(ctx context.Context, env Envelope) error {
+		received = append(received, env)
+		if len(received) == 1 {
+			return errors.New("暂时不可用")
+		}
+		return nil
+	})
+
+	ctx := tenant.WithID(context.Background(), "acme")
+	if err := NewOutboxRecorder(outbox).Record(ctx, UserCreated{User: model.User{ID: 1, Name: "alice"}}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	relay := NewRelay(outbox, RelayOptions{Interval: time.Millisecond, MaxAttempts: 5}, file, bus)
+
+	if n, _ := relay.Flush(context.Background()); n != 0 {
+		t.Fatalf("订阅者失败时 Flush = %d, want 0", n)
+	}
+	if n, _ := outbox.Pending(context.Background()); n != 1 {
+		t.Fatalf("失败后待投递 %d 个事件, want 1", n)
+	}
+	flushUntilEmpty(t, relay, outbox)
+
+	// 失败后整体重新投递，已成功的文件发布器再次收到同一事件
+	written := readEvents(t, path)
+	if len(written) != 2 || written[0].ID != written[1].ID {
+		t.Fatalf("文件发布器写入 %d 个事件, want 同一事件 2 次", len(written))
+	}
+	if len(received) != 2 || received[1].ID != written[0].ID {
+		t.Fatalf("订阅者收到 %d 次, want 2", len(received))
+	}
+	env := written[0]
+	var ev UserCreated
+	if env.Type != "user.created" || env.Tenant != "acme" || env.Decode(&ev) != nil || ev.User.Name != "alice" {
+		t.Errorf("事件为 %+v, want acme 的 user.created", env)
+	}
+}
+
+func TestRelayRetriesThenGivesUp(t *testing.T) {
+	outbox := repository.NewOutboxRepository()
+	attempts := 0
+	failing := PublisherFunc(func(ctx context.Context, env Envelope) error {
+		attempts++
+		return errors.New("下游不可用")
+	})
+
+	ctx := tenant.WithID(context.Background(), "acme")
+	if err := NewOutboxRecorder(outbox).Record(ctx, CouponDeleted{CouponID: 1}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	relay := NewRelay(outbox, RelayOptions{Interval: time.Millisecond, MaxAttempts: 3}, failing)
+
+	// 第 1 次失败后推迟到退避时间之后，本轮不会重复投递
+	relay.Flush(context.Background())
+	if attempts != 1 {
+		t.Fatalf("第 1 轮投递 %d 次, want 1", attempts)
+	}
+	records, _ := outbox.FetchPending(context.Background(), time.Now().Add(time.Hour), 10)
+	if len(records) != 1 || records[0].Attempts != 1 || records[0].LastError == "" || !records[0].NextAttemptAt.After(time.Now().Add(-time.Millisecond)) {
+		t.Fatalf("失败后的记录为 %+v, want 1 次失败并推迟重试", records)
+	}
+
+	flushUntilEmpty(t, relay, outbox)
+	if attempts != 3 {
+		t.Errorf("放弃前投递 %d 次, want 3", attempts)
+	}
+	// 放弃后不再投递
+	time.Sleep(5 * time.Millisecond)
+	relay.Flush(context.Background())
+	if attempts != 3 {
+		t.Errorf("放弃后又投递了 %d 次", attempts-3)
+	}
+}
+
+func TestRecordRolledBackWithTransaction(t *testing.T) {
+	outbox := repository.NewOutboxRepository()
+	recorder := NewOutboxRecorder(outbox)
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	errAbort := errors.New("abort")
+	err := repository.NewTransactor().WithinTx(ctx, func(ctx context.Context) error {
+		if err := recorder.Record(ctx, UserDeleted{UserID: 1}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithinTx = %v, want %v", err, errAbort)
+	}
+	if n, _ := outbox.Pending(ctx); n != 0 {
+		t.Errorf("回滚后待投递 %d 个事件, want 0", n)
+	}
+}
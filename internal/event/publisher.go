@@ -0,0 +1,61 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Publisher 外部发布器，Relay 将 outbox 中的事件逐个投递给所有发布器
+// 返回错误时事件会被重新投递，已成功的发布器也可能再次收到同一事件
+type Publisher interface {
+	Publish(ctx context.Context, env Envelope) error
+}
+
+// PublisherFunc 函数形式的 Publisher
+type PublisherFunc func(ctx context.Context, env Envelope) error
+
+func (f PublisherFunc) Publish(ctx context.Context, env Envelope) error {
+	return f(ctx, env)
+}
+
+// FilePublisher 以 NDJSON 格式将事件追加到本地文件，用于开发和测试
+type FilePublisher struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFilePublisher 创建文件发布器，目录不存在时自动创建
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("创建事件输出目录失败: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("打开事件输出文件失败: %w", err)
+	}
+	return &FilePublisher{f: f}, nil
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, env Envelope) error {
+	line, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.f.Write(line)
+	return err
+}
+
+// Close 关闭文件
+func (p *FilePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.f.Close()
+}
@@ -0,0 +1,243 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 检查状态
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker 健康检查接口，由仓储、连接池、缓存、后台任务等组件实现
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc 函数形式的 Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check 实现 Checker 接口
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Option 注册检查项时的可选配置
+type Option func(*check)
+
+// WithTimeout 设置单个检查项的超时时间
+func WithTimeout(d time.Duration) Option {
+	return func(c *check) { c.timeout = d }
+}
+
+// WithCacheTTL 设置检查结果的缓存时间，0 表示不缓存
+func WithCacheTTL(d time.Duration) Option {
+	return func(c *check) { c.cacheTTL = d }
+}
+
+// WithLiveness 将检查项同时纳入存活检查（/livez）
+// 只应用于进程自身无法恢复的故障，例如后台任务卡死
+func WithLiveness() Option {
+	return func(c *check) { c.liveness = true }
+}
+
+// CheckResult 单个检查项结果
+type CheckResult struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Cached    bool      `json:"cached"`
+}
+
+// Report 健康检查报告
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Healthy 报告是否健康
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// check 已注册的检查项
+type check struct {
+	name     string
+	checker  Checker
+	timeout  time.Duration
+	cacheTTL time.Duration
+	liveness bool
+
+	mu     sync.Mutex
+	last   CheckResult
+	hasRun bool
+}
+
+// Registry 健康检查注册中心
+type Registry struct {
+	mu     sync.RWMutex
+	checks []*check
+
+	defaultTimeout  time.Duration
+	defaultCacheTTL time.Duration
+	shuttingDown    atomic.Bool
+}
+
+// NewRegistry 创建健康检查注册中心
+func NewRegistry(defaultTimeout, defaultCacheTTL time.Duration) *Registry {
+	return &Registry{
+		defaultTimeout:  defaultTimeout,
+		defaultCacheTTL: defaultCacheTTL,
+	}
+}
+
+// Register 注册检查项
+func (r *Registry) Register(name string, checker Checker, opts ...Option) {
+	c := &check{
+		name:     name,
+		checker:  checker,
+		timeout:  r.defaultTimeout,
+		cacheTTL: r.defaultCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, c)
+}
+
+// SetShuttingDown 标记服务正在关闭，之后就绪检查始终返回未就绪
+func (r *Registry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown 服务是否正在关闭
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Liveness 执行存活检查
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.run(ctx, func(c *check) bool { return c.liveness })
+}
+
+// Readiness 执行就绪检查，关闭过程中直接返回未就绪
+func (r *Registry) Readiness(ctx context.Context) Report {
+	report := r.run(ctx, func(*check) bool { return true })
+	if r.ShuttingDown() {
+		report.Status = StatusDown
+		report.Checks["shutdown"] = CheckResult{
+			Status:    StatusDown,
+			Error:     "服务正在关闭",
+			Duration:  "0s",
+			CheckedAt: time.Now(),
+		}
+	}
+	return report
+}
+
+// run 并发执行满足条件的检查项
+func (r *Registry) run(ctx context.Context, include func(*check) bool) Report {
+	r.mu.RLock()
+	selected := make([]*check, 0, len(r.checks))
+	for _, c := range r.checks {
+		if include(c) {
+			selected = append(selected, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(selected))
+	var wg sync.WaitGroup
+	for i, c := range selected {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(selected)),
+	}
+	for i, c := range selected {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// run 执行单个检查项，命中缓存时直接返回上次结果
+func (c *check) run(ctx context.Context) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hasRun && c.cacheTTL > 0 && time.Since(c.last.CheckedAt) < c.cacheTTL {
+		cached := c.last
+		cached.Cached = true
+		return cached
+	}
+
+	parent := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				errCh <- panicError{value: rec}
+			}
+		}()
+		errCh <- c.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		Duration:  time.Since(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	// 调用方取消（如客户端断开）导致的失败不代表依赖不可用，不缓存；检查项自身超时仍然缓存
+	if parent.Err() == nil {
+		c.last = result
+		c.hasRun = true
+	}
+	return result
+}
+
+// panicError 检查项 panic 时返回的错误
+type panicError struct {
+	value interface{}
+}
+
+func (e panicError) Error() string {
+	return fmt.Sprintf("检查项 panic: %v", e.value)
+}
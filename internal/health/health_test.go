@@ -0,0 +1,141 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLivenessReadinessTransitions(t *testing.T) {
+	var dbErr, workerErr error
+	r := NewRegistry(time.Second, 0)
+	r.Register("db", CheckerFunc(func(context.Context) error { return dbErr }))
+	r.Register("worker", CheckerFunc(func(context.Context) error { return workerErr }), WithLiveness())
+
+	down := errors.New("down")
+	steps := []struct {
+		name         string
+		dbErr        error
+		workerErr    error
+		shuttingDown bool
+		wantLive     string
+		wantReady    string
+	}{
+		{"全部正常", nil, nil, false, StatusUp, StatusUp},
+		{"依赖故障只影响就绪", down, nil, false, StatusUp, StatusDown},
+		{"依赖恢复", nil, nil, false, StatusUp, StatusUp},
+		{"存活检查项故障同时影响存活和就绪", nil, down, false, StatusDown, StatusDown},
+		{"存活检查项恢复", nil, nil, false, StatusUp, StatusUp},
+		{"关闭中只影响就绪", nil, nil, true, StatusUp, StatusDown},
+		{"关闭后依赖正常仍未就绪", nil, nil, true, StatusUp, StatusDown},
+	}
+	// 各步骤依次执行，验证状态随检查项变化而转换
+	for _, step := range steps {
+		dbErr, workerErr = step.dbErr, step.workerErr
+		if step.shuttingDown {
+			r.SetShuttingDown()
+		}
+		ctx := context.Background()
+
+		live := r.Liveness(ctx)
+		if live.Status != step.wantLive {
+			t.Errorf("%s: 存活检查为 %s, want %s", step.name, live.Status, step.wantLive)
+		}
+		if _, ok := live.Checks["db"]; ok {
+			t.Errorf("%s: 存活检查包含了只用于就绪的 db", step.name)
+		}
+		ready := r.Readiness(ctx)
+		if ready.Status != step.wantReady {
+			t.Errorf("%s: 就绪检查为 %s, want %s", step.name, ready.Status, step.wantReady)
+		}
+		if _, ok := ready.Checks["shutdown"]; ok != step.shuttingDown {
+			t.Errorf("%s: 就绪检查中 shutdown 项存在 = %v, want %v", step.name, ok, step.shuttingDown)
+		}
+	}
+}
+
+func TestCheckResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		checker   Checker
+		wantUp    bool
+		wantError string
+	}{
+		{"成功", CheckerFunc(func(context.Context) error { return nil }), true, ""},
+		{"返回错误", CheckerFunc(func(context.Context) error { return errors.New("连接失败") }), false, "连接失败"},
+		{"panic", CheckerFunc(func(context.Context) error { panic("boom") }), false, "检查项 panic: boom"},
+		{"超时", CheckerFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}), false, context.DeadlineExceeded.Error()},
+		{"心跳正常", NewHeartbeat(time.Minute), true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(10*time.Millisecond, 0)
+			r.Register("dep", tt.checker)
+			result := r.Readiness(context.Background()).Checks["dep"]
+			if (result.Status == StatusUp) != tt.wantUp || result.Error != tt.wantError {
+				t.Errorf("检查结果为 %+v, want up=%v error=%q", result, tt.wantUp, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestHeartbeatExpires(t *testing.T) {
+	h := NewHeartbeat(20 * time.Millisecond)
+	if err := h.Check(context.Background()); err != nil {
+		t.Fatalf("刚创建的心跳检查: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := h.Check(context.Background()); err == nil {
+		t.Error("超过 maxAge 未上报时心跳检查通过, want 错误")
+	}
+	h.Beat()
+	if err := h.Check(context.Background()); err != nil {
+		t.Errorf("重新上报后心跳检查: %v", err)
+	}
+}
+
+func TestCancelledCheckNotCached(t *testing.T) {
+	var healthy atomic.Bool
+	r := NewRegistry(time.Second, time.Minute)
+	r.Register("db", CheckerFunc(func(ctx context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := r.Readiness(ctx); report.Healthy() {
+		t.Fatalf("请求已取消时就绪检查为 %+v, want down", report)
+	}
+
+	// 调用方取消导致的失败不缓存，下一次请求重新执行检查
+	healthy.Store(true)
+	report := r.Readiness(context.Background())
+	if !report.Healthy() || report.Checks["db"].Cached {
+		t.Errorf("取消后的下一次就绪检查为 %+v, want 重新执行且 up", report)
+	}
+}
+
+func TestTimedOutCheckCached(t *testing.T) {
+	r := NewRegistry(10*time.Millisecond, time.Minute)
+	r.Register("db", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	if report := r.Readiness(context.Background()); report.Healthy() {
+		t.Fatalf("检查项超时时就绪检查为 %+v, want down", report)
+	}
+	report := r.Readiness(context.Background())
+	if report.Healthy() || !report.Checks["db"].Cached {
+		t.Errorf("检查项超时后的下一次就绪检查为 %+v, want 命中缓存的 down", report)
+	}
+}
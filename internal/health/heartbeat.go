@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat 后台任务心跳检查
+// 后台任务定期调用 Beat，超过 maxAge 未上报即视为不健康
+type Heartbeat struct {
+	maxAge time.Duration
+	last   atomic.Int64
+}
+
+// NewHeartbeat 创建心跳检查
+func NewHeartbeat(maxAge time.Duration) *Heartbeat {
+	h := &Heartbeat{maxAge: maxAge}
+	h.Beat()
+	return h
+}
+
+// Beat 上报心跳
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// Check 实现 Checker 接口
+func (h *Heartbeat) Check(ctx context.Context) error {
+	age := time.Since(time.Unix(0, h.last.Load()))
+	if age > h.maxAge {
+		return fmt.Errorf("心跳超时: 已 %s 未上报", age.Truncate(time.Millisecond))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// 传输协议
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// 缓存读取结果
+const (
+	CacheHit         = "hit"
+	CacheNegativeHit = "negative_hit" // 命中“记录不存在”的缓存
+	CacheMiss        = "miss"
+	CacheError       = "error" // 缓存不可用或值无法解析，直接读取仓储
+)
+
+// Registry 项目使用的 Prometheus 注册表
+var Registry = prometheus.NewRegistry()
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rich_go",
+		Name:      "requests_total",
+		Help:      "按传输协议、方法和结果码统计的请求总数",
+	}, []string{"transport", "method", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rich_go",
+		Name:      "request_duration_seconds",
+		Help:      "请求处理耗时",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"transport", "method"})
+
+	panicsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rich_go",
+		Name:      "panics_total",
+		Help:      "被恢复的 panic 次数",
+	}, []string{"transport"})
+
+	apiVersionRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rich_go",
+		Name:      "api_version_requests_total",
+		Help:      "按 API 版本、路由和调用方统计的 HTTP 请求总数，用于判断旧版本何时可以下线",
+	}, []string{"version", "deprecated", "route", "caller"})
+
+	eventDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rich_go",
+		Name:      "event_deliveries_total",
+		Help:      "按事件类型和结果统计的 outbox 事件投递次数",
+	}, []string{"type", "result"})
+
+	jobRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rich_go",
+		Name:      "job_runs_total",
+		Help:      "按任务和结果统计的定时任务运行次数，包括被跳过的运行",
+	}, []string{"job", "result"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rich_go",
+		Name:      "job_duration_seconds",
+		Help:      "定时任务运行耗时",
+		Buckets:   []float64{0.01, 0.1, 0.5, 1, 5, 15, 60, 300},
+	}, []string{"job"})
+
+	cacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rich_go",
+		Name:      "cache_requests_total",
+		Help:      "按缓存名称和结果统计的读缓存请求次数",
+	}, []string{"cache", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDuration,
+		panicsTotal,
+		apiVersionRequestsTotal,
+		eventDeliveriesTotal,
+		jobRunsTotal,
+		jobDuration,
+		cacheRequestsTotal,
+	)
+}
+
+// ObserveRequest 记录一次请求，HTTP 与 gRPC 共用
+// method 对 HTTP 为 "GET /api/v1/users/:id"，对 gRPC 为完整方法名；code 为 HTTP 状态码或 gRPC 状态码名称
+func ObserveRequest(transport, method, code string, latency time.Duration) {
+	requestsTotal.WithLabelValues(transport, method, code).Inc()
+	requestDuration.WithLabelValues(transport, method).Observe(latency.Seconds())
+}
+
+// ObservePanic 记录一次被恢复的 panic
+func ObservePanic(transport string) {
+	panicsTotal.WithLabelValues(transport).Inc()
+}
+
+// ObserveAPIVersion 记录一次版本化 API 请求
+// route 为 "GET /api/v1/users/:id"；caller 为认证调用方名称，未认证时为 anonymous
+func ObserveAPIVersion(version string, deprecated bool, route, caller string) {
+	apiVersionRequestsTotal.WithLabelValues(version, strconv.FormatBool(deprecated), route, caller).Inc()
+}
+
+// ObserveEventDelivery 记录一次事件投递，result 为 delivered、failed 或 dead
+func ObserveEventDelivery(eventType, result string) {
+	eventDeliveriesTotal.WithLabelValues(eventType, result).Inc()
+}
+
+// ObserveJobRun 记录一次定时任务运行
+func ObserveJobRun(job, result string, latency time.Duration) {
+	jobRunsTotal.WithLabelValues(job, result).Inc()
+	jobDuration.WithLabelValues(job).Observe(latency.Seconds())
+}
+
+// ObserveJobSkipped 记录一次被跳过的定时任务运行
+func ObserveJobSkipped(job, result string) {
+	jobRunsTotal.WithLabelValues(job, result).Inc()
+}
+
+// ObserveCache 记录一次读缓存请求，result 为 CacheHit 等常量
+func ObserveCache(cache, result string) {
+	cacheRequestsTotal.WithLabelValues(cache, result).Inc()
+}
+
+// Handler 返回 Prometheus 指标暴露接口
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+
+	"rich_go/internal/audit"
+	"rich_go/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader 请求 ID 的请求头及 gRPC metadata 名称，客户端未提供时使用 trace ID 或随机生成
+const RequestIDHeader = "X-Request-Id"
+
+// maxRequestIDLength 客户端提供的请求 ID 的最大长度，超过时忽略
+const maxRequestIDLength = 128
+
+// callerName 返回已认证调用方的名称，未认证时为 anonymous
+func callerName(ctx context.Context) string {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return principal.Name
+	}
+	return audit.ActorAnonymous
+}
+
+// requestID 优先使用客户端提供的可打印 ASCII 请求 ID，其次使用 trace ID，都没有时随机生成
+func requestID(ctx context.Context, provided string) string {
+	if validRequestID(provided) {
+		return provided
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		return traceID
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// validRequestID 判断客户端提供的请求 ID 是否可用
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// AuditContext 将调用方、请求 ID 和客户端 IP 写入 context 供审计日志使用，需在认证之后执行
+func AuditContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id := requestID(ctx, c.GetHeader(RequestIDHeader))
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(audit.WithRequest(ctx, audit.Request{
+			Actor:     callerName(ctx),
+			RequestID: id,
+			ClientIP:  c.ClientIP(),
+		}))
+		c.Next()
+	}
+}
+
+// grpcAuditContext 从认证结果、metadata 的 x-request-id 和对端地址构建审计信息
+func grpcAuditContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var provided string
+	if values := md.Get(RequestIDHeader); len(values) > 0 {
+		provided = values[0]
+	}
+	clientIP := peerAddr(ctx)
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		clientIP = host
+	}
+	return audit.WithRequest(ctx, audit.Request{
+		Actor:     callerName(ctx),
+		RequestID: requestID(ctx, provided),
+		ClientIP:  clientIP,
+	})
+}
+
+// UnaryAuditContext 审计信息拦截器，对应 HTTP 的 AuditContext
+func UnaryAuditContext() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(grpcAuditContext(ctx), req)
+	}
+}
+
+// StreamAuditContext 流式审计信息拦截器
+func StreamAuditContext() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, withStreamContext(ss, grpcAuditContext(ss.Context())))
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Principal 已认证的调用方
+type Principal struct {
+	Name   string
+	Tenant string // token 绑定的租户，为空时为平台调用方，可通过请求指定租户
+}
+
+// Authenticator 认证器，HTTP 与 gRPC 共用
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// staticTokenAuthenticator 基于静态 token 的认证器
+type staticTokenAuthenticator struct {
+	tokens map[string]Principal // token -> 调用方
+}
+
+// NewStaticTokenAuthenticator 创建基于静态 token 的认证器
+func NewStaticTokenAuthenticator(tokens map[string]Principal) Authenticator {
+	return &staticTokenAuthenticator{tokens: tokens}
+}
+
+func (a *staticTokenAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for candidate, principal := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			p := principal
+			return &p, nil
+		}
+	}
+	return nil, errors.ErrUnauthorized
+}
+
+type principalKey struct{}
+
+// WithPrincipal 将调用方写入 context
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext 从 context 中获取调用方
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// authenticate 解析 "Bearer <token>" 形式的凭证并认证
+func authenticate(ctx context.Context, authn Authenticator, authorization string) (context.Context, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return ctx, errors.ErrUnauthorized
+	}
+	principal, err := authn.Authenticate(ctx, token)
+	if err != nil {
+		return ctx, err
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+// Auth 认证中间件，skipPaths 中的路径无需认证
+func Auth(authn Authenticator, skipPaths []string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if _, ok := skip[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+
+		ctx, err := authenticate(c.Request.Context(), authn, c.GetHeader("Authorization"))
+		if err != nil {
+			response.ErrorFrom(c, errors.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// RequireAuth 只对 prefixes 下的路径认证，未启用认证时用于保护平台管理接口
+func RequireAuth(authn Authenticator, prefixes []string) gin.HandlerFunc {
+	auth := Auth(authn, nil)
+	return func(c *gin.Context) {
+		if !platformPath(c.Request.URL.Path, prefixes) {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"rich_go/internal/metrics"
+	"rich_go/internal/tracing"
+	"rich_go/pkg/errors"
+)
+
+// 本文件为 HTTP 中间件与 gRPC 拦截器共用的核心逻辑
+
+// logRequest 记录请求日志
+// verb 对 HTTP 为请求方法，对 gRPC 为 "gRPC"；target 为路径或完整方法名
+func logRequest(ctx context.Context, verb, target, peer string, code interface{}, latency time.Duration) {
+	log.Printf("[%s] %s %s %v %v trace_id=%s",
+		verb,
+		target,
+		peer,
+		code,
+		latency,
+		tracing.TraceID(ctx),
+	)
+}
+
+// logPanic 记录被恢复的 panic 及堆栈，并计入指标
+func logPanic(ctx context.Context, transport string, recovered interface{}) {
+	metrics.ObservePanic(transport)
+	log.Printf("Panic recovered: %v trace_id=%s\n%s", recovered, tracing.TraceID(ctx), debug.Stack())
+}
+
+// resolveError 将错误归一化为业务错误，内部错误输出原始错误链和调用栈
+// 非业务错误统一转换为内部错误，避免向客户端暴露内部信息
+func resolveError(ctx context.Context, err error) *errors.BusinessError {
+	be, ok := errors.AsBusinessError(err)
+	if !ok {
+		be = errors.Internal(err)
+	}
+	if be.HTTPStatus() >= http.StatusInternalServerError {
+		log.Printf("Error: %+v trace_id=%s", be, tracing.TraceID(ctx))
+	}
+	return be
+}
+
+// applyDeadline 为请求设置截止时间
+// 未设置截止时间时使用 defaultTimeout，超过 maxTimeout 时截断；参数为 0 表示不限制
+func applyDeadline(ctx context.Context, defaultTimeout, maxTimeout time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	switch {
+	case !ok && defaultTimeout > 0:
+		return context.WithTimeout(ctx, defaultTimeout)
+	case ok && maxTimeout > 0 && time.Until(deadline) > maxTimeout:
+		return context.WithTimeout(ctx, maxTimeout)
+	default:
+		return ctx, func() {}
+	}
+}
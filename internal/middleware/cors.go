@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSOptions 跨域选项
+type CORSOptions struct {
+	AllowOrigins     []string // 支持 "*" 和 "https://*.example.com"
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORS 跨域中间件，需在认证中间件之前执行，保证预检请求无需认证
+// 不允许的来源不输出 CORS 响应头，由浏览器拦截
+func CORS(opts CORSOptions) gin.HandlerFunc {
+	allowMethods := strings.Join(opts.AllowMethods, ", ")
+	allowHeaders := strings.Join(opts.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(opts.ExposeHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+	allowAll := slices.Contains(opts.AllowOrigins, "*")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+		if !allowAll && !originAllowed(origin, opts.AllowOrigins) {
+			c.Next()
+			return
+		}
+
+		// 允许携带凭证时不能返回 "*"
+		if allowAll && !opts.AllowCredentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if opts.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		// 预检请求直接返回
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+			header.Set("Access-Control-Allow-Methods", allowMethods)
+			if allowHeaders != "" {
+				header.Set("Access-Control-Allow-Headers", allowHeaders)
+			}
+			if opts.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
+
+// originAllowed 判断来源是否允许，"https://*.example.com" 匹配任意子域名
+func originAllowed(origin string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if strings.EqualFold(pattern, origin) {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(pattern, "*"); ok &&
+			len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORS(t *testing.T) {
+	opts := CORSOptions{
+		AllowOrigins:     []string{"https://app.example.com", "https://*.example.org"},
+		AllowMethods:     []string{http.MethodGet, http.MethodPost},
+		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		ExposeHeaders:    []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+	tests := []struct {
+		name        string
+		opts        CORSOptions
+		method      string
+		origin      string
+		wantStatus  int
+		wantHeaders map[string]string // 值为空表示不应输出
+	}{
+		{"允许的来源预检", opts, http.MethodOptions, "https://app.example.com", http.StatusNoContent, map[string]string{
+			"Access-Control-Allow-Origin":      "https://app.example.com",
+			"Access-Control-Allow-Credentials": "true",
+			"Access-Control-Allow-Methods":     "GET, POST",
+			"Access-Control-Allow-Headers":     "Authorization, Content-Type",
+			"Access-Control-Max-Age":           "600",
+			"Access-Control-Expose-Headers":    "",
+		}},
+		{"通配子域名预检", opts, http.MethodOptions, "https://shop.example.org", http.StatusNoContent, map[string]string{
+			"Access-Control-Allow-Origin":  "https://shop.example.org",
+			"Access-Control-Allow-Methods": "GET, POST",
+		}},
+		{"通配不匹配根域名", opts, http.MethodOptions, "https://example.org", http.StatusOK, map[string]string{
+			"Access-Control-Allow-Origin":  "",
+			"Access-Control-Allow-Methods": "",
+		}},
+		{"通配不匹配其他协议", opts, http.MethodOptions, "http://shop.example.org", http.StatusOK, map[string]string{
+			"Access-Control-Allow-Origin": "",
+		}},
+		{"拒绝的来源预检", opts, http.MethodOptions, "https://evil.example.com", http.StatusOK, map[string]string{
+			"Access-Control-Allow-Origin":      "",
+			"Access-Control-Allow-Credentials": "",
+			"Access-Control-Allow-Methods":     "",
+		}},
+		{"允许的来源普通请求", opts, http.MethodGet, "https://app.example.com", http.StatusOK, map[string]string{
+			"Access-Control-Allow-Origin":   "https://app.example.com",
+			"Access-Control-Expose-Headers": "X-Request-ID",
+			"Access-Control-Allow-Methods":  "",
+		}},
+		{"没有 Origin", opts, http.MethodGet, "", http.StatusOK, map[string]string{
+			"Access-Control-Allow-Origin": "",
+			"Vary":                        "",
+		}},
+		{"允许所有来源", CORSOptions{AllowOrigins: []string{"*"}, AllowMethods: []string{http.MethodGet}}, http.MethodOptions, "https://any.test", http.StatusNoContent, map[string]string{
+			"Access-Control-Allow-Origin":      "*",
+			"Access-Control-Allow-Credentials": "",
+			"Access-Control-Max-Age":           "",
+		}},
+		{"允许所有来源且携带凭证时回显来源", CORSOptions{AllowOrigins: []string{"*"}, AllowCredentials: true}, http.MethodGet, "https://any.test", http.StatusOK, map[string]string{
+			"Access-Control-Allow-Origin":      "https://any.test",
+			"Access-Control-Allow-Credentials": "true",
+		}},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(CORS(tt.opts))
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			engine.GET("/coupons", ok)
+			engine.OPTIONS("/coupons", ok)
+
+			req := httptest.NewRequest(tt.method, "/coupons", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("状态码 = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for name, want := range tt.wantHeaders {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+			if tt.origin != "" && w.Header().Values("Vary")[0] != "Origin" {
+				t.Errorf("Vary = %v, want 包含 Origin", w.Header().Values("Vary"))
+			}
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package middleware
 
 import (
-	"log"
+	"rich_go/internal/metrics"
+	"rich_go/internal/repository"
 	"rich_go/pkg/errors"
 	"rich_go/pkg/response"
 
@@ -18,24 +19,22 @@ func ErrorHandler() gin.HandlerFunc {
 		// 检查是否有通过 c.Error() 设置的错误
 		if len(c.Errors) > 0 {
 			err := c.Errors.Last()
-			
-			// 记录错误日志
-			log.Printf("Error: %v", err.Error())
 
-			// 处理业务错误
-			if be, ok := errors.AsBusinessError(err.Err); ok {
-				response.Error(c, be.Code, be.Message)
+			// Handler 已输出响应（例如 response.ErrorFrom），只记录日志
+			if c.Writer.Written() {
+				resolveError(c.Request.Context(), err.Err)
 				return
 			}
 
-			// 处理标准库错误
-			if err.Err == errors.ErrRecordNotFound {
-				response.NotFound(c, "资源不存在")
+			// 未被 Service 层转换的记录不存在错误
+			if errors.Is(err.Err, repository.ErrNotFound) {
+				response.ErrorFrom(c, errors.ErrNotFound.WithCause(err.Err))
 				return
 			}
 
-			// 默认错误处理
-			response.InternalServerError(c, "内部服务器错误")
+			// 记录错误日志并归一化为业务错误，HTTP 状态码由错误码注册表决定
+			be := resolveError(c.Request.Context(), err.Err)
+			response.ErrorFrom(c, be)
 		}
 	}
 }
@@ -43,9 +42,8 @@ func ErrorHandler() gin.HandlerFunc {
 // Recovery 恢复中间件（增强版）
 func Recovery() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		log.Printf("Panic recovered: %v", recovered)
-		response.InternalServerError(c, "服务器内部错误")
+		logPanic(c.Request.Context(), metrics.TransportHTTP, recovered)
+		response.ErrorFrom(c, errors.ErrInternalError)
 		c.Abort()
 	})
 }
-
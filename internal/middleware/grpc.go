@@ -0,0 +1,280 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"rich_go/internal/metrics"
+	"rich_go/internal/tracing"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// 本文件为与 HTTP 中间件对应的 gRPC 拦截器，核心逻辑见 common.go
+
+// wrappedStream 允许拦截器替换流的 context
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+// withStreamContext 返回使用新 context 的流
+func withStreamContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
+
+// metadataCarrier 将 gRPC metadata 适配为 OpenTelemetry TextMapCarrier
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// startServerSpan 从 metadata 提取 traceparent 并开启 server span，对应 HTTP 的 Tracing
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md.Copy()))
+	return tracing.Tracer().Start(ctx, fullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.method", fullMethod),
+		),
+	)
+}
+
+// endServerSpan 记录 gRPC 状态码并结束 span
+func endServerSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, code.String())
+	}
+	span.End()
+}
+
+// peerAddr 返回调用方地址
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// UnaryTracing 链路追踪拦截器
+func UnaryTracing() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer func() { endServerSpan(span, err) }()
+		return handler(ctx, req)
+	}
+}
+
+// StreamTracing 流式链路追踪拦截器
+func StreamTracing() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		defer func() { endServerSpan(span, err) }()
+		return handler(srv, withStreamContext(ss, ctx))
+	}
+}
+
+// UnaryRecovery 恢复拦截器，对应 HTTP 的 Recovery
+func UnaryRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logPanic(ctx, metrics.TransportGRPC, recovered)
+				err = errors.ToLocalizedGRPCStatus(errors.ErrInternalError, i18n.LanguageFromContext(ctx))
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery 流式恢复拦截器
+func StreamRecovery() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logPanic(ss.Context(), metrics.TransportGRPC, recovered)
+				err = errors.ToLocalizedGRPCStatus(errors.ErrInternalError, i18n.LanguageFromContext(ss.Context()))
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// UnaryLogger 日志拦截器，对应 HTTP 的 Logger
+func UnaryLogger() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRequest(ctx, "gRPC", info.FullMethod, peerAddr(ctx), status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamLogger 流式日志拦截器
+func StreamLogger() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRequest(ss.Context(), "gRPC", info.FullMethod, peerAddr(ss.Context()), status.Code(err), time.Since(start))
+		return err
+	}
+}
+
+// UnaryMetrics 指标拦截器，对应 HTTP 的 Metrics
+func UnaryMetrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.ObserveRequest(metrics.TransportGRPC, info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamMetrics 流式指标拦截器
+func StreamMetrics() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		metrics.ObserveRequest(metrics.TransportGRPC, info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// toStatusError 将处理器返回的错误转换为按请求语言本地化的 gRPC status
+// 已是 status 的错误原样返回，context 取消或超时转换为对应状态码
+func toStatusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	be := resolveError(ctx, err)
+	return errors.ToLocalizedGRPCStatus(be, i18n.LanguageFromContext(ctx))
+}
+
+// UnaryErrorHandler 错误转换拦截器，对应 HTTP 的 ErrorHandler
+func UnaryErrorHandler() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatusError(ctx, err)
+	}
+}
+
+// StreamErrorHandler 流式错误转换拦截器
+func StreamErrorHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatusError(ss.Context(), handler(srv, ss))
+	}
+}
+
+// UnaryDeadline 截止时间拦截器，为未设置截止时间的请求设置默认值并限制最大值
+func UnaryDeadline(defaultTimeout, maxTimeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := applyDeadline(ctx, defaultTimeout, maxTimeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// StreamDeadline 流式截止时间拦截器
+func StreamDeadline(defaultTimeout, maxTimeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := applyDeadline(ss.Context(), defaultTimeout, maxTimeout)
+		defer cancel()
+		return handler(srv, withStreamContext(ss, ctx))
+	}
+}
+
+// skipGRPCAuth 判断方法是否在免认证列表中，列表项为完整方法名或以 "/" 结尾的服务前缀
+func skipGRPCAuth(fullMethod string, skipMethods []string) bool {
+	for _, m := range skipMethods {
+		if fullMethod == m || (strings.HasSuffix(m, "/") && strings.HasPrefix(fullMethod, m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticateGRPC 从 metadata 的 authorization 中认证调用方
+func authenticateGRPC(ctx context.Context, authn Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, errors.ToLocalizedGRPCStatus(errors.ErrUnauthorized, i18n.LanguageFromContext(ctx))
+	}
+	ctx, err := authenticate(ctx, authn, values[0])
+	if err != nil {
+		return ctx, errors.ToLocalizedGRPCStatus(errors.ErrUnauthorized, i18n.LanguageFromContext(ctx))
+	}
+	return ctx, nil
+}
+
+// UnaryAuth 认证拦截器，对应 HTTP 的 Auth
+func UnaryAuth(authn Authenticator, skipMethods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if skipGRPCAuth(info.FullMethod, skipMethods) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticateGRPC(ctx, authn)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth 流式认证拦截器
+func StreamAuth(authn Authenticator, skipMethods []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skipGRPCAuth(info.FullMethod, skipMethods) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticateGRPC(ss.Context(), authn)
+		if err != nil {
+			return err
+		}
+		return handler(srv, withStreamContext(ss, ctx))
+	}
+}
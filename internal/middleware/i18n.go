@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+
+	"rich_go/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// LanguageParam 用户显式指定语言的查询参数、Cookie 及 gRPC metadata 名称
+const LanguageParam = "lang"
+
+// resolveLanguage 按优先级解析请求语言：用户偏好（lang）> Accept-Language > 默认语言
+func resolveLanguage(preference, acceptLanguage string) string {
+	if lang := i18n.Match(preference); lang != "" {
+		return lang
+	}
+	if lang := i18n.Match(i18n.ParseAcceptLanguage(acceptLanguage)...); lang != "" {
+		return lang
+	}
+	return i18n.DefaultLanguage
+}
+
+// Language 语言解析中间件，将请求语言写入 context 并设置 Content-Language
+// 用户偏好依次取自查询参数 lang 和 Cookie lang
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		preference := c.Query(LanguageParam)
+		if preference == "" {
+			preference, _ = c.Cookie(LanguageParam)
+		}
+
+		lang := resolveLanguage(preference, c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(i18n.WithLanguage(c.Request.Context(), lang))
+		c.Header("Content-Language", lang)
+		c.Next()
+	}
+}
+
+// grpcLanguage 从 metadata 的 lang 和 accept-language 解析请求语言
+func grpcLanguage(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	return i18n.WithLanguage(ctx, resolveLanguage(first(LanguageParam), first("accept-language")))
+}
+
+// UnaryLanguage 语言解析拦截器，对应 HTTP 的 Language
+func UnaryLanguage() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(grpcLanguage(ctx), req)
+	}
+}
+
+// StreamLanguage 流式语言解析拦截器
+func StreamLanguage() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, withStreamContext(ss, grpcLanguage(ss.Context())))
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"rich_go/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		cookie         string
+		acceptLanguage string
+		want           string
+	}{
+		{"未指定时使用默认语言", "", "", "", i18n.DefaultLanguage},
+		{"Accept-Language", "", "", "en-US,en;q=0.9", i18n.LangEnUS},
+		{"Accept-Language 按权重选择", "", "", "zh-CN;q=0.3, en;q=0.8", i18n.LangEnUS},
+		{"Accept-Language 地区变体回退到同语言", "", "", "en-GB", i18n.LangEnUS},
+		{"Accept-Language 跳过不支持的语言", "", "", "fr-FR, de;q=0.9, zh;q=0.5", i18n.LangZhCN},
+		{"Accept-Language 都不支持时使用默认语言", "", "", "fr-FR, de", i18n.DefaultLanguage},
+		{"Cookie 优先于 Accept-Language", "", "en-US", "zh-CN", i18n.LangEnUS},
+		{"查询参数优先于 Cookie", "zh-CN", "en-US", "en-US", i18n.LangZhCN},
+		{"不支持的偏好回退到 Accept-Language", "fr", "", "en-US", i18n.LangEnUS},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			engine := gin.New()
+			engine.Use(Language())
+			engine.GET("/", func(c *gin.Context) { got = i18n.LanguageFromContext(c.Request.Context()) })
+
+			target := "/"
+			if tt.query != "" {
+				target += "?" + LanguageParam + "=" + tt.query
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: LanguageParam, Value: tt.cookie})
+			}
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if got != tt.want {
+				t.Errorf("context 中的语言为 %q, want %q", got, tt.want)
+			}
+			if cl := w.Header().Get("Content-Language"); cl != tt.want {
+				t.Errorf("Content-Language = %q, want %q", cl, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit 请求体大小限制中间件
+// Content-Length 超过上限时直接拒绝，否则读取超过上限时解析失败并返回同样的错误
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			response.ErrorFrom(c, errors.ErrBodyTooLarge)
+			c.Abort()
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+		c.Next()
+	}
+}
+
+// Timeout 请求处理时限中间件，与 gRPC 的 Deadline 拦截器共用 applyDeadline
+// 超时后由处理链通过 context 感知并返回；处理链未输出响应时返回超时错误
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := applyDeadline(c.Request.Context(), timeout, 0)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if !c.Writer.Written() && stderrors.Is(ctx.Err(), context.DeadlineExceeded) {
+			response.ErrorFrom(c, errors.ErrTimeout)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseCode 返回统一响应结构中的业务错误码
+func responseCode(t *testing.T, w *httptest.ResponseRecorder) int {
+	t.Helper()
+	var resp response.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v, body = %s", err, w.Body.String())
+	}
+	return resp.Code
+}
+
+func TestBodyLimit(t *testing.T) {
+	const maxBytes = 16
+	tests := []struct {
+		name       string
+		body       string
+		chunked    bool // 不设置 Content-Length，读取时才发现超出上限
+		wantStatus int
+		wantCode   int
+	}{
+		{"未超出上限", strings.Repeat("a", maxBytes), false, http.StatusOK, 0},
+		{"Content-Length 超出上限", strings.Repeat("a", maxBytes+1), false, http.StatusRequestEntityTooLarge, errors.CodeBodyTooLarge},
+		{"读取时超出上限", strings.Repeat("a", maxBytes+1), true, http.StatusRequestEntityTooLarge, errors.CodeBodyTooLarge},
+		{"分块传输未超出上限", strings.Repeat("a", maxBytes), true, http.StatusOK, 0},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(BodyLimit(maxBytes))
+			engine.POST("/coupons", func(c *gin.Context) {
+				_, err := io.ReadAll(c.Request.Body)
+				var maxBytesErr *http.MaxBytesError
+				switch {
+				case stderrors.As(err, &maxBytesErr):
+					response.ErrorFrom(c, errors.ErrBodyTooLarge)
+				case err != nil:
+					response.ErrorFrom(c, err)
+				default:
+					response.Success(c, nil)
+				}
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/coupons", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("状态码 = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if code := responseCode(t, w); code != tt.wantCode {
+				t.Errorf("code = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		wantStatus int
+		wantCode   int
+	}{
+		{"超时后返回超时错误", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+		}, http.StatusGatewayTimeout, errors.CodeTimeout},
+		{"处理链已输出响应时保留原响应", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			response.Success(c, nil)
+		}, http.StatusOK, 0},
+		{"未超时", func(c *gin.Context) {
+			if _, ok := c.Request.Context().Deadline(); !ok {
+				t.Error("context 没有截止时间")
+			}
+			response.Success(c, nil)
+		}, http.StatusOK, 0},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(Timeout(timeout))
+			engine.GET("/slow", tt.handler)
+
+			start := time.Now()
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("状态码 = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if code := responseCode(t, w); code != tt.wantCode {
+				t.Errorf("code = %d, want %d", code, tt.wantCode)
+			}
+			if tt.wantStatus == http.StatusGatewayTimeout && time.Since(start) < timeout {
+				t.Errorf("%v 后返回, want 不早于 %v", time.Since(start), timeout)
+			}
+		})
+	}
+}
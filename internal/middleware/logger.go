@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"log"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,16 +17,6 @@ func Logger() gin.HandlerFunc {
 		c.Next()
 
 		// 记录日志
-		latency := time.Since(start)
-		status := c.Writer.Status()
-		
-		log.Printf("[%s] %s %s %d %v",
-			method,
-			path,
-			c.ClientIP(),
-			status,
-			latency,
-		)
+		logRequest(c.Request.Context(), method, path, c.ClientIP(), c.Writer.Status(), time.Since(start))
 	}
 }
-
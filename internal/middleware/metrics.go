@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"rich_go/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 请求指标中间件
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		// 使用路由模板而非实际路径，避免标签基数爆炸
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(
+			metrics.TransportHTTP,
+			c.Request.Method+" "+route,
+			strconv.Itoa(c.Writer.Status()),
+			time.Since(start),
+		)
+	}
+}
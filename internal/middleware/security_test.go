@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	opts := SecurityOptions{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "DENY",
+		ReferrerPolicy:        "no-referrer",
+		ContentSecurityPolicy: "default-src 'none'",
+		PathPolicies:          map[string]string{"/docs": "default-src 'self'; script-src 'self'"},
+	}
+	tests := []struct {
+		name        string
+		opts        SecurityOptions
+		path        string
+		tls         bool
+		forwarded   string
+		wantHeaders map[string]string // 值为空表示不应输出
+	}{
+		{"HTTP 请求不输出 HSTS", opts, "/api", false, "", map[string]string{
+			"X-Content-Type-Options":    "nosniff",
+			"X-Frame-Options":           "DENY",
+			"Referrer-Policy":           "no-referrer",
+			"Content-Security-Policy":   "default-src 'none'",
+			"Strict-Transport-Security": "",
+		}},
+		{"HTTPS 请求", opts, "/api", true, "", map[string]string{
+			"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+		}},
+		{"反向代理转发的 HTTPS 请求", opts, "/api", false, "https", map[string]string{
+			"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+		}},
+		{"反向代理转发的 HTTP 请求", opts, "/api", false, "http", map[string]string{
+			"Strict-Transport-Security": "",
+		}},
+		{"不包含子域名", SecurityOptions{HSTSMaxAge: time.Hour}, "/api", true, "", map[string]string{
+			"Strict-Transport-Security": "max-age=3600",
+		}},
+		{"指定路径的 CSP", opts, "/docs", false, "", map[string]string{
+			"Content-Security-Policy": "default-src 'self'; script-src 'self'",
+		}},
+		{"未配置时只输出 nosniff", SecurityOptions{}, "/api", true, "", map[string]string{
+			"X-Content-Type-Options":    "nosniff",
+			"X-Frame-Options":           "",
+			"Referrer-Policy":           "",
+			"Content-Security-Policy":   "",
+			"Strict-Transport-Security": "",
+		}},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(SecurityHeaders(tt.opts))
+			engine.GET(tt.path, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.forwarded)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			for name, want := range tt.wantHeaders {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
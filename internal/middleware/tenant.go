@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"rich_go/internal/model"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TenantResolver 按 ID 查找租户，租户不存在或已停用时返回业务错误，HTTP 与 gRPC 共用
+type TenantResolver interface {
+	ResolveTenant(ctx context.Context, id string) (*model.Tenant, error)
+}
+
+// TenantOptions 租户解析参数
+type TenantOptions struct {
+	Header        string   // 指定租户的请求头及 gRPC metadata，为空时不从请求中读取
+	BaseDomain    string   // 非空时从 <tenant>.<BaseDomain> 形式的 Host 解析租户，仅 HTTP
+	Default       string   // 未指定租户时使用
+	PlatformPaths []string // 平台管理接口的路径前缀，不解析租户，token 绑定了租户的调用方无权访问
+}
+
+// resolveTenantID 按优先级确定租户 ID：token 绑定的租户 > 请求中指定的租户 > 默认租户
+// 请求中指定的租户（请求头、子域名）与 token 绑定的租户或彼此不一致时返回 ErrTenantMismatch
+func resolveTenantID(ctx context.Context, requested []string, defaultID string) (string, error) {
+	var id string
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		id = principal.Tenant
+	}
+	for _, r := range requested {
+		switch {
+		case r == "":
+		case id == "":
+			id = r
+		case r != id:
+			return "", errors.ErrTenantMismatch
+		}
+	}
+	if id == "" {
+		id = defaultID
+	}
+	return id, nil
+}
+
+// withTenant 查找租户并写入 context，id 为空时不写入，之后访问租户数据会返回 ErrTenantRequired
+func withTenant(ctx context.Context, resolver TenantResolver, id string) (context.Context, error) {
+	if id == "" {
+		return ctx, nil
+	}
+	t, err := resolver.ResolveTenant(ctx, id)
+	if err != nil {
+		return ctx, err
+	}
+	return tenant.WithTenant(ctx, t), nil
+}
+
+// tenantLanguage 请求未指定语言时使用租户的默认语言，返回空表示沿用已解析的语言
+func tenantLanguage(ctx context.Context) string {
+	if t, ok := tenant.FromContext(ctx); ok {
+		return i18n.Match(t.Locale)
+	}
+	return ""
+}
+
+// subdomain 从 Host 中解析 <tenant>.<baseDomain> 的租户部分，只匹配一级子域名
+func subdomain(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	label, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(baseDomain))
+	if !ok || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
+
+// platformPath 判断路径是否为平台管理接口
+func platformPath(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tenant 租户解析中间件，需在认证之后执行，skipPaths 中的路径不解析租户
+// 租户写入 context 供仓储隔离数据；请求未通过 lang 或 Accept-Language 指定语言时改用租户的默认语言
+func Tenant(resolver TenantResolver, opts TenantOptions, skipPaths []string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, ok := skip[path]; ok {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+		if platformPath(path, opts.PlatformPaths) {
+			if principal, ok := PrincipalFromContext(ctx); ok && principal.Tenant != "" {
+				response.ErrorFrom(c, errors.ErrForbidden)
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		var requested []string
+		if opts.Header != "" {
+			requested = append(requested, c.GetHeader(opts.Header))
+		}
+		requested = append(requested, subdomain(c.Request.Host, opts.BaseDomain))
+		id, err := resolveTenantID(ctx, requested, opts.Default)
+		if err == nil {
+			ctx, err = withTenant(ctx, resolver, id)
+		}
+		if err != nil {
+			response.ErrorFrom(c, err)
+			c.Abort()
+			return
+		}
+
+		if !explicitLanguage(c) {
+			if lang := tenantLanguage(ctx); lang != "" {
+				ctx = i18n.WithLanguage(ctx, lang)
+				c.Header("Content-Language", lang)
+			}
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// explicitLanguage 判断请求是否通过 lang 或 Accept-Language 指定了语言
+func explicitLanguage(c *gin.Context) bool {
+	if c.Query(LanguageParam) != "" || c.GetHeader("Accept-Language") != "" {
+		return true
+	}
+	_, err := c.Cookie(LanguageParam)
+	return err == nil
+}
+
+// grpcTenant 从认证结果和 metadata 解析租户，对应 HTTP 的 Tenant
+func grpcTenant(ctx context.Context, resolver TenantResolver, opts TenantOptions) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var requested []string
+	if opts.Header != "" {
+		if values := md.Get(opts.Header); len(values) > 0 {
+			requested = append(requested, values[0])
+		}
+	}
+	id, err := resolveTenantID(ctx, requested, opts.Default)
+	if err == nil {
+		ctx, err = withTenant(ctx, resolver, id)
+	}
+	if err != nil {
+		return ctx, errors.ToLocalizedGRPCStatus(err, i18n.LanguageFromContext(ctx))
+	}
+	if len(md.Get(LanguageParam)) == 0 && len(md.Get("accept-language")) == 0 {
+		if lang := tenantLanguage(ctx); lang != "" {
+			ctx = i18n.WithLanguage(ctx, lang)
+		}
+	}
+	return ctx, nil
+}
+
+// UnaryTenant 租户解析拦截器，对应 HTTP 的 Tenant，skipMethods 的格式与 UnaryAuth 相同
+func UnaryTenant(resolver TenantResolver, opts TenantOptions, skipMethods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if skipGRPCAuth(info.FullMethod, skipMethods) {
+			return handler(ctx, req)
+		}
+		ctx, err := grpcTenant(ctx, resolver, opts)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamTenant 流式租户解析拦截器
+func StreamTenant(resolver TenantResolver, opts TenantOptions, skipMethods []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skipGRPCAuth(info.FullMethod, skipMethods) {
+			return handler(srv, ss)
+		}
+		ctx, err := grpcTenant(ss.Context(), resolver, opts)
+		if err != nil {
+			return err
+		}
+		return handler(srv, withStreamContext(ss, ctx))
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fmt"
+
+	"rich_go/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader 响应头中返回的 trace ID
+const TraceIDHeader = "X-Trace-Id"
+
+// Tracing 链路追踪中间件
+// 从请求头提取 W3C traceparent，开启 server span 并写回 traceparent 和 X-Trace-Id
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx,
+			fmt.Sprintf("%s %s", c.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+				attribute.String("user_agent.original", c.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		propagator.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			c.Header(TraceIDHeader, traceID)
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last().Err)
+		}
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+	}
+}
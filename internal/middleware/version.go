@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"rich_go/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API 版本相关响应头
+const (
+	APIVersionHeader  = "X-API-Version"
+	DeprecationHeader = "Deprecation" // RFC 9745
+	SunsetHeader      = "Sunset"      // RFC 8594
+)
+
+// VersionPolicy API 版本的弃用计划
+type VersionPolicy struct {
+	Version      string
+	Deprecated   bool
+	DeprecatedAt time.Time // 为零值时 Deprecation 输出 true
+	Sunset       time.Time // 为零值时不输出 Sunset
+	SuccessorURL string    // 替代版本地址，通过 Link rel="successor-version" 输出
+}
+
+// APIVersion 版本中间件，为响应添加版本及弃用相关响应头，并按版本统计调用量
+func APIVersion(policy VersionPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set(APIVersionHeader, policy.Version)
+		if policy.Deprecated {
+			if policy.DeprecatedAt.IsZero() {
+				header.Set(DeprecationHeader, "true")
+			} else {
+				header.Set(DeprecationHeader, "@"+strconv.FormatInt(policy.DeprecatedAt.Unix(), 10))
+			}
+			if !policy.Sunset.IsZero() {
+				header.Set(SunsetHeader, policy.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if policy.SuccessorURL != "" {
+				header.Add("Link", "<"+policy.SuccessorURL+`>; rel="successor-version"`)
+			}
+		}
+
+		c.Next()
+
+		metrics.ObserveAPIVersion(policy.Version, policy.Deprecated, c.Request.Method+" "+c.FullPath(), callerName(c.Request.Context()))
+	}
+}
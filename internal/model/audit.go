@@ -0,0 +1,47 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// 审计操作
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditEntry 审计日志
+// 每条记录的 Hash 覆盖自身内容和上一条记录的 Hash，任意记录被修改、删除或插入都会使链校验失败
+type AuditEntry struct {
+	ID           uint64        `json:"id"`
+	Timestamp    time.Time     `json:"timestamp"`
+	Tenant       string        `json:"tenant,omitempty"` // 变更数据所属的租户，平台管理操作为空
+	Actor        string        `json:"actor"`
+	Action       string        `json:"action"`
+	ResourceType string        `json:"resourceType"`
+	ResourceID   string        `json:"resourceId"`
+	Changes      []AuditChange `json:"changes"`
+	RequestID    string        `json:"requestId,omitempty"`
+	ClientIP     string        `json:"clientIp,omitempty"`
+	PrevHash     string        `json:"prevHash"`
+	Hash         string        `json:"hash"`
+}
+
+// AuditChange 单个字段的变更，创建时 Before 为 null，删除时 After 为 null
+type AuditChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// ComputeHash 计算记录的哈希，即除 Hash 外所有字段 JSON 序列化后的 SHA-256
+func (e AuditEntry) ComputeHash() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -3,11 +3,13 @@ package model
 // Coupon 优惠券模型
 type Coupon struct {
 	ID           uint    `json:"id"`
+	TenantID     string  `json:"tenantId"`
 	Name         string  `json:"name"`
 	Description  string  `json:"description"`
 	DiscountType string  `json:"discountType"` // fixed: 固定金额, percent: 百分比
 	DiscountValue float64 `json:"discountValue"`
 	MinAmount    float64 `json:"minAmount"`
+	Currency     string  `json:"currency"` // 租户币种，固定金额及最低消费金额的单位
 	Status       string  `json:"status"` // active: 启用, inactive: 禁用
 }
 
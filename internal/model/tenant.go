@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// 租户状态
+const (
+	TenantActive    = "active"
+	TenantSuspended = "suspended" // 停用后该租户的请求被拒绝，数据保留
+)
+
+// Tenant 租户，不同租户的数据相互隔离
+type Tenant struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Currency  string       `json:"currency"` // ISO 4217 币种，固定金额优惠券使用
+	Locale    string       `json:"locale"`   // 默认语言，请求未指定语言时使用
+	Limits    TenantLimits `json:"limits"`
+	Status    string       `json:"status"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// TenantLimits 租户配额，0 表示不限制
+type TenantLimits struct {
+	MaxUsers   int `json:"maxUsers"`
+	MaxCoupons int `json:"maxCoupons"`
+}
@@ -2,8 +2,10 @@ package model
 
 // User 用户模型
 type User struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID       uint   `json:"id"`
+	TenantID string `json:"tenantId"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Status   string `json:"status"` // active: 启用, inactive: 禁用
 }
 
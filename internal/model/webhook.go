@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Webhook webhook 订阅
+type Webhook struct {
+	ID         uint      `json:"id"`
+	TenantID   string    `json:"tenantId"` // 只接收该租户的事件
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"` // 订阅的事件类型，例如 coupon.updated
+	Secret     string    `json:"-"`          // 签名密钥，只在创建时返回
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// 投递状态
+const (
+	DeliveryPending   = "pending"   // 等待投递或等待重试
+	DeliveryDelivered = "delivered" // 接收方返回 2xx
+	DeliveryDead      = "dead"      // 超过重试次数，进入死信列表，可手动重新投递
+	DeliverySkipped   = "skipped"   // 发送前 webhook 已停用，未发送
+)
+
+// WebhookDelivery 一个事件对一个 webhook 的投递记录
+type WebhookDelivery struct {
+	ID            uint              `json:"id"`
+	WebhookID     uint              `json:"webhookId"`
+	TenantID      string            `json:"tenantId"` // 与 webhook 所属租户一致，发送时据此读取 webhook
+	EventID       string            `json:"eventId"`
+	EventType     string            `json:"eventType"`
+	Payload       []byte            `json:"-"` // 请求体
+	Status        string            `json:"status"`
+	AttemptCount  int               `json:"attemptCount"` // 本轮已尝试次数，手动重新投递时清零
+	NextAttemptAt time.Time         `json:"nextAttemptAt"`
+	Attempts      []DeliveryAttempt `json:"attempts"` // 全部投递尝试，按时间顺序
+	CreatedAt     time.Time         `json:"createdAt"`
+	DeliveredAt   *time.Time        `json:"deliveredAt,omitempty"`
+}
+
+// DeliveryAttempt 单次投递尝试
+type DeliveryAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+}
@@ -0,0 +1,193 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"rich_go/internal/router"
+	"rich_go/internal/server"
+)
+
+// Manager 按依赖顺序管理模块的初始化和后台任务
+type Manager struct {
+	modules []Module // 已按依赖排序
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager 创建模块管理器，依赖缺失或存在循环依赖时返回错误
+func NewManager(modules ...Module) (*Manager, error) {
+	sorted, err := sortModules(modules)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{modules: sorted}, nil
+}
+
+// Modules 返回按依赖排序后的模块
+func (m *Manager) Modules() []Module {
+	return m.modules
+}
+
+// Init 依次初始化模块，不执行迁移
+func (m *Manager) Init(c *Context) error {
+	for _, mod := range m.modules {
+		if err := mod.Init(c); err != nil {
+			return fmt.Errorf("初始化模块 %s 失败: %w", mod.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Migrate 按依赖顺序执行模块的迁移
+func (m *Manager) Migrate(ctx context.Context) error {
+	for _, mod := range m.modules {
+		if mig, ok := mod.(Migrator); ok {
+			if err := mig.Migrate(ctx); err != nil {
+				return fmt.Errorf("模块 %s 迁移失败: %w", mod.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Rollback 按依赖的逆序回滚模块的迁移，依赖方先于被依赖方回滚
+func (m *Manager) Rollback(ctx context.Context) error {
+	for i := len(m.modules) - 1; i >= 0; i-- {
+		mod := m.modules[i]
+		if mig, ok := mod.(Migrator); ok {
+			if err := mig.Rollback(ctx); err != nil {
+				return fmt.Errorf("模块 %s 回滚迁移失败: %w", mod.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Migrations 返回实现了迁移的模块名称，按依赖顺序
+func (m *Manager) Migrations() []string {
+	var names []string
+	for _, mod := range m.modules {
+		if _, ok := mod.(Migrator); ok {
+			names = append(names, mod.Name())
+		}
+	}
+	return names
+}
+
+// HTTPRegistrars 返回注册 HTTP 路由的模块
+func (m *Manager) HTTPRegistrars() []router.Registrar {
+	var registrars []router.Registrar
+	for _, mod := range m.modules {
+		if r, ok := mod.(HTTPModule); ok {
+			registrars = append(registrars, r)
+		}
+	}
+	return registrars
+}
+
+// GRPCRegistrars 返回注册 gRPC 服务的模块
+func (m *Manager) GRPCRegistrars() []server.GRPCRegistrar {
+	var registrars []server.GRPCRegistrar
+	for _, mod := range m.modules {
+		if g, ok := mod.(GRPCModule); ok {
+			registrars = append(registrars, g)
+		}
+	}
+	return registrars
+}
+
+// StartJobs 启动所有模块的后台任务
+func (m *Manager) StartJobs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	for _, mod := range m.modules {
+		jm, ok := mod.(JobModule)
+		if !ok {
+			continue
+		}
+		for _, job := range jm.Jobs() {
+			m.wg.Add(1)
+			go func(name string, job Job) {
+				defer m.wg.Done()
+				if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+					log.Printf("后台任务 %s 异常退出: %v", name, err)
+				}
+			}(mod.Name()+"/"+job.Name, job)
+		}
+	}
+}
+
+// StopJobs 停止后台任务并等待退出，ctx 超时后不再等待
+func (m *Manager) StopJobs(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("等待后台任务退出超时: %w", ctx.Err())
+	}
+}
+
+// sortModules 按依赖拓扑排序，同层级保持注册顺序
+func sortModules(modules []Module) ([]Module, error) {
+	byName := make(map[string]Module, len(modules))
+	for _, mod := range modules {
+		if _, dup := byName[mod.Name()]; dup {
+			return nil, fmt.Errorf("模块 %s 重复注册", mod.Name())
+		}
+		byName[mod.Name()] = mod
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(modules))
+	sorted := make([]Module, 0, len(modules))
+
+	var visit func(mod Module, path []string) error
+	visit = func(mod Module, path []string) error {
+		name := mod.Name()
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("模块存在循环依赖: %v", append(path, name))
+		}
+		state[name] = visiting
+		if dep, ok := mod.(Dependent); ok {
+			for _, depName := range dep.DependsOn() {
+				depMod, ok := byName[depName]
+				if !ok {
+					return fmt.Errorf("模块 %s 依赖的模块 %s 未注册", name, depName)
+				}
+				if err := visit(depMod, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = visited
+		sorted = append(sorted, mod)
+		return nil
+	}
+
+	for _, mod := range modules {
+		if err := visit(mod, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
@@ -0,0 +1,195 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testModule 测试用模块，可声明依赖
+type testModule struct {
+	name string
+	deps []string
+}
+
+func (m testModule) Name() string          { return m.name }
+func (m testModule) Init(c *Context) error { return nil }
+func (m testModule) DependsOn() []string   { return m.deps }
+
+// migratingModule 实现 Migrator，将执行的迁移追加到 log
+type migratingModule struct {
+	testModule
+	log *[]string
+	err error
+}
+
+func (m migratingModule) Migrate(ctx context.Context) error {
+	*m.log = append(*m.log, "up "+m.name)
+	return m.err
+}
+
+func (m migratingModule) Rollback(ctx context.Context) error {
+	*m.log = append(*m.log, "down "+m.name)
+	return m.err
+}
+
+func TestMigrateAndRollback(t *testing.T) {
+	var log []string
+	manager, err := NewManager(
+		migratingModule{testModule{name: "coupons", deps: []string{"tenants"}}, &log, nil},
+		testModule{name: "health"},
+		migratingModule{testModule{name: "tenants"}, &log, nil},
+		migratingModule{testModule{name: "events", deps: []string{"coupons"}}, &log, nil},
+	)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	if got, want := fmt.Sprint(manager.Migrations()), "[tenants coupons events]"; got != want {
+		t.Errorf("Migrations = %s, want %s", got, want)
+	}
+	if err := manager.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := manager.Rollback(context.Background()); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	want := "[up tenants up coupons up events down events down coupons down tenants]"
+	if got := fmt.Sprint(log); got != want {
+		t.Errorf("迁移顺序为 %s, want %s", got, want)
+	}
+}
+
+func TestMigrateStopsOnError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		run  func(m *Manager, ctx context.Context) error
+		want string
+	}{
+		{"Migrate", (*Manager).Migrate, "[up a up b]"},
+		{"Rollback", (*Manager).Rollback, "[down c down b]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			manager, err := NewManager(
+				migratingModule{testModule{name: "a"}, &log, nil},
+				migratingModule{testModule{name: "b"}, &log, fmt.Errorf("失败")},
+				migratingModule{testModule{name: "c"}, &log, nil},
+			)
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			if err := tt.run(manager, context.Background()); err == nil {
+				t.Errorf("%s 返回 nil, want 模块 b 的错误", tt.name)
+			}
+			if got := fmt.Sprint(log); got != tt.want {
+				t.Errorf("执行顺序为 %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortModules(t *testing.T) {
+	mod := func(name string, deps ...string) Module { return testModule{name: name, deps: deps} }
+	tests := []struct {
+		name    string
+		modules []Module
+		want    string // 排序后的模块名称
+		wantErr string // 错误中应包含的内容
+	}{
+		{"没有依赖时保持注册顺序", []Module{mod("c"), mod("a"), mod("b")}, "[c a b]", ""},
+		{"被依赖的模块先初始化", []Module{mod("coupons", "tenants"), mod("tenants")}, "[tenants coupons]", ""},
+		{"多层依赖", []Module{mod("events", "coupons"), mod("coupons", "tenants"), mod("tenants")}, "[tenants coupons events]", ""},
+		{"同层级保持注册顺序", []Module{
+			mod("users", "tenants"), mod("coupons", "tenants"), mod("tenants"), mod("audit"), mod("jobs"),
+		}, "[tenants users coupons audit jobs]", ""},
+		{"依赖按声明顺序初始化", []Module{mod("app", "b", "a"), mod("a"), mod("b")}, "[b a app]", ""},
+		{"共同依赖只出现一次", []Module{mod("a", "base"), mod("b", "base"), mod("base")}, "[base a b]", ""},
+		{"空列表", nil, "[]", ""},
+		{"循环依赖", []Module{mod("a", "b"), mod("b", "c"), mod("c", "a")}, "", "循环依赖: [a b c a]"},
+		{"依赖自身", []Module{mod("a", "a")}, "", "循环依赖: [a a]"},
+		{"依赖的模块未注册", []Module{mod("coupons", "tenants")}, "", "模块 coupons 依赖的模块 tenants 未注册"},
+		{"重复注册", []Module{mod("a"), mod("b"), mod("a")}, "", "模块 a 重复注册"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, err := NewManager(tt.modules...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewManager 错误为 %v, want 包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			var names []string
+			for _, m := range manager.Modules() {
+				names = append(names, m.Name())
+			}
+			if got := fmt.Sprint(names); got != tt.want {
+				t.Errorf("模块顺序为 %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// jobModule 提供后台任务的模块
+type jobModule struct {
+	testModule
+	jobs []Job
+}
+
+func (m jobModule) Jobs() []Job { return m.jobs }
+
+func TestStopJobs(t *testing.T) {
+	t.Run("未启动时直接返回", func(t *testing.T) {
+		manager, _ := NewManager(testModule{name: "a"})
+		if err := manager.StopJobs(context.Background()); err != nil {
+			t.Errorf("StopJobs: %v", err)
+		}
+	})
+
+	t.Run("取消后等待任务退出", func(t *testing.T) {
+		stopped := make(chan struct{})
+		manager, _ := NewManager(jobModule{testModule{name: "a"}, []Job{{Name: "tick", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			close(stopped)
+			return ctx.Err()
+		}}}})
+		manager.StartJobs()
+		if err := manager.StopJobs(context.Background()); err != nil {
+			t.Fatalf("StopJobs: %v", err)
+		}
+		select {
+		case <-stopped:
+		default:
+			t.Error("StopJobs 在任务退出前返回")
+		}
+	})
+
+	t.Run("超时后不再等待", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		manager, _ := NewManager(jobModule{testModule{name: "a"}, []Job{{Name: "stuck", Run: func(ctx context.Context) error {
+			<-release // 忽略取消
+			return nil
+		}}}})
+		manager.StartJobs()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := manager.StopJobs(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("StopJobs 错误为 %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("StopJobs 等待了 %v, want 超时后返回", elapsed)
+		}
+	})
+}
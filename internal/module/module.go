@@ -0,0 +1,92 @@
+// Package module 业务模块注册与装配
+// 每个业务域实现 Module，提供自身的构造、路由、gRPC 服务、迁移、健康检查和后台任务，
+// 应用按依赖顺序初始化已注册的模块，新增业务域无需修改服务器代码
+package module
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/router"
+	"rich_go/internal/server"
+)
+
+// Module 业务模块
+type Module interface {
+	// Name 模块名称，用于依赖声明和日志
+	Name() string
+	// Init 构造模块的 Repository、Service 等组件，并通过 Provide 发布供其他模块使用
+	Init(c *Context) error
+}
+
+// Dependent 声明依赖的模块，被依赖的模块先初始化
+type Dependent interface {
+	DependsOn() []string
+}
+
+// Migrator 初始化后执行的数据迁移，服务启动时和 migrate up 命令执行，重复执行应无副作用
+// Rollback 撤销 Migrate 的变更，由 migrate down 命令执行，未迁移时执行应无副作用
+type Migrator interface {
+	Migrate(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// HTTPModule 注册 HTTP 路由及接口描述
+type HTTPModule interface {
+	router.Registrar
+}
+
+// GRPCModule 注册 gRPC 服务
+type GRPCModule interface {
+	server.GRPCRegistrar
+}
+
+// JobModule 提供后台任务，随应用启动和关闭
+type JobModule interface {
+	Jobs() []Job
+}
+
+// Job 后台任务，Run 应在 ctx 取消后返回
+type Job struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Context 模块初始化时可用的依赖，并在模块之间共享组件
+type Context struct {
+	Config *config.Config
+	Health *health.Registry
+
+	components map[reflect.Type]interface{}
+}
+
+// NewContext 创建模块上下文
+func NewContext(cfg *config.Config, healthRegistry *health.Registry) *Context {
+	return &Context{
+		Config:     cfg,
+		Health:     healthRegistry,
+		components: make(map[reflect.Type]interface{}),
+	}
+}
+
+// Provide 发布组件，按类型 T 索引，例如 Provide[service.UserService](c, svc)
+func Provide[T any](c *Context, v T) {
+	c.components[typeOf[T]()] = v
+}
+
+// Resolve 获取其他模块发布的组件，提供方需在 DependsOn 中声明
+func Resolve[T any](c *Context) (T, error) {
+	v, ok := c.components[typeOf[T]()]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("组件 %v 未注册，请检查模块依赖", typeOf[T]())
+	}
+	return v.(T), nil
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,56 @@
+// Package audit 审计日志模块
+// 发布 audit.Recorder 供业务模块在写操作中记录审计日志，审计日志通过 /api/v1/admin/audit 查询
+package audit
+
+import (
+	"net/http"
+
+	"rich_go/internal/audit"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	"rich_go/internal/repository"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	"rich_go/pkg/openapi"
+)
+
+// Name 模块名称，写操作需要记录审计日志的模块应在 DependsOn 中声明
+const Name = "audit"
+
+// Module 审计日志模块
+type Module struct {
+	auditService service.AuditService
+}
+
+// New 创建审计日志模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) Init(c *module.Context) error {
+	auditRepo := repository.NewTracingAuditRepository(repository.NewAuditRepository())
+	c.Health.Register("audit_repository", health.CheckerFunc(auditRepo.Ping))
+
+	m.auditService = service.NewTracingAuditService(service.NewAuditService(auditRepo))
+	module.Provide(c, audit.NewRecorder(auditRepo))
+	return nil
+}
+
+func (m *Module) RegisterRoutes(api *router.API) {
+	handler := handlers.NewAuditHandler(m.auditService)
+	entries := api.Admin("admin").Group("/audit")
+	entries.GET("", openapi.Operation{
+		Summary:  "查询审计日志，支持 tenant、actor、action、resourceType、resourceId、from、to 过滤及分页",
+		Response: handlers.AuditEntryList{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.ListEntries)
+	entries.GET("/verify", openapi.Operation{
+		Summary:  "校验审计日志哈希链是否完整",
+		Response: audit.Verification{},
+	}, handler.VerifyChain)
+}
@@ -0,0 +1,39 @@
+// Package cache 缓存模块
+// 按配置创建缓存后端并发布 cache.Backend，未启用缓存时不发布
+package cache
+
+import (
+	"fmt"
+
+	"rich_go/internal/module"
+	"rich_go/pkg/cache"
+)
+
+// Name 模块名称，使用缓存的模块应在 DependsOn 中声明
+const Name = "cache"
+
+// Module 缓存模块
+type Module struct{}
+
+// New 创建缓存模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) Init(c *module.Context) error {
+	cfg := c.Config.Cache
+	if !cfg.Enabled {
+		return nil
+	}
+	switch cfg.Backend {
+	case "memory":
+		module.Provide[cache.Backend](c, cache.NewLRU(cfg.Capacity))
+	default:
+		return fmt.Errorf("不支持的缓存 backend: %s", cfg.Backend)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package coupon
+
+import (
+	"net/http"
+
+	"rich_go/internal/model"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/openapi"
+)
+
+// setupRoutes 设置 v1 优惠券相关路由及接口描述
+func setupRoutes(v1 *router.Routes, handler *handlers.CouponHandler) {
+	v1.Action("/coupons", "batch", openapi.Operation{
+		Summary:  "批量创建、更新、删除优惠券",
+		Request:  service.BatchRequest{},
+		Response: service.BatchResult{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.BatchCoupons)
+
+	coupons := v1.Group("/coupons")
+	coupons.GET("", openapi.Operation{
+		Summary:  "获取优惠券列表",
+		Response: handlers.CouponList{},
+	}, handler.ListCoupons)
+	coupons.GET("/export", openapi.Operation{
+		Summary:  "导出优惠券",
+		Query:    service.CouponFilter{},
+		Response: model.Coupon{},
+		Produces: bulk.MediaTypes,
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType},
+	}, handler.ExportCoupons)
+	coupons.POST("/import", openapi.Operation{
+		Summary:  "批量导入优惠券",
+		Query:    service.ImportOptions{},
+		Request:  service.CreateCouponRequest{},
+		Consumes: bulk.MediaTypes,
+		Response: service.ImportResult{},
+		Errors:   []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+	}, handler.ImportCoupons)
+	coupons.GET("/:id", openapi.Operation{
+		Summary:  "获取单个优惠券",
+		Response: model.Coupon{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.GetCoupon)
+	coupons.POST("", openapi.Operation{
+		Summary:  "创建优惠券",
+		Request:  service.CreateCouponRequest{},
+		Response: model.Coupon{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.CreateCoupon)
+	coupons.PUT("/:id", openapi.Operation{
+		Summary:  "更新优惠券",
+		Request:  service.UpdateCouponRequest{},
+		Response: model.Coupon{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.UpdateCoupon)
+	coupons.DELETE("/:id", openapi.Operation{
+		Summary:  "删除优惠券",
+		Response: handlers.Deleted{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.DeleteCoupon)
+}
+
+// setupRoutesV2 设置 v2 优惠券相关路由及接口描述
+func setupRoutesV2(v2 *router.Routes, handler *handlers.CouponHandlerV2) {
+	coupons := v2.Group("/coupons")
+	coupons.GET("", openapi.Operation{
+		Summary:  "分页获取优惠券列表",
+		Response: handlers.CouponListV2{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.ListCoupons)
+	coupons.GET("/:id", openapi.Operation{
+		Summary:  "获取单个优惠券",
+		Response: handlers.CouponV2{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.GetCoupon)
+	coupons.POST("", openapi.Operation{
+		Summary:  "创建优惠券",
+		Request:  handlers.CreateCouponRequestV2{},
+		Response: handlers.CouponV2{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.CreateCoupon)
+	coupons.PUT("/:id", openapi.Operation{
+		Summary:  "更新优惠券",
+		Request:  handlers.UpdateCouponRequestV2{},
+		Response: handlers.CouponV2{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.UpdateCoupon)
+	coupons.DELETE("/:id", openapi.Operation{
+		Summary:  "删除优惠券",
+		Response: handlers.Deleted{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.DeleteCoupon)
+}
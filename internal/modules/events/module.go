@@ -0,0 +1,76 @@
+// Package events 领域事件模块
+// 发布 repository.Transactor、event.Recorder 和 *event.Bus 供业务模块使用，并运行 outbox 投递任务
+package events
+
+import (
+	"context"
+	"log"
+
+	"rich_go/internal/event"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	"rich_go/internal/repository"
+)
+
+// Name 模块名称，写操作需要记录事件的模块应在 DependsOn 中声明
+const Name = "events"
+
+// Module 领域事件模块
+type Module struct {
+	bus   *event.Bus
+	relay *event.Relay
+	file  *event.FilePublisher
+}
+
+// New 创建领域事件模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) Init(c *module.Context) error {
+	cfg := c.Config.Events
+	outbox := repository.NewOutboxRepository()
+	c.Health.Register("outbox_repository", health.CheckerFunc(outbox.Ping))
+
+	m.bus = event.NewBus()
+	publishers := []event.Publisher{m.bus}
+	if cfg.Publisher == "file" {
+		file, err := event.NewFilePublisher(cfg.FilePath)
+		if err != nil {
+			return err
+		}
+		m.file = file
+		publishers = append(publishers, file)
+	}
+	m.relay = event.NewRelay(outbox, event.RelayOptions{
+		Interval:    cfg.RelayInterval,
+		BatchSize:   cfg.BatchSize,
+		MaxAttempts: cfg.MaxAttempts,
+		MaxBackoff:  cfg.MaxBackoff,
+	}, publishers...)
+
+	module.Provide(c, repository.NewTransactor())
+	module.Provide(c, event.NewOutboxRecorder(outbox))
+	module.Provide(c, m.bus)
+	return nil
+}
+
+func (m *Module) Jobs() []module.Job {
+	return []module.Job{{Name: "outbox-relay", Run: m.run}}
+}
+
+// run 运行投递任务，退出后关闭总线和发布器
+func (m *Module) run(ctx context.Context) error {
+	err := m.relay.Run(ctx)
+	m.bus.Close()
+	if m.file != nil {
+		if cerr := m.file.Close(); cerr != nil {
+			log.Printf("关闭事件输出文件失败: %v", cerr)
+		}
+	}
+	return err
+}
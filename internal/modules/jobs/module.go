@@ -0,0 +1,61 @@
+// Package jobs 定时任务模块
+// 发布 *scheduler.Scheduler 供其他模块在 Init 中注册定时任务，调度循环随应用启动和关闭，
+// 任务状态通过 /api/v1/admin/jobs 查看
+package jobs
+
+import (
+	"net/http"
+
+	"rich_go/internal/module"
+	"rich_go/internal/router"
+	"rich_go/internal/scheduler"
+	"rich_go/internal/server/handlers"
+	"rich_go/pkg/openapi"
+)
+
+// Name 模块名称，注册定时任务的模块应在 DependsOn 中声明
+const Name = "jobs"
+
+// Module 定时任务模块
+type Module struct {
+	scheduler *scheduler.Scheduler
+}
+
+// New 创建定时任务模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) Init(c *module.Context) error {
+	cfg := c.Config.Jobs
+	m.scheduler = scheduler.New(scheduler.Options{
+		Workers:        cfg.Workers,
+		DefaultTimeout: cfg.DefaultTimeout,
+		Leader:         scheduler.StaticLeader(cfg.Leader),
+	})
+	c.Health.Register("scheduler", m.scheduler.Heartbeat())
+	module.Provide(c, m.scheduler)
+	return nil
+}
+
+func (m *Module) Jobs() []module.Job {
+	return []module.Job{{Name: "scheduler", Run: m.scheduler.Run}}
+}
+
+func (m *Module) RegisterRoutes(api *router.API) {
+	handler := handlers.NewJobHandler(m.scheduler)
+	admin := api.Admin("admin")
+	admin.GET("/jobs", openapi.Operation{
+		Summary:  "获取定时任务状态",
+		Response: handlers.JobList{},
+	}, handler.ListJobs)
+	admin.POST("/jobs/:name/run", openapi.Operation{
+		Summary:  "立即运行一次定时任务",
+		Response: handlers.JobList{},
+		Errors:   []int{http.StatusNotFound, http.StatusConflict},
+	}, handler.RunJob)
+}
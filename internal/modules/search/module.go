@@ -0,0 +1,48 @@
+// Package search 全文搜索模块
+// 发布 *search.Index，用户和优惠券模块写入时同步更新索引，通过 /api/v1/search 搜索
+package search
+
+import (
+	"net/http"
+
+	"rich_go/internal/module"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	"rich_go/pkg/openapi"
+	"rich_go/pkg/search"
+)
+
+// Name 模块名称，需要写入搜索索引的模块应在 DependsOn 中声明
+const Name = "search"
+
+// Module 全文搜索模块
+type Module struct {
+	searchService service.SearchService
+}
+
+// New 创建全文搜索模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) Init(c *module.Context) error {
+	index := search.NewIndex()
+	m.searchService = service.NewTracingSearchService(service.NewSearchService(index))
+	module.Provide(c, index)
+	return nil
+}
+
+func (m *Module) RegisterRoutes(api *router.API) {
+	handler := handlers.NewSearchHandler(m.searchService)
+	api.Routes(router.APIVersionV1, "search").GET("/search", openapi.Operation{
+		Summary:  "搜索用户和优惠券，q 支持 name:、email:、status: 等限定字段，结果按相关度排序并高亮",
+		Query:    service.SearchQuery{},
+		Response: handlers.SearchResults{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.Search)
+}
@@ -0,0 +1,127 @@
+// Package tenant 租户模块
+// 发布 service.TenantService，HTTP 中间件和 gRPC 拦截器用它解析请求所属的租户，
+// 启动时创建配置中的租户，租户通过 /api/v1/admin/tenants 管理
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"rich_go/internal/audit"
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/model"
+	"rich_go/internal/module"
+	auditmodule "rich_go/internal/modules/audit"
+	"rich_go/internal/modules/events"
+	"rich_go/internal/repository"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	tenantctx "rich_go/internal/tenant"
+	"rich_go/pkg/openapi"
+)
+
+// Name 模块名称，数据按租户隔离的模块应在 DependsOn 中声明
+const Name = "tenant"
+
+// Module 租户模块
+type Module struct {
+	tenantService service.TenantService
+}
+
+// New 创建租户模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) DependsOn() []string {
+	return []string{events.Name, auditmodule.Name}
+}
+
+func (m *Module) Init(c *module.Context) error {
+	tx, err := module.Resolve[repository.Transactor](c)
+	if err != nil {
+		return err
+	}
+	auditor, err := module.Resolve[audit.Recorder](c)
+	if err != nil {
+		return err
+	}
+
+	tenantRepo := repository.NewTracingTenantRepository(repository.NewTenantRepository())
+	c.Health.Register("tenant_repository", health.CheckerFunc(tenantRepo.Ping))
+
+	m.tenantService = service.NewTracingTenantService(service.NewTenantService(tenantRepo, tx, auditor))
+	if err := seed(context.Background(), m.tenantService, c.Config.Tenancy.Tenants); err != nil {
+		return err
+	}
+	module.Provide(c, m.tenantService)
+	return nil
+}
+
+// seed 创建配置中尚不存在的租户
+func seed(ctx context.Context, tenants service.TenantService, configs []config.TenantConfig) error {
+	for _, tc := range configs {
+		if _, err := tenants.GetTenant(ctx, tc.ID); err == nil {
+			continue
+		}
+		_, err := tenants.CreateTenant(ctx, &service.CreateTenantRequest{
+			ID:       tc.ID,
+			Name:     tc.Name,
+			Currency: tc.Currency,
+			Locale:   tc.Locale,
+			Limits:   service.TenantLimits{MaxUsers: tc.MaxUsers, MaxCoupons: tc.MaxCoupons},
+		})
+		if err != nil {
+			return fmt.Errorf("创建租户 %s 失败: %w", tc.ID, err)
+		}
+	}
+	return nil
+}
+
+func (m *Module) RegisterRoutes(api *router.API) {
+	handler := handlers.NewTenantHandler(m.tenantService)
+	tenants := api.Admin("admin").Group("/tenants")
+	tenants.GET("", openapi.Operation{
+		Summary:  "获取租户列表",
+		Response: handlers.TenantList{},
+		Errors:   []int{http.StatusForbidden},
+	}, handler.ListTenants)
+	tenants.POST("", openapi.Operation{
+		Summary:  "创建租户，ID 同时用作子域名",
+		Request:  service.CreateTenantRequest{},
+		Response: model.Tenant{},
+		Errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict},
+	}, handler.CreateTenant)
+	tenants.GET("/:id", openapi.Operation{
+		Summary:  "获取单个租户",
+		Response: model.Tenant{},
+		Errors:   []int{http.StatusForbidden, http.StatusNotFound},
+	}, handler.GetTenant)
+	tenants.PUT("/:id", openapi.Operation{
+		Summary:  "更新租户配置，status 为 suspended 时停用租户",
+		Request:  service.UpdateTenantRequest{},
+		Response: model.Tenant{},
+		Errors:   []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+	}, handler.UpdateTenant)
+}
+
+// Each 依次在每个租户的 context 中执行 fn，供按租户重建索引等启动任务使用
+func Each(ctx context.Context, tenants service.TenantService, fn func(ctx context.Context) error) error {
+	list, err := tenants.ListTenants(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range list {
+		if err := fn(tenantctx.WithTenant(ctx, t)); err != nil {
+			return fmt.Errorf("租户 %s: %w", t.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package user 用户模块
+package user
+
+import (
+	"context"
+
+	userv1 "rich_go/api/proto/user/v1"
+	"rich_go/internal/audit"
+	"rich_go/internal/event"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	auditmodule "rich_go/internal/modules/audit"
+	cachemodule "rich_go/internal/modules/cache"
+	"rich_go/internal/modules/events"
+	searchmodule "rich_go/internal/modules/search"
+	tenantmodule "rich_go/internal/modules/tenant"
+	"rich_go/internal/repository"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/server/rpc"
+	"rich_go/internal/service"
+	"rich_go/pkg/cache"
+	"rich_go/pkg/search"
+
+	"google.golang.org/grpc"
+)
+
+// Name 模块名称
+const Name = "user"
+
+// Module 用户模块，发布 service.UserService 供其他模块使用
+type Module struct {
+	userService service.UserService
+}
+
+// New 创建用户模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) DependsOn() []string {
+	return []string{events.Name, auditmodule.Name, cachemodule.Name, searchmodule.Name, tenantmodule.Name}
+}
+
+func (m *Module) Init(c *module.Context) error {
+	tx, err := module.Resolve[repository.Transactor](c)
+	if err != nil {
+		return err
+	}
+	recorder, err := module.Resolve[event.Recorder](c)
+	if err != nil {
+		return err
+	}
+	auditor, err := module.Resolve[audit.Recorder](c)
+	if err != nil {
+		return err
+	}
+	index, err := module.Resolve[*search.Index](c)
+	if err != nil {
+		return err
+	}
+	tenants, err := module.Resolve[service.TenantService](c)
+	if err != nil {
+		return err
+	}
+
+	userRepo := repository.NewUserRepository()
+	if cfg := c.Config.Cache; cfg.Enabled {
+		backend, err := module.Resolve[cache.Backend](c)
+		if err != nil {
+			return err
+		}
+		userRepo = repository.NewCachingUserRepository(userRepo, backend, repository.CacheOptions{
+			TTL:         cfg.TTL,
+			NegativeTTL: cfg.NegativeTTL,
+		})
+	}
+	userRepo = repository.NewTracingUserRepository(repository.NewIndexingUserRepository(userRepo, index))
+	err = tenantmodule.Each(context.Background(), tenants, func(ctx context.Context) error {
+		return repository.IndexUsers(ctx, userRepo, index)
+	})
+	if err != nil {
+		return err
+	}
+	c.Health.Register("user_repository", health.CheckerFunc(userRepo.Ping))
+
+	m.userService = service.NewTracingUserService(service.NewUserService(userRepo, tx, recorder, auditor))
+	module.Provide(c, m.userService)
+	return nil
+}
+
+func (m *Module) RegisterRoutes(api *router.API) {
+	setupRoutes(api.Routes(router.APIVersionV1, "users"), handlers.NewUserHandler(m.userService))
+	setupRoutesV2(api.Routes(router.APIVersionV2, "users"), handlers.NewUserHandlerV2(m.userService))
+}
+
+func (m *Module) RegisterGRPC(s *grpc.Server) {
+	userv1.RegisterUserServiceServer(s, rpc.NewUserServer(m.userService))
+}
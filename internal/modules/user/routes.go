@@ -0,0 +1,97 @@
+package user
+
+import (
+	"net/http"
+
+	"rich_go/internal/model"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/openapi"
+)
+
+// setupRoutes 设置 v1 用户相关路由及接口描述
+func setupRoutes(v1 *router.Routes, handler *handlers.UserHandler) {
+	v1.Action("/users", "batch", openapi.Operation{
+		Summary:  "批量创建、更新、删除用户",
+		Request:  service.BatchRequest{},
+		Response: service.BatchResult{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.BatchUsers)
+
+	users := v1.Group("/users")
+	users.GET("", openapi.Operation{
+		Summary:  "获取用户列表",
+		Response: handlers.UserList{},
+	}, handler.ListUsers)
+	users.GET("/export", openapi.Operation{
+		Summary:  "导出用户",
+		Query:    service.UserFilter{},
+		Response: model.User{},
+		Produces: bulk.MediaTypes,
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType},
+	}, handler.ExportUsers)
+	users.POST("/import", openapi.Operation{
+		Summary:  "批量导入用户",
+		Query:    service.ImportOptions{},
+		Request:  service.CreateUserRequest{},
+		Consumes: bulk.MediaTypes,
+		Response: service.ImportResult{},
+		Errors:   []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+	}, handler.ImportUsers)
+	users.GET("/:id", openapi.Operation{
+		Summary:  "获取单个用户",
+		Response: model.User{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.GetUser)
+	users.POST("", openapi.Operation{
+		Summary:  "创建用户",
+		Request:  service.CreateUserRequest{},
+		Response: model.User{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.CreateUser)
+	users.PUT("/:id", openapi.Operation{
+		Summary:  "更新用户",
+		Request:  service.UpdateUserRequest{},
+		Response: model.User{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.UpdateUser)
+	users.DELETE("/:id", openapi.Operation{
+		Summary:  "删除用户",
+		Response: handlers.Deleted{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.DeleteUser)
+}
+
+// setupRoutesV2 设置 v2 用户相关路由及接口描述
+func setupRoutesV2(v2 *router.Routes, handler *handlers.UserHandlerV2) {
+	users := v2.Group("/users")
+	users.GET("", openapi.Operation{
+		Summary:  "分页获取用户列表",
+		Response: handlers.UserListV2{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.ListUsers)
+	users.GET("/:id", openapi.Operation{
+		Summary:  "获取单个用户",
+		Response: handlers.UserV2{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.GetUser)
+	users.POST("", openapi.Operation{
+		Summary:  "创建用户",
+		Request:  service.CreateUserRequest{},
+		Response: handlers.UserV2{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.CreateUser)
+	users.PUT("/:id", openapi.Operation{
+		Summary:  "更新用户",
+		Request:  service.UpdateUserRequest{},
+		Response: handlers.UserV2{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.UpdateUser)
+	users.DELETE("/:id", openapi.Operation{
+		Summary:  "删除用户",
+		Response: handlers.Deleted{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.DeleteUser)
+}
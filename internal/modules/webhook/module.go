@@ -0,0 +1,108 @@
+// Package webhook webhook 模块
+// 订阅领域事件，向合作方登记的 URL 投递签名后的事件
+package webhook
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"rich_go/internal/event"
+	"rich_go/internal/health"
+	"rich_go/internal/module"
+	"rich_go/internal/modules/events"
+	"rich_go/internal/modules/jobs"
+	"rich_go/internal/repository"
+	"rich_go/internal/router"
+	"rich_go/internal/scheduler"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	"rich_go/internal/webhook"
+)
+
+// Name 模块名称
+const Name = "webhook"
+
+// Module webhook 模块
+type Module struct {
+	webhookService service.WebhookService
+	dispatcher     *webhook.Dispatcher
+}
+
+// New 创建 webhook 模块
+func New() *Module {
+	return &Module{}
+}
+
+func (m *Module) Name() string {
+	return Name
+}
+
+func (m *Module) DependsOn() []string {
+	return []string{events.Name, jobs.Name}
+}
+
+func (m *Module) Init(c *module.Context) error {
+	bus, err := module.Resolve[*event.Bus](c)
+	if err != nil {
+		return err
+	}
+	sched, err := module.Resolve[*scheduler.Scheduler](c)
+	if err != nil {
+		return err
+	}
+
+	cfg := c.Config.Webhooks
+	webhookRepo := repository.NewTracingWebhookRepository(repository.NewWebhookRepository())
+	deliveryRepo := repository.NewWebhookDeliveryRepository()
+	c.Health.Register("webhook_repository", health.CheckerFunc(webhookRepo.Ping))
+
+	m.webhookService = service.NewTracingWebhookService(service.NewWebhookService(webhookRepo, deliveryRepo, service.WebhookOptions{
+		AllowPrivateTargets: cfg.AllowPrivateTargets,
+	}))
+	m.dispatcher = webhook.NewDispatcher(webhookRepo, deliveryRepo, nil, webhook.Options{
+		Interval:    cfg.Interval,
+		BatchSize:   cfg.BatchSize,
+		MaxAttempts: cfg.MaxAttempts,
+		MaxBackoff:  cfg.MaxBackoff,
+		Timeout:     cfg.Timeout,
+
+		AllowPrivateTargets: cfg.AllowPrivateTargets,
+	})
+	// 同步订阅：投递记录写入成功后 outbox 才标记事件已投递
+	// 只投递优惠券事件，用户事件包含个人信息，不发送给合作方
+	for _, eventType := range []string{event.TypeCouponCreated, event.TypeCouponUpdated, event.TypeCouponDeleted} {
+		bus.Subscribe(eventType, m.dispatcher.Enqueue)
+	}
+
+	if cfg.Retention > 0 {
+		return sched.Register(scheduler.Job{
+			Name:       "webhook-purge-deliveries",
+			Schedule:   scheduler.MustParse("@hourly"),
+			Timeout:    time.Minute,
+			Jitter:     5 * time.Minute,
+			LeaderOnly: true,
+			Run:        m.purgeDeliveries(cfg.Retention),
+		})
+	}
+	return nil
+}
+
+// purgeDeliveries 清理过期的投递成功记录
+func (m *Module) purgeDeliveries(retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		deleted, err := m.webhookService.PurgeDeliveries(ctx, retention)
+		if err == nil && deleted > 0 {
+			log.Printf("已清理 %d 条过期的 webhook 投递记录", deleted)
+		}
+		return err
+	}
+}
+
+func (m *Module) Jobs() []module.Job {
+	return []module.Job{{Name: "dispatcher", Run: m.dispatcher.Run}}
+}
+
+func (m *Module) RegisterRoutes(api *router.API) {
+	setupRoutes(api.Routes(router.APIVersionV1, "webhooks"), handlers.NewWebhookHandler(m.webhookService))
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"net/http"
+
+	"rich_go/internal/model"
+	"rich_go/internal/router"
+	"rich_go/internal/server/handlers"
+	"rich_go/internal/service"
+	"rich_go/pkg/openapi"
+)
+
+// setupRoutes 设置 webhook 相关路由及接口描述
+func setupRoutes(v1 *router.Routes, handler *handlers.WebhookHandler) {
+	webhooks := v1.Group("/webhooks")
+	webhooks.GET("", openapi.Operation{
+		Summary:  "获取 webhook 列表",
+		Response: handlers.WebhookList{},
+	}, handler.ListWebhooks)
+	webhooks.GET("/:id", openapi.Operation{
+		Summary:  "获取单个 webhook",
+		Response: model.Webhook{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.GetWebhook)
+	webhooks.POST("", openapi.Operation{
+		Summary:  "创建 webhook，响应中的签名密钥只返回一次",
+		Request:  service.CreateWebhookRequest{},
+		Response: handlers.WebhookCreated{},
+		Errors:   []int{http.StatusBadRequest},
+	}, handler.CreateWebhook)
+	webhooks.PUT("/:id", openapi.Operation{
+		Summary:  "更新 webhook",
+		Request:  service.UpdateWebhookRequest{},
+		Response: model.Webhook{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.UpdateWebhook)
+	webhooks.DELETE("/:id", openapi.Operation{
+		Summary:  "删除 webhook",
+		Response: handlers.Deleted{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.DeleteWebhook)
+	webhooks.GET("/:id/deliveries", openapi.Operation{
+		Summary:  "获取投递记录，status=dead 时返回死信列表",
+		Response: handlers.WebhookDeliveryList{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.ListDeliveries)
+	webhooks.POST("/:id/deliveries/:deliveryId/redeliver", openapi.Operation{
+		Summary:  "手动重新投递",
+		Response: model.WebhookDelivery{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	}, handler.Redeliver)
+}
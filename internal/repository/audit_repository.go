@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rich_go/internal/model"
+)
+
+// AuditFilter 审计日志查询条件，零值字段表示不过滤
+type AuditFilter struct {
+	Tenant       string
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         time.Time // 包含
+	To           time.Time // 不包含
+}
+
+// AuditRepository 审计日志仓储接口，只允许追加
+type AuditRepository interface {
+	// Append 追加记录并链接到上一条记录的哈希，在事务中调用时随事务提交
+	Append(ctx context.Context, entry *model.AuditEntry) error
+	// Find 按条件查询，按时间倒序返回
+	Find(ctx context.Context, filter AuditFilter) ([]*model.AuditEntry, error)
+	// FindAll 按追加顺序返回全部记录，用于校验哈希链
+	FindAll(ctx context.Context) ([]*model.AuditEntry, error)
+	Ping(ctx context.Context) error
+}
+
+// auditRepository 审计日志仓储实现（内存实现，后续可替换为数据库表）
+type auditRepository struct {
+	mu      sync.RWMutex
+	entries []*model.AuditEntry
+	nextID  uint64
+}
+
+// NewAuditRepository 创建审计日志仓储实例
+func NewAuditRepository() AuditRepository {
+	return &auditRepository{nextID: 1}
+}
+
+func (r *auditRepository) Append(ctx context.Context, entry *model.AuditEntry) error {
+	// 复制一份，避免提交前调用方修改
+	c := *entry
+	afterCommit(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		c.ID = r.nextID
+		r.nextID++
+		if c.Timestamp.IsZero() {
+			c.Timestamp = time.Now().UTC()
+		}
+		c.PrevHash = ""
+		if n := len(r.entries); n > 0 {
+			c.PrevHash = r.entries[n-1].Hash
+		}
+		c.Hash = c.ComputeHash()
+		r.entries = append(r.entries, &c)
+	})
+	return nil
+}
+
+func (r *auditRepository) Find(ctx context.Context, filter AuditFilter) ([]*model.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*model.AuditEntry, 0)
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		e := r.entries[i]
+		if !filter.match(e) {
+			continue
+		}
+		c := *e
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (r *auditRepository) FindAll(ctx context.Context) ([]*model.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*model.AuditEntry, len(r.entries))
+	for i, e := range r.entries {
+		c := *e
+		result[i] = &c
+	}
+	return result, nil
+}
+
+func (r *auditRepository) Ping(ctx context.Context) error {
+	// 内存实现始终可用，数据库实现应在此检查连接
+	return ctx.Err()
+}
+
+// match 判断记录是否满足查询条件
+func (f AuditFilter) match(e *model.AuditEntry) bool {
+	switch {
+	case f.Tenant != "" && e.Tenant != f.Tenant,
+		f.Actor != "" && e.Actor != f.Actor,
+		f.Action != "" && e.Action != f.Action,
+		f.ResourceType != "" && e.ResourceType != f.ResourceType,
+		f.ResourceID != "" && e.ResourceID != f.ResourceID,
+		!f.From.IsZero() && e.Timestamp.Before(f.From),
+		!f.To.IsZero() && !e.Timestamp.Before(f.To):
+		return false
+	}
+	return true
+}
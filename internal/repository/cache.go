@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"rich_go/internal/metrics"
+	"rich_go/internal/model"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/cache"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheOptions 仓储读缓存参数
+type CacheOptions struct {
+	TTL         time.Duration // 缓存有效期
+	NegativeTTL time.Duration // 记录不存在时的缓存有效期，0 表示不缓存
+}
+
+// notFoundValue 缓存中表示记录不存在的值，序列化后的记录不会为空
+var notFoundValue = []byte{}
+
+// readThrough 按 ID 读取的通用缓存逻辑
+// 并发的未命中合并为一次加载，值以 JSON 存储，每个调用方拿到独立的副本；
+// 键包含 context 中的租户，命中缓存时同样不会读到其他租户的记录
+// 加载期间发生过失效时不写入加载结果，避免在写操作之后缓存写之前读到的旧值
+type readThrough[T any] struct {
+	name    string // 键前缀及指标标签
+	backend cache.Backend
+	opts    CacheOptions
+	group   singleflight.Group
+	gen     atomic.Uint64 // 失效次数，不区分键
+}
+
+func (c *readThrough[T]) key(ctx context.Context, id uint) string {
+	return c.name + ":" + tenant.ID(ctx) + ":" + strconv.FormatUint(uint64(id), 10)
+}
+
+// get 读取缓存，未命中时调用 load 加载并写入缓存
+func (c *readThrough[T]) get(ctx context.Context, id uint, load func(ctx context.Context) (*T, error)) (*T, error) {
+	key := c.key(ctx, id)
+	data, err := c.backend.Get(ctx, key)
+	switch {
+	case err == nil && len(data) == 0:
+		metrics.ObserveCache(c.name, metrics.CacheNegativeHit)
+		return nil, ErrNotFound
+	case err == nil:
+		if v, err := decode[T](data); err == nil {
+			metrics.ObserveCache(c.name, metrics.CacheHit)
+			return v, nil
+		}
+		metrics.ObserveCache(c.name, metrics.CacheError)
+	case errors.Is(err, cache.ErrMiss):
+		metrics.ObserveCache(c.name, metrics.CacheMiss)
+	default:
+		// 缓存不可用时直接读取仓储
+		metrics.ObserveCache(c.name, metrics.CacheError)
+		log.Printf("读取缓存 %s 失败: %v", key, err)
+	}
+
+	// 加载不随单个调用方取消，避免一个调用方取消导致其他等待者一起失败
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		return c.load(context.WithoutCancel(ctx), key, load)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return decode[T](res.Val.([]byte))
+	}
+}
+
+// load 加载记录并写入缓存，记录不存在时按 NegativeTTL 缓存
+func (c *readThrough[T]) load(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) ([]byte, error) {
+	gen := c.gen.Load()
+	v, err := load(ctx)
+	if errors.Is(err, ErrNotFound) {
+		if c.opts.NegativeTTL > 0 {
+			c.store(ctx, key, gen, notFoundValue, c.opts.NegativeTTL)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	c.store(ctx, key, gen, data, c.opts.TTL)
+	return data, nil
+}
+
+// store 写入加载结果，gen 为加载开始时的失效次数
+// 写入前后各检查一次：写入期间发生的失效可能先于写入删除缓存，此时删除刚写入的值
+func (c *readThrough[T]) store(ctx context.Context, key string, gen uint64, value []byte, ttl time.Duration) {
+	if c.gen.Load() != gen {
+		return
+	}
+	c.set(ctx, key, value, ttl)
+	if c.gen.Load() != gen {
+		c.delete(ctx, key)
+	}
+}
+
+func (c *readThrough[T]) set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if err := c.backend.Set(ctx, key, value, ttl); err != nil {
+		log.Printf("写入缓存 %s 失败: %v", key, err)
+	}
+}
+
+// invalidate 写操作后删除缓存，并让之后的读取不再复用进行中的加载
+// 事务回滚后再删除一次，避免事务期间读到并缓存了未提交的值
+func (c *readThrough[T]) invalidate(ctx context.Context, id uint) {
+	key := c.key(ctx, id)
+	c.remove(ctx, key)
+	afterRollback(ctx, func() { c.remove(context.WithoutCancel(ctx), key) })
+}
+
+// remove 先增加失效次数再删除，进行中的加载据此放弃或撤销写入
+func (c *readThrough[T]) remove(ctx context.Context, key string) {
+	c.gen.Add(1)
+	c.group.Forget(key)
+	c.delete(ctx, key)
+}
+
+func (c *readThrough[T]) delete(ctx context.Context, key string) {
+	if err := c.backend.Delete(ctx, key); err != nil {
+		log.Printf("删除缓存 %s 失败: %v", key, err)
+	}
+}
+
+func decode[T any](data []byte) (*T, error) {
+	v := new(T)
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// cachingCouponRepository 为 CouponRepository 的 FindByID 添加读缓存的装饰器
+type cachingCouponRepository struct {
+	CouponRepository
+	cache *readThrough[model.Coupon]
+}
+
+// NewCachingCouponRepository 创建带读缓存的优惠券仓储，写操作后使对应缓存失效
+func NewCachingCouponRepository(next CouponRepository, backend cache.Backend, opts CacheOptions) CouponRepository {
+	return &cachingCouponRepository{
+		CouponRepository: next,
+		cache:            &readThrough[model.Coupon]{name: "coupon", backend: backend, opts: opts},
+	}
+}
+
+func (r *cachingCouponRepository) FindByID(ctx context.Context, id uint) (*model.Coupon, error) {
+	return r.cache.get(ctx, id, func(ctx context.Context) (*model.Coupon, error) {
+		return r.CouponRepository.FindByID(ctx, id)
+	})
+}
+
+func (r *cachingCouponRepository) Create(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	created, err := r.CouponRepository.Create(ctx, coupon)
+	if err == nil {
+		// 清除可能存在的不存在记录
+		r.cache.invalidate(ctx, created.ID)
+	}
+	return created, err
+}
+
+func (r *cachingCouponRepository) Update(ctx context.Context, id uint, coupon *model.Coupon) (*model.Coupon, error) {
+	defer r.cache.invalidate(ctx, id)
+	return r.CouponRepository.Update(ctx, id, coupon)
+}
+
+func (r *cachingCouponRepository) Delete(ctx context.Context, id uint) error {
+	defer r.cache.invalidate(ctx, id)
+	return r.CouponRepository.Delete(ctx, id)
+}
+
+// cachingUserRepository 为 UserRepository 的 FindByID 添加读缓存的装饰器
+type cachingUserRepository struct {
+	UserRepository
+	cache *readThrough[model.User]
+}
+
+// NewCachingUserRepository 创建带读缓存的用户仓储，写操作后使对应缓存失效
+func NewCachingUserRepository(next UserRepository, backend cache.Backend, opts CacheOptions) UserRepository {
+	return &cachingUserRepository{
+		UserRepository: next,
+		cache:          &readThrough[model.User]{name: "user", backend: backend, opts: opts},
+	}
+}
+
+func (r *cachingUserRepository) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	return r.cache.get(ctx, id, func(ctx context.Context) (*model.User, error) {
+		return r.UserRepository.FindByID(ctx, id)
+	})
+}
+
+func (r *cachingUserRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
+	created, err := r.UserRepository.Create(ctx, user)
+	if err == nil {
+		// 清除可能存在的不存在记录
+		r.cache.invalidate(ctx, created.ID)
+	}
+	return created, err
+}
+
+func (r *cachingUserRepository) Update(ctx context.Context, id uint, user *model.User) (*model.User, error) {
+	defer r.cache.invalidate(ctx, id)
+	return r.UserRepository.Update(ctx, id, user)
+}
+
+func (r *cachingUserRepository) Delete(ctx context.Context, id uint) error {
+	defer r.cache.invalidate(ctx, id)
+	return r.UserRepository.Delete(ctx, id)
+}
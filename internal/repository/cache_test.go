@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"rich_go/internal/model"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/cache"
+)
+
+// countingBackend 记录 Get 次数的缓存后端
+type countingBackend struct {
+	cache.Backend
+	gets atomic.Int32
+}
+
+func (b *countingBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	b.gets.Add(1)
+	return b.Backend.Get(ctx, key)
+}
+
+func newTestCache(opts CacheOptions) (*readThrough[model.Coupon], *countingBackend) {
+	backend := &countingBackend{Backend: cache.NewLRU(100)}
+	return &readThrough[model.Coupon]{name: "coupon", backend: backend, opts: opts}, backend
+}
+
+// waitFor 等待 cond 成立
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("等待超时")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReadThroughCollapsesConcurrentMisses(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	c, backend := newTestCache(CacheOptions{TTL: time.Minute})
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (*model.Coupon, error) {
+		loads.Add(1)
+		<-release
+		return &model.Coupon{ID: 1, Name: "summer"}, nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]*model.Coupon, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.get(ctx, 1, load)
+		}()
+	}
+	// 所有调用方都未命中后再完成加载
+	waitFor(t, func() bool { return backend.gets.Load() == callers })
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("加载 %d 次, want 1", n)
+	}
+	for i, r := range results {
+		if r == nil || r.Name != "summer" {
+			t.Fatalf("results[%d] = %+v, want summer", i, r)
+		}
+	}
+	results[0].Name = "changed"
+	if results[1].Name != "summer" {
+		t.Error("调用方共享了同一个副本")
+	}
+	// 之后的读取命中缓存
+	if _, err := c.get(ctx, 1, load); err != nil || loads.Load() != 1 {
+		t.Errorf("命中缓存时加载 %d 次, %v", loads.Load(), err)
+	}
+}
+
+func TestReadThroughNegativeCaching(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	tests := []struct {
+		name        string
+		negativeTTL time.Duration
+		wantLoads   int32
+	}{
+		{"缓存不存在的记录", time.Minute, 1},
+		{"NegativeTTL 为 0 时不缓存", 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestCache(CacheOptions{TTL: time.Minute, NegativeTTL: tt.negativeTTL})
+			var loads atomic.Int32
+			load := func(ctx context.Context) (*model.Coupon, error) {
+				loads.Add(1)
+				return nil, ErrNotFound
+			}
+			for range 2 {
+				if _, err := c.get(ctx, 1, load); !errors.Is(err, ErrNotFound) {
+					t.Fatalf("get = %v, want ErrNotFound", err)
+				}
+			}
+			if n := loads.Load(); n != tt.wantLoads {
+				t.Errorf("加载 %d 次, want %d", n, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestCachingRepositoryInvalidatesOnWrite(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	coupons := NewCachingCouponRepository(NewCouponRepository(), cache.NewLRU(100), CacheOptions{TTL: time.Minute, NegativeTTL: time.Minute})
+
+	// 不存在时的缓存在创建后失效
+	if _, err := coupons.FindByID(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("FindByID = %v, want ErrNotFound", err)
+	}
+	created, err := coupons.Create(ctx, &model.Coupon{Name: "summer", DiscountType: "fixed", DiscountValue: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := coupons.FindByID(ctx, created.ID); err != nil || got.Name != "summer" {
+		t.Fatalf("创建后 FindByID = %+v, %v", got, err)
+	}
+
+	updated := *created
+	updated.Name = "winter"
+	if _, err := coupons.Update(ctx, created.ID, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := coupons.FindByID(ctx, created.ID); got.Name != "winter" {
+		t.Errorf("更新后 FindByID 名称为 %q, want winter", got.Name)
+	}
+
+	if err := coupons.Delete(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := coupons.FindByID(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("删除后 FindByID = %v, want ErrNotFound", err)
+	}
+}
+
+func TestReadThroughDropsLoadInvalidatedInFlight(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	c, _ := newTestCache(CacheOptions{TTL: time.Minute})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	stale := func(ctx context.Context) (*model.Coupon, error) {
+		close(started)
+		<-release
+		return &model.Coupon{ID: 1, Name: "stale"}, nil
+	}
+	done := make(chan *model.Coupon)
+	go func() {
+		v, _ := c.get(ctx, 1, stale)
+		done <- v
+	}()
+
+	// 加载读到旧值之后、写入缓存之前发生写操作
+	<-started
+	c.invalidate(ctx, 1)
+	close(release)
+	if v := <-done; v == nil || v.Name != "stale" {
+		t.Fatalf("进行中的读取返回 %+v, want 加载到的旧值", v)
+	}
+
+	if _, err := c.backend.Get(ctx, c.key(ctx, 1)); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("失效后缓存中仍有加载到的旧值: %v", err)
+	}
+	fresh := func(ctx context.Context) (*model.Coupon, error) {
+		return &model.Coupon{ID: 1, Name: "fresh"}, nil
+	}
+	if v, _ := c.get(ctx, 1, fresh); v == nil || v.Name != "fresh" {
+		t.Errorf("失效后读取 %+v, want fresh", v)
+	}
+}
@@ -3,19 +3,27 @@ package repository
 import (
 	"context"
 	"rich_go/internal/model"
+	"slices"
+	"sync"
 )
 
 // CouponRepository 优惠券仓储接口
 type CouponRepository interface {
 	FindAll(ctx context.Context) ([]*model.Coupon, error)
 	FindByID(ctx context.Context, id uint) (*model.Coupon, error)
+	// Count 返回当前租户的优惠券数量，在事务中调用时包含事务内的写入
+	Count(ctx context.Context) (int, error)
 	Create(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error)
+	// Update 覆盖可修改的字段，与现有值的合并由调用方完成
 	Update(ctx context.Context, id uint, coupon *model.Coupon) (*model.Coupon, error)
 	Delete(ctx context.Context, id uint) error
+	Ping(ctx context.Context) error
 }
 
 // couponRepository 优惠券仓储实现（内存实现，后续可替换为数据库实现）
+// 每个方法只访问 context 中租户的数据，其他租户的记录视为不存在
 type couponRepository struct {
+	mu     sync.RWMutex
 	coupons []*model.Coupon
 	nextID  uint
 }
@@ -29,14 +37,33 @@ func NewCouponRepository() CouponRepository {
 }
 
 func (r *couponRepository) FindAll(ctx context.Context) ([]*model.Coupon, error) {
-	result := make([]*model.Coupon, len(r.coupons))
-	copy(result, r.coupons)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*model.Coupon, 0, len(r.coupons))
+	for _, coupon := range r.coupons {
+		if coupon.TenantID == tenantID {
+			c := *coupon
+			result = append(result, &c)
+		}
+	}
 	return result, nil
 }
 
 func (r *couponRepository) FindByID(ctx context.Context, id uint) (*model.Coupon, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, coupon := range r.coupons {
-		if coupon.ID == id {
+		if coupon.ID == id && coupon.TenantID == tenantID {
 			c := *coupon
 			return &c, nil
 		}
@@ -44,36 +71,61 @@ func (r *couponRepository) FindByID(ctx context.Context, id uint) (*model.Coupon
 	return nil, ErrNotFound
 }
 
+func (r *couponRepository) Count(ctx context.Context) (int, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := 0
+	for _, c := range r.coupons {
+		if c.TenantID == tenantID {
+			n++
+		}
+	}
+	return n, nil
+}
+
 func (r *couponRepository) Create(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	coupon.ID = r.nextID
+	coupon.TenantID = tenantID
 	r.nextID++
 	r.coupons = append(r.coupons, coupon)
+	undoLocked(ctx, &r.mu, func() {
+		r.coupons = slices.DeleteFunc(r.coupons, func(c *model.Coupon) bool { return c == coupon })
+	})
 	c := *coupon
 	return &c, nil
 }
 
 func (r *couponRepository) Update(ctx context.Context, id uint, coupon *model.Coupon) (*model.Coupon, error) {
-	for i, c := range r.coupons {
-		if c.ID == id {
-			if coupon.Name != "" {
-				r.coupons[i].Name = coupon.Name
-			}
-			if coupon.Description != "" {
-				r.coupons[i].Description = coupon.Description
-			}
-			if coupon.DiscountType != "" {
-				r.coupons[i].DiscountType = coupon.DiscountType
-			}
-			if coupon.DiscountValue > 0 {
-				r.coupons[i].DiscountValue = coupon.DiscountValue
-			}
-			if coupon.MinAmount >= 0 {
-				r.coupons[i].MinAmount = coupon.MinAmount
-			}
-			if coupon.Status != "" {
-				r.coupons[i].Status = coupon.Status
-			}
-			updated := *r.coupons[i]
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.coupons {
+		if c.ID == id && c.TenantID == tenantID {
+			before := *c
+			undoLocked(ctx, &r.mu, func() { *c = before })
+			c.Name = coupon.Name
+			c.Description = coupon.Description
+			c.DiscountType = coupon.DiscountType
+			c.DiscountValue = coupon.DiscountValue
+			c.MinAmount = coupon.MinAmount
+			c.Status = coupon.Status
+			updated := *c
 			return &updated, nil
 		}
 	}
@@ -81,12 +133,26 @@ func (r *couponRepository) Update(ctx context.Context, id uint, coupon *model.Co
 }
 
 func (r *couponRepository) Delete(ctx context.Context, id uint) error {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	for i, coupon := range r.coupons {
-		if coupon.ID == id {
+		if coupon.ID == id && coupon.TenantID == tenantID {
 			r.coupons = append(r.coupons[:i], r.coupons[i+1:]...)
+			undoLocked(ctx, &r.mu, func() {
+				r.coupons = slices.Insert(r.coupons, min(i, len(r.coupons)), coupon)
+			})
 			return nil
 		}
 	}
 	return ErrNotFound
 }
 
+func (r *couponRepository) Ping(ctx context.Context) error {
+	// 内存实现始终可用，数据库实现应在此检查连接
+	return ctx.Err()
+}
@@ -5,5 +5,8 @@ import "errors"
 var (
 	// ErrNotFound 记录未找到错误
 	ErrNotFound = errors.New("record not found")
+	// ErrAlreadyExists 记录已存在错误
+	ErrAlreadyExists = errors.New("record already exists")
+	// ErrTenantRequired context 中没有租户，按租户隔离的仓储拒绝访问
+	ErrTenantRequired = errors.New("tenant required")
 )
-
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// OutboxRecord outbox 中待投递的事件
+type OutboxRecord struct {
+	ID            uint64
+	EventID       string
+	Type          string
+	Payload       []byte // 序列化后的事件信封
+	CreatedAt     time.Time
+	Attempts      int       // 已投递失败的次数
+	NextAttemptAt time.Time // 下次可投递时间
+	LastError     string
+}
+
+// OutboxRepository outbox 仓储接口
+// 事件与业务数据在同一事务中追加，由投递任务读取并投递，投递成功后才标记完成，保证至少投递一次
+type OutboxRepository interface {
+	// Append 追加事件，在事务中调用时随事务提交
+	Append(ctx context.Context, records ...*OutboxRecord) error
+	// FetchPending 按写入顺序返回到期的待投递事件
+	FetchPending(ctx context.Context, now time.Time, limit int) ([]*OutboxRecord, error)
+	// MarkDelivered 标记投递成功
+	MarkDelivered(ctx context.Context, id uint64) error
+	// MarkFailed 记录投递失败，nextAttempt 之前不再投递
+	MarkFailed(ctx context.Context, id uint64, lastErr string, nextAttempt time.Time) error
+	// MarkDead 超过重试次数，不再投递
+	MarkDead(ctx context.Context, id uint64, lastErr string) error
+	// Pending 返回待投递事件数量
+	Pending(ctx context.Context) (int, error)
+	Ping(ctx context.Context) error
+}
+
+// outboxRepository outbox 仓储实现（内存实现，后续可替换为数据库表）
+// 投递成功的记录直接删除，放弃投递的记录移入 dead
+type outboxRepository struct {
+	mu      sync.Mutex
+	pending []*OutboxRecord
+	dead    []*OutboxRecord
+	nextID  uint64
+}
+
+// NewOutboxRepository 创建 outbox 仓储实例
+func NewOutboxRepository() OutboxRepository {
+	return &outboxRepository{nextID: 1}
+}
+
+func (r *outboxRepository) Append(ctx context.Context, records ...*OutboxRecord) error {
+	// 复制一份，避免提交前调用方修改
+	copies := make([]*OutboxRecord, len(records))
+	for i, rec := range records {
+		c := *rec
+		copies[i] = &c
+	}
+
+	afterCommit(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		now := time.Now()
+		for _, rec := range copies {
+			rec.ID = r.nextID
+			r.nextID++
+			if rec.CreatedAt.IsZero() {
+				rec.CreatedAt = now
+			}
+			r.pending = append(r.pending, rec)
+		}
+	})
+	return nil
+}
+
+func (r *outboxRepository) FetchPending(ctx context.Context, now time.Time, limit int) ([]*OutboxRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*OutboxRecord, 0, min(limit, len(r.pending)))
+	for _, rec := range r.pending {
+		if len(result) >= limit {
+			break
+		}
+		if rec.NextAttemptAt.After(now) {
+			continue
+		}
+		c := *rec
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	r.pending = append(r.pending[:i], r.pending[i+1:]...)
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uint64, lastErr string, nextAttempt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	rec := r.pending[i]
+	rec.Attempts++
+	rec.LastError = lastErr
+	rec.NextAttemptAt = nextAttempt
+	return nil
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id uint64, lastErr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	rec := r.pending[i]
+	rec.Attempts++
+	rec.LastError = lastErr
+	r.pending = append(r.pending[:i], r.pending[i+1:]...)
+	r.dead = append(r.dead, rec)
+	return nil
+}
+
+func (r *outboxRepository) Pending(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending), nil
+}
+
+func (r *outboxRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (r *outboxRepository) indexOf(id uint64) int {
+	for i, rec := range r.pending {
+		if rec.ID == id {
+			return i
+		}
+	}
+	return -1
+}
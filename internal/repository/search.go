@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"rich_go/internal/model"
+	"rich_go/pkg/search"
+)
+
+// 搜索文档类型
+const (
+	SearchTypeUser   = "user"
+	SearchTypeCoupon = "coupon"
+)
+
+// 搜索字段权重，名称命中优先
+const (
+	nameBoost  = 2
+	emailBoost = 1.5
+)
+
+// SearchFields 可在查询中指定的字段
+var SearchFields = []string{"name", "email", "description", "status", "discountType"}
+
+// userDocument 用户的搜索文档
+func userDocument(u *model.User) search.Document {
+	return search.Document{
+		Type:  SearchTypeUser,
+		ID:    strconv.FormatUint(uint64(u.ID), 10),
+		Scope: u.TenantID,
+		Fields: []search.Field{
+			{Name: "name", Value: u.Name, Boost: nameBoost},
+			{Name: "email", Value: u.Email, Boost: emailBoost},
+		},
+	}
+}
+
+// couponDocument 优惠券的搜索文档
+func couponDocument(c *model.Coupon) search.Document {
+	return search.Document{
+		Type:  SearchTypeCoupon,
+		ID:    strconv.FormatUint(uint64(c.ID), 10),
+		Scope: c.TenantID,
+		Fields: []search.Field{
+			{Name: "name", Value: c.Name, Boost: nameBoost},
+			{Name: "description", Value: c.Description},
+			{Name: "status", Value: c.Status},
+			{Name: "discountType", Value: c.DiscountType},
+		},
+	}
+}
+
+// indexingUserRepository 写入用户后同步更新搜索索引，事务中的写入在提交后生效
+type indexingUserRepository struct {
+	UserRepository
+	index *search.Index
+}
+
+// NewIndexingUserRepository 创建同步搜索索引的用户仓储
+func NewIndexingUserRepository(next UserRepository, index *search.Index) UserRepository {
+	return &indexingUserRepository{UserRepository: next, index: index}
+}
+
+func (r *indexingUserRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
+	created, err := r.UserRepository.Create(ctx, user)
+	if err == nil {
+		doc := userDocument(created)
+		afterCommit(ctx, func() { r.index.Put(doc) })
+	}
+	return created, err
+}
+
+func (r *indexingUserRepository) Update(ctx context.Context, id uint, user *model.User) (*model.User, error) {
+	updated, err := r.UserRepository.Update(ctx, id, user)
+	if err == nil {
+		doc := userDocument(updated)
+		afterCommit(ctx, func() { r.index.Put(doc) })
+	}
+	return updated, err
+}
+
+func (r *indexingUserRepository) Delete(ctx context.Context, id uint) error {
+	err := r.UserRepository.Delete(ctx, id)
+	if err == nil {
+		afterCommit(ctx, func() { r.index.Delete(SearchTypeUser, strconv.FormatUint(uint64(id), 10)) })
+	}
+	return err
+}
+
+// IndexUsers 将仓储中 context 所属租户的全部用户写入索引，启动时按租户重建索引使用
+func IndexUsers(ctx context.Context, repo UserRepository, index *search.Index) error {
+	users, err := repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		index.Put(userDocument(u))
+	}
+	return nil
+}
+
+// indexingCouponRepository 写入优惠券后同步更新搜索索引，事务中的写入在提交后生效
+type indexingCouponRepository struct {
+	CouponRepository
+	index *search.Index
+}
+
+// NewIndexingCouponRepository 创建同步搜索索引的优惠券仓储
+func NewIndexingCouponRepository(next CouponRepository, index *search.Index) CouponRepository {
+	return &indexingCouponRepository{CouponRepository: next, index: index}
+}
+
+func (r *indexingCouponRepository) Create(ctx context.Context, coupon *model.Coupon) (*model.Coupon, error) {
+	created, err := r.CouponRepository.Create(ctx, coupon)
+	if err == nil {
+		doc := couponDocument(created)
+		afterCommit(ctx, func() { r.index.Put(doc) })
+	}
+	return created, err
+}
+
+func (r *indexingCouponRepository) Update(ctx context.Context, id uint, coupon *model.Coupon) (*model.Coupon, error) {
+	updated, err := r.CouponRepository.Update(ctx, id, coupon)
+	if err == nil {
+		doc := couponDocument(updated)
+		afterCommit(ctx, func() { r.index.Put(doc) })
+	}
+	return updated, err
+}
+
+func (r *indexingCouponRepository) Delete(ctx context.Context, id uint) error {
+	err := r.CouponRepository.Delete(ctx, id)
+	if err == nil {
+		afterCommit(ctx, func() { r.index.Delete(SearchTypeCoupon, strconv.FormatUint(uint64(id), 10)) })
+	}
+	return err
+}
+
+// IndexCoupons 将仓储中 context 所属租户的全部优惠券写入索引，启动时按租户重建索引使用
+func IndexCoupons(ctx context.Context, repo CouponRepository, index *search.Index) error {
+	coupons, err := repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range coupons {
+		index.Put(couponDocument(c))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"rich_go/internal/model"
+	"rich_go/internal/tenant"
+)
+
+// tenantScope 返回 context 中的租户 ID，按租户隔离的仓储在每个方法中调用
+func tenantScope(ctx context.Context) (string, error) {
+	if id := tenant.ID(ctx); id != "" {
+		return id, nil
+	}
+	return "", ErrTenantRequired
+}
+
+// TenantRepository 租户仓储接口，租户本身不按租户隔离
+type TenantRepository interface {
+	FindAll(ctx context.Context) ([]*model.Tenant, error)
+	FindByID(ctx context.Context, id string) (*model.Tenant, error)
+	// Create 创建租户，ID 已存在时返回 ErrAlreadyExists
+	Create(ctx context.Context, t *model.Tenant) (*model.Tenant, error)
+	// Update 覆盖除 ID 和创建时间外的全部字段
+	Update(ctx context.Context, id string, t *model.Tenant) (*model.Tenant, error)
+	Ping(ctx context.Context) error
+}
+
+// tenantRepository 租户仓储实现（内存实现，后续可替换为数据库实现）
+type tenantRepository struct {
+	mu      sync.RWMutex
+	tenants map[string]*model.Tenant
+}
+
+// NewTenantRepository 创建租户仓储实例
+func NewTenantRepository() TenantRepository {
+	return &tenantRepository{tenants: make(map[string]*model.Tenant)}
+}
+
+func (r *tenantRepository) FindAll(ctx context.Context) ([]*model.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*model.Tenant, 0, len(r.tenants))
+	for _, t := range r.tenants {
+		c := *t
+		result = append(result, &c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *tenantRepository) FindByID(ctx context.Context, id string) (*model.Tenant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tenants[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *t
+	return &c, nil
+}
+
+func (r *tenantRepository) Create(ctx context.Context, t *model.Tenant) (*model.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tenants[t.ID]; ok {
+		return nil, ErrAlreadyExists
+	}
+	c := *t
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	r.tenants[c.ID] = &c
+	undoLocked(ctx, &r.mu, func() { delete(r.tenants, c.ID) })
+	created := c
+	return &created, nil
+}
+
+func (r *tenantRepository) Update(ctx context.Context, id string, t *model.Tenant) (*model.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.tenants[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *t
+	c.ID = existing.ID
+	c.CreatedAt = existing.CreatedAt
+	r.tenants[id] = &c
+	undoLocked(ctx, &r.mu, func() { r.tenants[id] = existing })
+	updated := c
+	return &updated, nil
+}
+
+func (r *tenantRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+
+	"rich_go/internal/model"
+	"rich_go/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracingUserRepository 为 UserRepository 添加链路追踪的装饰器
+type tracingUserRepository struct {
+	next UserRepository
+}
+
+// NewTracingUserRepository 创建带链路追踪的用户仓储
+func NewTracingUserRepository(next UserRepository) UserRepository {
+	return &tracingUserRepository{next: next}
+}
+
+func (r *tracingUserRepository) FindAll(ctx context.Context) (users []*model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *tracingUserRepository) FindByID(ctx context.Context, id uint) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByID", attribute.Int64("user.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tracingUserRepository) Count(ctx context.Context) (n int, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Count")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Count(ctx)
+}
+
+func (r *tracingUserRepository) Create(ctx context.Context, user *model.User) (created *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Create")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Create(ctx, user)
+}
+
+func (r *tracingUserRepository) Update(ctx context.Context, id uint, user *model.User) (updated *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Update", attribute.Int64("user.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, id, user)
+}
+
+func (r *tracingUserRepository) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Delete", attribute.Int64("user.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tracingUserRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// tracingCouponRepository 为 CouponRepository 添加链路追踪的装饰器
+type tracingCouponRepository struct {
+	next CouponRepository
+}
+
+// NewTracingCouponRepository 创建带链路追踪的优惠券仓储
+func NewTracingCouponRepository(next CouponRepository) CouponRepository {
+	return &tracingCouponRepository{next: next}
+}
+
+func (r *tracingCouponRepository) FindAll(ctx context.Context) (coupons []*model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *tracingCouponRepository) FindByID(ctx context.Context, id uint) (coupon *model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponRepository.FindByID", attribute.Int64("coupon.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tracingCouponRepository) Count(ctx context.Context) (n int, err error) {
+	ctx, span := tracing.Start(ctx, "CouponRepository.Count")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Count(ctx)
+}
+
+func (r *tracingCouponRepository) Create(ctx context.Context, coupon *model.Coupon) (created *model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponRepository.Create")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Create(ctx, coupon)
+}
+
+func (r *tracingCouponRepository) Update(ctx context.Context, id uint, coupon *model.Coupon) (updated *model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponRepository.Update", attribute.Int64("coupon.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, id, coupon)
+}
+
+func (r *tracingCouponRepository) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "CouponRepository.Delete", attribute.Int64("coupon.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tracingCouponRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// tracingWebhookRepository 为 WebhookRepository 添加链路追踪的装饰器
+type tracingWebhookRepository struct {
+	next WebhookRepository
+}
+
+// NewTracingWebhookRepository 创建带链路追踪的 webhook 订阅仓储
+func NewTracingWebhookRepository(next WebhookRepository) WebhookRepository {
+	return &tracingWebhookRepository{next: next}
+}
+
+func (r *tracingWebhookRepository) FindAll(ctx context.Context) (webhooks []*model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *tracingWebhookRepository) FindByID(ctx context.Context, id uint) (webhook *model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.FindByID", attribute.Int64("webhook.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tracingWebhookRepository) FindByEventType(ctx context.Context, eventType string) (webhooks []*model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.FindByEventType", attribute.String("event.type", eventType))
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByEventType(ctx, eventType)
+}
+
+func (r *tracingWebhookRepository) Create(ctx context.Context, webhook *model.Webhook) (created *model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.Create")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Create(ctx, webhook)
+}
+
+func (r *tracingWebhookRepository) Update(ctx context.Context, id uint, webhook *model.Webhook) (updated *model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.Update", attribute.Int64("webhook.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, id, webhook)
+}
+
+func (r *tracingWebhookRepository) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookRepository.Delete", attribute.Int64("webhook.id", int64(id)))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *tracingWebhookRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// tracingAuditRepository 为 AuditRepository 添加链路追踪的装饰器
+type tracingAuditRepository struct {
+	next AuditRepository
+}
+
+// NewTracingAuditRepository 创建带链路追踪的审计日志仓储
+func NewTracingAuditRepository(next AuditRepository) AuditRepository {
+	return &tracingAuditRepository{next: next}
+}
+
+func (r *tracingAuditRepository) Append(ctx context.Context, entry *model.AuditEntry) (err error) {
+	ctx, span := tracing.Start(ctx, "AuditRepository.Append",
+		attribute.String("audit.action", entry.Action),
+		attribute.String("audit.resource_type", entry.ResourceType),
+	)
+	defer func() { tracing.End(span, err) }()
+	return r.next.Append(ctx, entry)
+}
+
+func (r *tracingAuditRepository) Find(ctx context.Context, filter AuditFilter) (entries []*model.AuditEntry, err error) {
+	ctx, span := tracing.Start(ctx, "AuditRepository.Find")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Find(ctx, filter)
+}
+
+func (r *tracingAuditRepository) FindAll(ctx context.Context) (entries []*model.AuditEntry, err error) {
+	ctx, span := tracing.Start(ctx, "AuditRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *tracingAuditRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// tracingTenantRepository 为 TenantRepository 添加链路追踪的装饰器
+type tracingTenantRepository struct {
+	next TenantRepository
+}
+
+// NewTracingTenantRepository 创建带链路追踪的租户仓储
+func NewTracingTenantRepository(next TenantRepository) TenantRepository {
+	return &tracingTenantRepository{next: next}
+}
+
+func (r *tracingTenantRepository) FindAll(ctx context.Context) (tenants []*model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *tracingTenantRepository) FindByID(ctx context.Context, id string) (t *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantRepository.FindByID", attribute.String("tenant.id", id))
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *tracingTenantRepository) Create(ctx context.Context, t *model.Tenant) (created *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantRepository.Create", attribute.String("tenant.id", t.ID))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Create(ctx, t)
+}
+
+func (r *tracingTenantRepository) Update(ctx context.Context, id string, t *model.Tenant) (updated *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantRepository.Update", attribute.String("tenant.id", id))
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, id, t)
+}
+
+func (r *tracingTenantRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
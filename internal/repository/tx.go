@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// Transactor 事务管理，业务数据与 outbox 记录在同一事务中写入
+type Transactor interface {
+	// WithinTx 在事务中执行 fn，fn 返回错误时回滚；已在事务中时直接复用外层事务
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// memoryTx 内存事务，记录提交后需要执行的写入和回滚时的撤销操作
+type memoryTx struct {
+	onCommit      []func()
+	onRollback    []func()
+	afterRollback []func()
+}
+
+// memoryTransactor 内存事务实现
+// 仓储写入立即生效并登记撤销操作，fn 失败或 panic 时按逆序撤销；outbox、审计日志、搜索索引等延迟写入在提交后才执行。
+// 事务之间串行执行，但事务外的读取可以看到未提交的写入；替换为数据库实现时应使用数据库事务
+type memoryTransactor struct {
+	mu sync.Mutex // 串行提交，保证 outbox 顺序与提交顺序一致
+}
+
+// NewTransactor 创建事务管理实例（内存实现）
+func NewTransactor() Transactor {
+	return &memoryTransactor{}
+}
+
+func (t *memoryTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*memoryTx); ok {
+		return fn(ctx)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tx := &memoryTx{}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.rollback()
+		}
+	}()
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	committed = true
+	for _, commit := range tx.onCommit {
+		commit()
+	}
+	return nil
+}
+
+// rollback 按登记的逆序执行撤销操作，全部撤销后再执行 afterRollback
+func (tx *memoryTx) rollback() {
+	for i := len(tx.onRollback) - 1; i >= 0; i-- {
+		tx.onRollback[i]()
+	}
+	for _, fn := range tx.afterRollback {
+		fn()
+	}
+}
+
+// afterCommit 在事务中时将写入延迟到提交后执行，否则立即执行
+func afterCommit(ctx context.Context, write func()) {
+	if tx, ok := ctx.Value(txKey{}).(*memoryTx); ok {
+		tx.onCommit = append(tx.onCommit, write)
+		return
+	}
+	write()
+}
+
+// onRollback 在事务中时登记回滚时执行的撤销操作，不在事务中时忽略
+// 撤销操作在仓储的锁之外执行，需要自行加锁
+func onRollback(ctx context.Context, undo func()) {
+	if tx, ok := ctx.Value(txKey{}).(*memoryTx); ok {
+		tx.onRollback = append(tx.onRollback, undo)
+	}
+}
+
+// afterRollback 在事务中时登记回滚完成后执行的操作（如清除缓存），不在事务中时忽略
+func afterRollback(ctx context.Context, fn func()) {
+	if tx, ok := ctx.Value(txKey{}).(*memoryTx); ok {
+		tx.afterRollback = append(tx.afterRollback, fn)
+	}
+}
+
+// undoLocked 登记在 mu 保护下执行的撤销操作
+func undoLocked(ctx context.Context, mu sync.Locker, undo func()) {
+	onRollback(ctx, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		undo()
+	})
+}
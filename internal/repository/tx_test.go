@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"rich_go/internal/model"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/cache"
+)
+
+func TestMemoryTransactorRollsBackWrites(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	users := NewCachingUserRepository(NewUserRepository(), cache.NewLRU(100), CacheOptions{TTL: time.Minute})
+	alice, _ := users.Create(ctx, &model.User{Name: "alice", Email: "alice@example.com"})
+	bob, _ := users.Create(ctx, &model.User{Name: "bob", Email: "bob@example.com"})
+
+	errAbort := errors.New("abort")
+	err := NewTransactor().WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := users.Create(ctx, &model.User{Name: "carol"}); err != nil {
+			return err
+		}
+		if _, err := users.Update(ctx, bob.ID, &model.User{Name: "robert"}); err != nil {
+			return err
+		}
+		// 事务内读取会缓存未提交的值，回滚后应失效
+		if u, _ := users.FindByID(ctx, bob.ID); u.Name != "robert" {
+			t.Errorf("事务内读取 %q, want robert", u.Name)
+		}
+		if err := users.Delete(ctx, alice.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithinTx = %v, want %v", err, errAbort)
+	}
+
+	all, _ := users.FindAll(ctx)
+	if len(all) != 2 || *all[0] != *alice || *all[1] != *bob {
+		t.Errorf("回滚后用户为 %+v, want [%+v %+v]", all, alice, bob)
+	}
+	if u, err := users.FindByID(ctx, bob.ID); err != nil || u.Name != "bob" {
+		t.Errorf("回滚后读取 bob = %+v, %v", u, err)
+	}
+}
+
+func TestMemoryTransactorRollsBackOnPanic(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "acme")
+	coupons := NewCouponRepository()
+	func() {
+		defer func() { recover() }()
+		_ = NewTransactor().WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := coupons.Create(ctx, &model.Coupon{Name: "summer"}); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+	if all, _ := coupons.FindAll(ctx); len(all) != 0 {
+		t.Errorf("panic 后优惠券 %d 条, want 0", len(all))
+	}
+}
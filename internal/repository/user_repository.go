@@ -3,19 +3,26 @@ package repository
 import (
 	"context"
 	"rich_go/internal/model"
+	"slices"
+	"sync"
 )
 
 // UserRepository 用户仓储接口
 type UserRepository interface {
 	FindAll(ctx context.Context) ([]*model.User, error)
 	FindByID(ctx context.Context, id uint) (*model.User, error)
+	// Count 返回当前租户的用户数量，在事务中调用时包含事务内的写入
+	Count(ctx context.Context) (int, error)
 	Create(ctx context.Context, user *model.User) (*model.User, error)
 	Update(ctx context.Context, id uint, user *model.User) (*model.User, error)
 	Delete(ctx context.Context, id uint) error
+	Ping(ctx context.Context) error
 }
 
 // userRepository 用户仓储实现（内存实现，后续可替换为数据库实现）
+// 每个方法只访问 context 中租户的数据，其他租户的记录视为不存在
 type userRepository struct {
+	mu     sync.RWMutex
 	users  []*model.User
 	nextID uint
 }
@@ -29,15 +36,34 @@ func NewUserRepository() UserRepository {
 }
 
 func (r *userRepository) FindAll(ctx context.Context) ([]*model.User, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	// 返回副本，避免外部修改
-	result := make([]*model.User, len(r.users))
-	copy(result, r.users)
+	result := make([]*model.User, 0, len(r.users))
+	for _, user := range r.users {
+		if user.TenantID == tenantID {
+			u := *user
+			result = append(result, &u)
+		}
+	}
 	return result, nil
 }
 
 func (r *userRepository) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, user := range r.users {
-		if user.ID == id {
+		if user.ID == id && user.TenantID == tenantID {
 			// 返回副本
 			u := *user
 			return &u, nil
@@ -46,18 +72,55 @@ func (r *userRepository) FindByID(ctx context.Context, id uint) (*model.User, er
 	return nil, ErrNotFound
 }
 
+func (r *userRepository) Count(ctx context.Context) (int, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := 0
+	for _, u := range r.users {
+		if u.TenantID == tenantID {
+			n++
+		}
+	}
+	return n, nil
+}
+
 func (r *userRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	user.ID = r.nextID
+	user.TenantID = tenantID
 	r.nextID++
 	r.users = append(r.users, user)
+	undoLocked(ctx, &r.mu, func() {
+		r.users = slices.DeleteFunc(r.users, func(u *model.User) bool { return u == user })
+	})
 	// 返回副本
 	u := *user
 	return &u, nil
 }
 
 func (r *userRepository) Update(ctx context.Context, id uint, user *model.User) (*model.User, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	for i, u := range r.users {
-		if u.ID == id {
+		if u.ID == id && u.TenantID == tenantID {
+			before := *u
+			undoLocked(ctx, &r.mu, func() { *u = before })
 			// 更新字段
 			if user.Name != "" {
 				r.users[i].Name = user.Name
@@ -65,6 +128,9 @@ func (r *userRepository) Update(ctx context.Context, id uint, user *model.User)
 			if user.Email != "" {
 				r.users[i].Email = user.Email
 			}
+			if user.Status != "" {
+				r.users[i].Status = user.Status
+			}
 			// 返回副本
 			updated := *r.users[i]
 			return &updated, nil
@@ -74,12 +140,26 @@ func (r *userRepository) Update(ctx context.Context, id uint, user *model.User)
 }
 
 func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	for i, user := range r.users {
-		if user.ID == id {
+		if user.ID == id && user.TenantID == tenantID {
 			r.users = append(r.users[:i], r.users[i+1:]...)
+			undoLocked(ctx, &r.mu, func() {
+				r.users = slices.Insert(r.users, min(i, len(r.users)), user)
+			})
 			return nil
 		}
 	}
 	return ErrNotFound
 }
 
+func (r *userRepository) Ping(ctx context.Context) error {
+	// 内存实现始终可用，数据库实现应在此检查连接
+	return ctx.Err()
+}
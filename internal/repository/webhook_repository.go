@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"rich_go/internal/model"
+)
+
+// WebhookRepository webhook 订阅仓储接口
+type WebhookRepository interface {
+	FindAll(ctx context.Context) ([]*model.Webhook, error)
+	FindByID(ctx context.Context, id uint) (*model.Webhook, error)
+	// FindByEventType 返回 context 中租户订阅了该事件类型的启用中 webhook
+	FindByEventType(ctx context.Context, eventType string) ([]*model.Webhook, error)
+	Create(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	// Update 覆盖 URL、事件类型和启用状态
+	Update(ctx context.Context, id uint, webhook *model.Webhook) (*model.Webhook, error)
+	Delete(ctx context.Context, id uint) error
+	Ping(ctx context.Context) error
+}
+
+// webhookRepository webhook 订阅仓储实现（内存实现，后续可替换为数据库实现）
+// 每个方法只访问 context 中租户的订阅，其他租户的记录视为不存在
+type webhookRepository struct {
+	mu       sync.RWMutex
+	webhooks []*model.Webhook
+	nextID   uint
+}
+
+// NewWebhookRepository 创建 webhook 订阅仓储实例
+func NewWebhookRepository() WebhookRepository {
+	return &webhookRepository{
+		webhooks: make([]*model.Webhook, 0),
+		nextID:   1,
+	}
+}
+
+func (r *webhookRepository) FindAll(ctx context.Context) ([]*model.Webhook, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*model.Webhook, 0, len(r.webhooks))
+	for _, w := range r.webhooks {
+		if w.TenantID == tenantID {
+			result = append(result, copyWebhook(w))
+		}
+	}
+	return result, nil
+}
+
+func (r *webhookRepository) FindByID(ctx context.Context, id uint) (*model.Webhook, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, w := range r.webhooks {
+		if w.ID == id && w.TenantID == tenantID {
+			return copyWebhook(w), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *webhookRepository) FindByEventType(ctx context.Context, eventType string) ([]*model.Webhook, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*model.Webhook
+	for _, w := range r.webhooks {
+		if w.TenantID == tenantID && w.Active && slices.Contains(w.EventTypes, eventType) {
+			result = append(result, copyWebhook(w))
+		}
+	}
+	return result, nil
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w := copyWebhook(webhook)
+	w.ID = r.nextID
+	w.TenantID = tenantID
+	r.nextID++
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = time.Now()
+	}
+	r.webhooks = append(r.webhooks, w)
+	undoLocked(ctx, &r.mu, func() {
+		r.webhooks = slices.DeleteFunc(r.webhooks, func(x *model.Webhook) bool { return x == w })
+	})
+	return copyWebhook(w), nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, id uint, webhook *model.Webhook) (*model.Webhook, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, w := range r.webhooks {
+		if w.ID == id && w.TenantID == tenantID {
+			before := copyWebhook(w)
+			undoLocked(ctx, &r.mu, func() { *w = *before })
+			w.URL = webhook.URL
+			w.EventTypes = slices.Clone(webhook.EventTypes)
+			w.Active = webhook.Active
+			return copyWebhook(w), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, w := range r.webhooks {
+		if w.ID == id && w.TenantID == tenantID {
+			r.webhooks = append(r.webhooks[:i], r.webhooks[i+1:]...)
+			undoLocked(ctx, &r.mu, func() {
+				r.webhooks = slices.Insert(r.webhooks, min(i, len(r.webhooks)), w)
+			})
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *webhookRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// copyWebhook 返回副本，避免外部修改
+func copyWebhook(w *model.Webhook) *model.Webhook {
+	c := *w
+	c.EventTypes = slices.Clone(w.EventTypes)
+	return &c
+}
+
+// WebhookDeliveryRepository webhook 投递记录仓储接口
+type WebhookDeliveryRepository interface {
+	// Create 创建投递记录，同一 webhook 的同一事件已存在时返回 ErrAlreadyExists
+	Create(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error)
+	FindByID(ctx context.Context, id uint) (*model.WebhookDelivery, error)
+	// FindByWebhook 按创建时间倒序返回 webhook 的投递记录，status 为空时返回全部
+	FindByWebhook(ctx context.Context, webhookID uint, status string) ([]*model.WebhookDelivery, error)
+	// FindDue 按创建顺序返回到期的待投递记录
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *model.WebhookDelivery) error
+	// DeleteDeliveredBefore 删除 before 之前投递成功的记录，返回删除数量
+	DeleteDeliveredBefore(ctx context.Context, before time.Time) (int, error)
+	Ping(ctx context.Context) error
+}
+
+// webhookDeliveryRepository webhook 投递记录仓储实现（内存实现，后续可替换为数据库实现）
+type webhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries []*model.WebhookDelivery
+	nextID     uint
+}
+
+// NewWebhookDeliveryRepository 创建 webhook 投递记录仓储实例
+func NewWebhookDeliveryRepository() WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		deliveries: make([]*model.WebhookDelivery, 0),
+		nextID:     1,
+	}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) (*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.deliveries {
+		if d.WebhookID == delivery.WebhookID && d.EventID == delivery.EventID {
+			return nil, ErrAlreadyExists
+		}
+	}
+	d := copyDelivery(delivery)
+	d.ID = r.nextID
+	r.nextID++
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	r.deliveries = append(r.deliveries, d)
+	return copyDelivery(d), nil
+}
+
+func (r *webhookDeliveryRepository) FindByID(ctx context.Context, id uint) (*model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.deliveries {
+		if d.ID == id {
+			return copyDelivery(d), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *webhookDeliveryRepository) FindByWebhook(ctx context.Context, webhookID uint, status string) ([]*model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*model.WebhookDelivery, 0)
+	for i := len(r.deliveries) - 1; i >= 0; i-- {
+		d := r.deliveries[i]
+		if d.WebhookID == webhookID && (status == "" || d.Status == status) {
+			result = append(result, copyDelivery(d))
+		}
+	}
+	return result, nil
+}
+
+func (r *webhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*model.WebhookDelivery
+	for _, d := range r.deliveries {
+		if len(result) >= limit {
+			break
+		}
+		if d.Status == model.DeliveryPending && !d.NextAttemptAt.After(now) {
+			result = append(result, copyDelivery(d))
+		}
+	}
+	return result, nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, d := range r.deliveries {
+		if d.ID == delivery.ID {
+			r.deliveries[i] = copyDelivery(delivery)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *webhookDeliveryRepository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.deliveries[:0]
+	for _, d := range r.deliveries {
+		if d.Status == model.DeliveryDelivered && d.DeliveredAt != nil && d.DeliveredAt.Before(before) {
+			continue
+		}
+		kept = append(kept, d)
+	}
+	deleted := len(r.deliveries) - len(kept)
+	clear(r.deliveries[len(kept):])
+	r.deliveries = kept
+	return deleted, nil
+}
+
+func (r *webhookDeliveryRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// copyDelivery 返回副本，避免外部修改
+func copyDelivery(d *model.WebhookDelivery) *model.WebhookDelivery {
+	c := *d
+	c.Payload = slices.Clone(d.Payload)
+	c.Attempts = slices.Clone(d.Attempts)
+	if d.DeliveredAt != nil {
+		t := *d.DeliveredAt
+		c.DeliveredAt = &t
+	}
+	return &c
+}
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actionParam 自定义方法路由的参数名
+const actionParam = "action"
+
+// actionRoute 同一资源上的自定义方法
+type actionRoute struct {
+	path     string // 资源的完整路径，例如 /api/v1/coupons
+	handlers map[string]gin.HandlerFunc
+}
+
+// action 注册自定义方法 POST {relativePath}:{name}，返回资源的完整路径，由 Routes.Action 调用
+// Gin 不支持路径中的字面量冒号，同一资源的自定义方法共用参数路由 {relativePath}:action 并按名称分发，
+// 接口描述使用实际路径，例如 /api/v1/coupons:batch
+func (a *API) action(g *gin.RouterGroup, relativePath, name string, handler gin.HandlerFunc) string {
+	fullPath := strings.TrimSuffix(g.BasePath(), "/") + relativePath
+	route, ok := a.actions[fullPath]
+	if !ok {
+		route = &actionRoute{path: fullPath, handlers: make(map[string]gin.HandlerFunc)}
+		a.actions[fullPath] = route
+		g.POST(relativePath+":"+actionParam, route.dispatch)
+		a.spec.Ignore(fullPath + ":" + actionParam)
+	}
+	if _, dup := route.handlers[name]; dup {
+		panic("router: 自定义方法 " + fullPath + ":" + name + " 重复注册")
+	}
+	route.handlers[name] = handler
+	return fullPath
+}
+
+// dispatch 按路径中冒号后的名称调用自定义方法，参数值包含冒号，例如 ":batch"
+func (r *actionRoute) dispatch(c *gin.Context) {
+	name, ok := strings.CutPrefix(c.Param(actionParam), ":")
+	handler, found := r.handlers[name]
+	if !ok || !found {
+		response.ErrorFrom(c, errors.ErrNotFound)
+		return
+	}
+	handler(c)
+}
+
+// actionRoutes 返回自定义方法的实际路由，用于生成接口描述
+func (a *API) actionRoutes() gin.RoutesInfo {
+	var routes gin.RoutesInfo
+	for _, route := range a.actions {
+		for name, handler := range route.handlers {
+			routes = append(routes, gin.RouteInfo{
+				Method:  http.MethodPost,
+				Path:    route.path + ":" + name,
+				Handler: runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name(),
+			})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	return routes
+}
+
+// expandActions 将自定义方法的分发路由替换为各方法的实际路由
+func (a *API) expandActions(routes gin.RoutesInfo) gin.RoutesInfo {
+	expanded := make(gin.RoutesInfo, 0, len(routes))
+	for _, route := range routes {
+		if !strings.HasSuffix(route.Path, ":"+actionParam) {
+			expanded = append(expanded, route)
+		}
+	}
+	return append(expanded, a.actionRoutes()...)
+}
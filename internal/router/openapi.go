@@ -0,0 +1,84 @@
+package router
+
+import (
+	"log"
+	"net/http"
+
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/server/handlers"
+	"rich_go/pkg/openapi"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI 文档路径
+const (
+	OpenAPIPath = "/openapi.json"
+	DocsPath    = "/docs"
+)
+
+// apiTitle、apiVersion OpenAPI 文档信息
+const (
+	apiTitle   = "Rich_GO API"
+	apiVersion = "1.0.0"
+)
+
+// newSpec 创建接口描述，统一响应结构和错误格式对所有接口生效
+func newSpec() *openapi.Spec {
+	spec := openapi.NewSpec(apiTitle, apiVersion).
+		Envelope(response.Response{}, "data").
+		Problem(response.Problem{})
+	spec.Ignore("/metrics", OpenAPIPath, DocsPath)
+	describeHealthRoutes(spec)
+	return spec
+}
+
+// setupOpenAPI 根据已注册的路由和自定义方法生成 OpenAPI 文档，并注册文档接口
+// 通过 Routes 注册的路由总有接口描述；直接在 Gin 路由组上注册的路由没有描述，此时记录日志且文档不包含该路由，
+// 测试中由 ValidateSpec 报错
+func setupOpenAPI(router *gin.Engine, cfg config.APIConfig, spec *openapi.Spec, actions gin.RoutesInfo) {
+	doc, err := buildSpec(router, cfg, spec, actions)
+	if err != nil {
+		log.Printf("OpenAPI 文档与路由不一致:\n%v", err)
+	}
+
+	router.GET(OpenAPIPath, openapi.JSONHandler(doc))
+	router.GET(DocsPath, openapi.UIHandler(apiTitle, OpenAPIPath))
+}
+
+// buildSpec 标记已弃用的版本并生成文档
+func buildSpec(router *gin.Engine, cfg config.APIConfig, spec *openapi.Spec, actions gin.RoutesInfo) (*openapi.Document, error) {
+	for version, v := range cfg.Versions {
+		if v.Deprecated {
+			spec.Deprecate(versionPath(version) + "/")
+		}
+	}
+	return spec.Build(append(router.Routes(), actions...))
+}
+
+// ValidateSpec 在新的 Gin 实例上注册 registrars，检查每个路由都有接口描述、每个接口描述都有对应路由
+// 用于测试，发现绕过 Routes 注册的路由
+func ValidateSpec(cfg config.APIConfig, registrars ...Registrar) error {
+	engine := gin.New()
+	api := registerRoutes(engine, cfg, handlers.NewHealthHandler(nil), registrars...)
+	_, err := buildSpec(engine, cfg, api.spec, api.actionRoutes())
+	return err
+}
+
+// describeHealthRoutes 描述健康检查接口
+func describeHealthRoutes(spec *openapi.Spec) {
+	tags := []string{"health"}
+	for path, summary := range map[string]string{
+		"/health": "健康检查",
+		"/livez":  "存活检查",
+		"/readyz": "就绪检查",
+	} {
+		spec.Handle(http.MethodGet, path, openapi.Operation{
+			Summary: summary, Tags: tags,
+			Response: health.Report{},
+			Errors:   []int{http.StatusServiceUnavailable},
+		})
+	}
+}
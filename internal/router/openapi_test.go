@@ -0,0 +1,126 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"rich_go/internal/config"
+	"rich_go/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registrarFunc 以函数实现 Registrar
+type registrarFunc func(api *API)
+
+func (f registrarFunc) RegisterRoutes(api *API) { f(api) }
+
+func TestValidateSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) {}
+	describe := func(api *API, method, path string) {
+		api.spec.Handle(method, versionPath(APIVersionV1)+path, openapi.Operation{Summary: path})
+	}
+
+	tests := []struct {
+		name      string
+		registrar registrarFunc
+		want      []string // 错误中应包含的内容，为空时应一致
+	}{
+		{"通过 Routes 注册", func(api *API) {
+			things := api.Routes(APIVersionV1, "things").Group("/things")
+			things.GET("", openapi.Operation{Summary: "列表"}, noop)
+			things.DELETE("/:id", openapi.Operation{Summary: "删除"}, noop)
+			api.Routes(APIVersionV1).Action("/things", "batch", openapi.Operation{Summary: "批量"}, noop)
+			api.Admin().GET("/stats", openapi.Operation{Summary: "统计"}, noop)
+		}, nil},
+		{"一致", func(api *API) {
+			api.Group(APIVersionV1).GET("/things/:id", noop)
+			describe(api, http.MethodGet, "/things/:id")
+		}, nil},
+		{"路由缺少描述", func(api *API) {
+			api.Group(APIVersionV1).GET("/things", noop)
+			api.Group(APIVersionV1).POST("/things", noop)
+			describe(api, http.MethodGet, "/things")
+		}, []string{"POST /api/v1/things"}},
+		{"描述没有对应路由", func(api *API) {
+			api.Group(APIVersionV1).GET("/things", noop)
+			describe(api, http.MethodGet, "/things")
+			describe(api, http.MethodDelete, "/things/:id")
+		}, []string{"DELETE /api/v1/things/:id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSpec(config.APIConfig{}, tt.registrar)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("ValidateSpec = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateSpec = nil, want 包含 %v 的错误", tt.want)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("ValidateSpec = %v, want 包含 %q", err, w)
+				}
+			}
+		})
+	}
+}
+
+func TestSetupRoutesDoesNotPanicOnDrift(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	undocumented := registrarFunc(func(api *API) {
+		api.Group(APIVersionV1).GET("/things", func(c *gin.Context) {})
+	})
+	routes := SetupRoutes(engine, config.APIConfig{}, nil, undocumented)
+
+	found := false
+	for _, r := range routes {
+		found = found || r.Path == OpenAPIPath
+	}
+	if !found {
+		t.Errorf("接口描述不一致时未注册 %s", OpenAPIPath)
+	}
+}
+
+func TestRoutesDescribeRegisteredPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	noop := func(c *gin.Context) {}
+	registrar := registrarFunc(func(api *API) {
+		v1 := api.Routes(APIVersionV1, "things")
+		v1.Action("/things", "batch", openapi.Operation{Summary: "批量"}, noop)
+		things := v1.Group("/things")
+		things.GET("", openapi.Operation{Summary: "列表"}, noop)
+		things.POST("/:id/parts/", openapi.Operation{Summary: "添加", Tags: []string{"parts"}}, noop)
+	})
+	api := registerRoutes(engine, config.APIConfig{}, nil, registrar)
+	doc, err := buildSpec(engine, config.APIConfig{}, api.spec, api.actionRoutes())
+	if err != nil {
+		t.Fatalf("buildSpec: %v", err)
+	}
+
+	tests := []struct {
+		path, method string
+		tags         []string
+	}{
+		{"/api/v1/things", "get", []string{"things"}},
+		{"/api/v1/things/{id}/parts/", "post", []string{"parts"}},
+		{"/api/v1/things:batch", "post", []string{"things"}},
+	}
+	for _, tt := range tests {
+		op := doc.Paths[tt.path][tt.method]
+		if op == nil {
+			t.Errorf("文档中没有 %s %s", tt.method, tt.path)
+			continue
+		}
+		if strings.Join(op.Tags, ",") != strings.Join(tt.tags, ",") {
+			t.Errorf("%s %s 的标签为 %v, want %v", tt.method, tt.path, op.Tags, tt.tags)
+		}
+	}
+}
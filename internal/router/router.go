@@ -1,24 +1,85 @@
 package router
 
 import (
+	"rich_go/internal/config"
+	"rich_go/internal/metrics"
 	"rich_go/internal/server/handlers"
+	"rich_go/pkg/openapi"
+
 	"github.com/gin-gonic/gin"
 )
 
-// SetupRoutes 设置所有路由
-func SetupRoutes(
-	router *gin.Engine,
-	userHandler *handlers.UserHandler,
-	couponHandler *handlers.CouponHandler,
-) {
-	// 健康检查接口
-	router.GET("/health", handlers.HealthCheck)
+// AdminPrefix 管理接口在版本路由组下的路径前缀，例如 /api/v1/admin
+const AdminPrefix = "/admin"
+
+// PlatformPaths 平台管理接口的路径前缀，这些接口不属于任何租户，且未启用认证时同样需要认证
+func PlatformPaths() []string {
+	return []string{versionPath(APIVersionV1) + AdminPrefix + "/"}
+}
+
+// Registrar 注册业务路由及对应的接口描述，由各业务模块实现
+type Registrar interface {
+	RegisterRoutes(api *API)
+}
+
+// API 版本化业务路由的注册入口
+type API struct {
+	engine *gin.Engine
+	cfg    config.APIConfig
+	groups map[string]*gin.RouterGroup
+	// actions 自定义方法，按资源的完整路径索引
+	actions map[string]*actionRoute
 
-	// API v1 路由组
-	v1 := router.Group("/api/v1")
-	{
-		SetupUserRoutes(v1, userHandler)
-		SetupCouponRoutes(v1, couponHandler)
+	// spec OpenAPI 接口描述，由 Routes 在注册路由时登记；绕过 Routes 注册的路由由 ValidateSpec 检查出来
+	spec *openapi.Spec
+}
+
+// Group 返回版本路由组，例如 Group(APIVersionV1) 对应 /api/v1
+// 直接在返回的路由组上注册的路由没有接口描述，业务路由应通过 Routes 注册
+func (a *API) Group(version string) *gin.RouterGroup {
+	if g, ok := a.groups[version]; ok {
+		return g
 	}
+	g := versionGroup(a.engine, version, a.cfg)
+	a.groups[version] = g
+	return g
+}
+
+// Admin 返回管理接口路由组 /api/v1/admin
+func (a *API) Admin(tags ...string) *Routes {
+	return a.Routes(APIVersionV1, tags...).Group(AdminPrefix)
 }
 
+// SetupRoutes 设置所有路由，业务路由由 registrars 注册
+// 返回已注册的路由，自定义方法按实际路径列出
+func SetupRoutes(router *gin.Engine, cfg config.APIConfig, healthHandler *handlers.HealthHandler, registrars ...Registrar) gin.RoutesInfo {
+	api := registerRoutes(router, cfg, healthHandler, registrars...)
+
+	// OpenAPI 文档（需在其他路由注册完成后生成）
+	setupOpenAPI(router, cfg, api.spec, api.actionRoutes())
+	return api.expandActions(router.Routes())
+}
+
+// registerRoutes 注册健康检查、指标及 registrars 的业务路由，返回收集了接口描述的 API
+func registerRoutes(router *gin.Engine, cfg config.APIConfig, healthHandler *handlers.HealthHandler, registrars ...Registrar) *API {
+	// 健康检查接口
+	router.GET("/health", healthHandler.HealthCheck)
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+
+	// Prometheus 指标接口
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// 业务路由
+	api := &API{
+		engine:  router,
+		cfg:     cfg,
+		groups:  make(map[string]*gin.RouterGroup),
+		actions: make(map[string]*actionRoute),
+		spec:    newSpec(),
+	}
+	for _, r := range registrars {
+		r.RegisterRoutes(api)
+	}
+	return api
+}
@@ -0,0 +1,81 @@
+package router
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"rich_go/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes 业务路由组，注册路由时同时附上接口描述，文档中的路径取自实际注册的路由
+type Routes struct {
+	api   *API
+	group *gin.RouterGroup
+	tags  []string
+}
+
+// Routes 返回版本路由组，例如 Routes(APIVersionV1, "users") 对应 /api/v1
+// tags 为未指定 Tags 的接口使用的默认标签
+func (a *API) Routes(version string, tags ...string) *Routes {
+	return &Routes{api: a, group: a.Group(version), tags: tags}
+}
+
+// Group 返回子路由组，沿用默认标签
+func (r *Routes) Group(relativePath string) *Routes {
+	return &Routes{api: r.api, group: r.group.Group(relativePath), tags: r.tags}
+}
+
+// GET 注册 GET 路由及其接口描述
+func (r *Routes) GET(relativePath string, op openapi.Operation, handler gin.HandlerFunc) {
+	r.Handle(http.MethodGet, relativePath, op, handler)
+}
+
+// POST 注册 POST 路由及其接口描述
+func (r *Routes) POST(relativePath string, op openapi.Operation, handler gin.HandlerFunc) {
+	r.Handle(http.MethodPost, relativePath, op, handler)
+}
+
+// PUT 注册 PUT 路由及其接口描述
+func (r *Routes) PUT(relativePath string, op openapi.Operation, handler gin.HandlerFunc) {
+	r.Handle(http.MethodPut, relativePath, op, handler)
+}
+
+// DELETE 注册 DELETE 路由及其接口描述
+func (r *Routes) DELETE(relativePath string, op openapi.Operation, handler gin.HandlerFunc) {
+	r.Handle(http.MethodDelete, relativePath, op, handler)
+}
+
+// Handle 注册路由，并以实际路由路径登记接口描述
+func (r *Routes) Handle(method, relativePath string, op openapi.Operation, handler gin.HandlerFunc) {
+	r.group.Handle(method, relativePath, handler)
+	r.api.spec.Handle(method, joinPath(r.group.BasePath(), relativePath), r.withTags(op))
+}
+
+// Action 注册自定义方法 POST {relativePath}:{name} 及其接口描述，例如 Action("/coupons", "batch", ...) 对应 POST /api/v1/coupons:batch
+func (r *Routes) Action(relativePath, name string, op openapi.Operation, handler gin.HandlerFunc) {
+	fullPath := r.api.action(r.group, relativePath, name, handler)
+	r.api.spec.Handle(http.MethodPost, fullPath+":"+name, r.withTags(op))
+}
+
+// withTags 接口未指定标签时使用路由组的默认标签
+func (r *Routes) withTags(op openapi.Operation) openapi.Operation {
+	if len(op.Tags) == 0 {
+		op.Tags = r.tags
+	}
+	return op
+}
+
+// joinPath 按 Gin 的规则拼接路由组路径和相对路径，保留相对路径末尾的斜杠
+func joinPath(base, relativePath string) string {
+	if relativePath == "" {
+		return base
+	}
+	joined := path.Join(base, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"rich_go/internal/config"
+	"rich_go/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIPrefix 版本化 API 的路径前缀，版本路由组为 /api/<version>
+const APIPrefix = "/api"
+
+// API 版本，新增版本时并行注册路由组，与旧版本共用 Service 层
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+)
+
+// versionPath 返回版本路由组的路径
+func versionPath(version string) string {
+	return APIPrefix + "/" + version
+}
+
+// versionGroup 创建版本路由组，响应携带版本及弃用相关响应头并按版本统计调用量
+func versionGroup(router *gin.Engine, version string, cfg config.APIConfig) *gin.RouterGroup {
+	group := router.Group(versionPath(version))
+	group.Use(middleware.APIVersion(versionPolicy(version, cfg)))
+	return group
+}
+
+// versionPolicy 根据配置生成版本的弃用计划
+func versionPolicy(version string, cfg config.APIConfig) middleware.VersionPolicy {
+	v := cfg.Versions[version]
+	policy := middleware.VersionPolicy{
+		Version:      version,
+		Deprecated:   v.Deprecated,
+		DeprecatedAt: v.DeprecatedAt,
+		Sunset:       v.Sunset,
+	}
+	if v.Successor != "" {
+		policy.SuccessorURL = versionPath(v.Successor)
+	}
+	return policy
+}
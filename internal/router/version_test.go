@@ -0,0 +1,108 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rich_go/internal/config"
+	"rich_go/internal/metrics"
+	"rich_go/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionTestEngine 在 v1、v2 两个版本下注册 path 路由，v1 按 cfg 弃用
+// 指标在测试间共享，各测试使用不同的 path 以免计数互相影响
+func versionTestEngine(t *testing.T, cfg config.APIConfig, path string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	noop := func(c *gin.Context) { c.Status(http.StatusOK) }
+	registrar := registrarFunc(func(api *API) {
+		api.Group(APIVersionV1).GET(path, noop)
+		api.Group(APIVersionV2).GET(path, noop)
+	})
+	SetupRoutes(engine, cfg, nil, registrar)
+	return engine
+}
+
+func TestVersionHeaders(t *testing.T) {
+	deprecatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 12, 31, 0, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	tests := []struct {
+		name            string
+		v1              config.APIVersionConfig
+		path            string
+		wantVersion     string
+		wantDeprecation string
+		wantSunset      string
+		wantLink        string
+	}{
+		{"未弃用", config.APIVersionConfig{}, "/api/v1/things", "v1", "", "", ""},
+		{"弃用但未指定时间", config.APIVersionConfig{Deprecated: true}, "/api/v1/things", "v1", "true", "", ""},
+		{"完整弃用计划", config.APIVersionConfig{
+			Deprecated:   true,
+			DeprecatedAt: deprecatedAt,
+			Sunset:       sunset,
+			Successor:    APIVersionV2,
+		}, "/api/v1/things", "v1", "@1767225600", "Wed, 30 Dec 2026 16:00:00 GMT", `</api/v2>; rel="successor-version"`},
+		{"未弃用时忽略下线时间和替代版本", config.APIVersionConfig{
+			Sunset:    sunset,
+			Successor: APIVersionV2,
+		}, "/api/v1/things", "v1", "", "", ""},
+		{"弃用 v1 不影响 v2", config.APIVersionConfig{
+			Deprecated: true,
+			Sunset:     sunset,
+			Successor:  APIVersionV2,
+		}, "/api/v2/things", "v2", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.APIConfig{Versions: map[string]config.APIVersionConfig{APIVersionV1: tt.v1}}
+			engine := versionTestEngine(t, cfg, "/things")
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("状态码 = %d, want %d", w.Code, http.StatusOK)
+			}
+			checks := []struct{ header, want string }{
+				{middleware.APIVersionHeader, tt.wantVersion},
+				{middleware.DeprecationHeader, tt.wantDeprecation},
+				{middleware.SunsetHeader, tt.wantSunset},
+				{"Link", tt.wantLink},
+			}
+			for _, c := range checks {
+				if got := w.Header().Get(c.header); got != c.want {
+					t.Errorf("%s = %q, want %q", c.header, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestVersionMetrics(t *testing.T) {
+	cfg := config.APIConfig{Versions: map[string]config.APIVersionConfig{
+		APIVersionV1: {Deprecated: true, Successor: APIVersionV2},
+	}}
+	engine := versionTestEngine(t, cfg, "/widgets")
+	for _, path := range []string{"/api/v1/widgets", "/api/v1/widgets", "/api/v2/widgets"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`rich_go_api_version_requests_total{caller="anonymous",deprecated="true",route="GET /api/v1/widgets",version="v1"} 2`,
+		`rich_go_api_version_requests_total{caller="anonymous",deprecated="false",route="GET /api/v2/widgets",version="v2"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("指标中缺少 %s", want)
+		}
+	}
+}
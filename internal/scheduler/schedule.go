@@ -0,0 +1,188 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 调度计划
+type Schedule interface {
+	// Next 返回 t 之后的下一次运行时间，不存在时返回零值
+	Next(t time.Time) time.Time
+	String() string
+}
+
+// Every 固定间隔调度
+func Every(d time.Duration) Schedule {
+	if d < time.Second {
+		d = time.Second
+	}
+	return interval(d)
+}
+
+type interval time.Duration
+
+func (i interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+func (i interval) String() string {
+	return "@every " + time.Duration(i).String()
+}
+
+// cronSchedule 5 字段 cron 表达式：分 时 日 月 周
+type cronSchedule struct {
+	expr                         string
+	minute, hour, dom, month     uint64
+	dow                          uint64
+	domRestricted, dowRestricted bool
+}
+
+// 预定义表达式
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse 解析调度表达式
+// 支持 5 字段 cron（"*/15 * * * *"，字段支持 *、列表、范围和步长，周日为 0 或 7）、
+// 预定义表达式（@hourly、@daily 等）以及 "@every 5m"
+func Parse(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := strings.CutPrefix(expr, "@every "); ok {
+		dur, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil || dur <= 0 {
+			return nil, fmt.Errorf("无效的调度间隔 %q", expr)
+		}
+		return Every(dur), nil
+	}
+	spec := expr
+	if d, ok := descriptors[expr]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron 表达式 %q 应包含 5 个字段", expr)
+	}
+	s := &cronSchedule{expr: expr}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("cron 表达式 %q 的分钟字段: %w", expr, err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("cron 表达式 %q 的小时字段: %w", expr, err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("cron 表达式 %q 的日期字段: %w", expr, err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("cron 表达式 %q 的月份字段: %w", expr, err)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("cron 表达式 %q 的星期字段: %w", expr, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // 7 与 0 都表示周日
+	}
+	s.domRestricted = fields[2] != "*" && !strings.HasPrefix(fields[2], "*/")
+	s.dowRestricted = fields[4] != "*" && !strings.HasPrefix(fields[4], "*/")
+	return s, nil
+}
+
+// MustParse 解析调度表达式，失败时 panic，用于常量表达式
+func MustParse(expr string) Schedule {
+	s, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// parseField 解析单个字段为位集合
+func parseField(field string, lo, hi int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("无效的步长 %q", part)
+			}
+			step = n
+		}
+
+		start, end := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = strconv.Atoi(a); err != nil {
+				return 0, fmt.Errorf("无效的值 %q", part)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(b); err != nil {
+					return 0, fmt.Errorf("无效的值 %q", part)
+				}
+			} else if hasStep {
+				end = hi
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("%q 超出范围 %d-%d", part, lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	// 从下一分钟开始逐级匹配，最多搜索 5 年
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches 日期与星期都有限制时满足其一即可，与标准 cron 一致
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+func (s *cronSchedule) String() string {
+	return s.expr
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"空", ""},
+		{"字段不足", "* * * *"},
+		{"字段过多", "* * * * * *"},
+		{"分钟超出范围", "60 * * * *"},
+		{"小时超出范围", "0 24 * * *"},
+		{"日期为 0", "0 0 0 * *"},
+		{"月份超出范围", "0 0 1 13 *"},
+		{"星期超出范围", "0 0 * * 8"},
+		{"范围倒置", "30-10 * * * *"},
+		{"步长为 0", "*/0 * * * *"},
+		{"无效的值", "a * * * *"},
+		{"未知的预定义表达式", "@minutely"},
+		{"无效的间隔", "@every soon"},
+		{"间隔为负", "@every -5m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if s, err := Parse(tt.expr); err == nil {
+				t.Errorf("Parse(%q) = %v, want 错误", tt.expr, s)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2026-03-14 是周六
+	from := time.Date(2026, 3, 14, 10, 7, 30, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"每分钟从下一分钟开始", "* * * * *", at(3, 14, 10, 8)},
+		{"步长", "*/15 * * * *", at(3, 14, 10, 15)},
+		{"起点加步长", "5/20 * * * *", at(3, 14, 10, 25)},
+		{"列表", "0,30 * * * *", at(3, 14, 10, 30)},
+		{"范围", "0 12-14 * * *", at(3, 14, 12, 0)},
+		{"当天已过则到次日", "0 9 * * *", at(3, 15, 9, 0)},
+		{"指定日期跨月", "0 0 1 * *", at(4, 1, 0, 0)},
+		{"指定月份", "0 0 1 6 *", at(6, 1, 0, 0)},
+		{"星期一", "0 9 * * 1", at(3, 16, 9, 0)},
+		{"周日可写为 7", "0 9 * * 7", at(3, 15, 9, 0)},
+		{"日期与星期同时限制时满足其一", "0 0 20 * 1", at(3, 16, 0, 0)},
+		{"@hourly", "@hourly", at(3, 14, 11, 0)},
+		{"@daily", "@daily", at(3, 15, 0, 0)},
+		{"@weekly", "@weekly", at(3, 15, 0, 0)},
+		{"@monthly", "@monthly", at(4, 1, 0, 0)},
+		{"@yearly", "@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"闰年 2 月 29 日", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"不存在的日期", "0 0 31 2 *", time.Time{}},
+		{"固定间隔", "@every 1m30s", from.Add(90 * time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Parse(%q).Next(%v) = %v, want %v", tt.expr, from, got, tt.want)
+			}
+			if s.String() != tt.expr {
+				t.Errorf("String() = %q, want %q", s.String(), tt.expr)
+			}
+		})
+	}
+}
+
+func TestEveryMinimum(t *testing.T) {
+	from := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	if got := Every(time.Millisecond).Next(from); !got.Equal(from.Add(time.Second)) {
+		t.Errorf("Every(1ms).Next = %v, want 间隔不小于 1s", got)
+	}
+}
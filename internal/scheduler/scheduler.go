@@ -0,0 +1,386 @@
+// Package scheduler 定时任务调度
+// 支持 cron 与固定间隔调度、有界并发、单次超时、panic 隔离、随机抖动、防止重叠执行，
+// 以及多副本部署时只在主副本执行的任务
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"rich_go/internal/health"
+	"rich_go/internal/metrics"
+)
+
+// 单次运行结果
+const (
+	ResultSuccess   = "success"
+	ResultFailed    = "failed"
+	ResultTimeout   = "timeout"
+	ResultPanic     = "panic"
+	ResultCanceled  = "canceled"           // 应用关闭时被取消
+	ResultOverlap   = "skipped_overlap"    // 上一次运行尚未结束
+	ResultNotLeader = "skipped_not_leader" // 当前副本不是主副本
+)
+
+var (
+	// ErrJobNotFound 任务不存在
+	ErrJobNotFound = errors.New("scheduler: job not found")
+	// ErrJobRunning 任务正在运行
+	ErrJobRunning = errors.New("scheduler: job is running")
+)
+
+// Job 定时任务
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+
+	Timeout    time.Duration // 单次运行超时，0 时使用 Options.DefaultTimeout
+	Jitter     time.Duration // 每次调度随机推迟 [0, Jitter)，避免多个任务或副本同时触发
+	LeaderOnly bool          // 只在主副本执行
+}
+
+// Leader 判断当前副本是否为主副本，多副本部署时可替换为基于分布式锁的实现
+type Leader interface {
+	IsLeader(ctx context.Context) bool
+}
+
+// StaticLeader 固定的主副本判定，单副本部署时为 true
+type StaticLeader bool
+
+func (l StaticLeader) IsLeader(ctx context.Context) bool {
+	return bool(l)
+}
+
+// Options 调度器参数
+type Options struct {
+	Workers        int           // 同时运行的任务数上限
+	DefaultTimeout time.Duration // 任务未设置超时时使用
+	Leader         Leader        // 为 nil 时视为主副本
+}
+
+// Status 任务状态
+type Status struct {
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	LeaderOnly   bool       `json:"leaderOnly"`
+	Running      bool       `json:"running"`
+	NextRun      time.Time  `json:"nextRun"`
+	LastStart    *time.Time `json:"lastStart,omitempty"`
+	LastDuration int64      `json:"lastDurationMs"`
+	LastResult   string     `json:"lastResult,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+	Runs         int64      `json:"runs"`
+	Failures     int64      `json:"failures"`
+	Skipped      int64      `json:"skipped"`
+}
+
+// entry 调度器内部的任务记录
+type entry struct {
+	job    Job
+	status Status
+}
+
+// Scheduler 定时任务调度器
+type Scheduler struct {
+	opts      Options
+	heartbeat *health.Heartbeat
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	runCtx  context.Context // Run 的 ctx，手动触发的任务也随其取消
+	wake    chan struct{}
+
+	workers chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New 创建调度器
+func New(opts Options) *Scheduler {
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+	if opts.DefaultTimeout <= 0 {
+		opts.DefaultTimeout = 5 * time.Minute
+	}
+	if opts.Leader == nil {
+		opts.Leader = StaticLeader(true)
+	}
+	return &Scheduler{
+		opts:      opts,
+		heartbeat: health.NewHeartbeat(time.Minute),
+		entries:   make(map[string]*entry),
+		wake:      make(chan struct{}, 1),
+		workers:   make(chan struct{}, opts.Workers),
+	}
+}
+
+// Register 注册任务，名称重复时返回错误
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return errors.New("scheduler: 任务必须设置 Name、Schedule 和 Run")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, dup := s.entries[job.Name]; dup {
+		return fmt.Errorf("scheduler: 任务 %s 重复注册", job.Name)
+	}
+	e := &entry{job: job, status: Status{
+		Name:       job.Name,
+		Schedule:   job.Schedule.String(),
+		LeaderOnly: job.LeaderOnly,
+	}}
+	e.status.NextRun = s.next(job, time.Now())
+	s.entries[job.Name] = e
+	s.notify()
+	return nil
+}
+
+// Heartbeat 调度循环的心跳，可注册为就绪检查
+func (s *Scheduler) Heartbeat() *health.Heartbeat {
+	return s.heartbeat
+}
+
+// Run 运行调度循环，ctx 取消后停止调度并等待运行中的任务退出
+// 运行中的任务通过 ctx 收到取消信号
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.runCtx != nil {
+		s.mu.Unlock()
+		return errors.New("scheduler: 已在运行")
+	}
+	s.runCtx = ctx
+	s.mu.Unlock()
+
+	defer s.wg.Wait()
+
+	// 至少每 15 秒醒来一次上报心跳
+	const maxSleep = 15 * time.Second
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		s.heartbeat.Beat()
+		now := time.Now()
+		wait := maxSleep
+		for _, e := range s.due(now) {
+			s.dispatch(ctx, e)
+		}
+		if next := s.earliest(); !next.IsZero() && next.Sub(now) < wait {
+			wait = max(next.Sub(now), 0)
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.wake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-timer.C:
+		}
+	}
+}
+
+// Trigger 立即运行一次任务，不检查主副本，任务正在运行时返回 ErrJobRunning
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if s.runCtx == nil || s.runCtx.Err() != nil {
+		return errors.New("scheduler: 未在运行")
+	}
+	if e.status.Running {
+		return ErrJobRunning
+	}
+	e.status.Running = true
+
+	s.wg.Add(1)
+	go s.execute(s.runCtx, e)
+	return nil
+}
+
+// Statuses 返回所有任务的状态，按名称排序
+func (s *Scheduler) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Status, 0, len(s.entries))
+	for _, e := range s.entries {
+		result = append(result, e.status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// due 返回到期的任务并计算其下一次运行时间
+func (s *Scheduler) due(now time.Time) []*entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*entry
+	for _, e := range s.entries {
+		if e.status.NextRun.IsZero() || e.status.NextRun.After(now) {
+			continue
+		}
+		e.status.NextRun = s.next(e.job, now)
+		result = append(result, e)
+	}
+	return result
+}
+
+// earliest 返回最早的下一次运行时间
+func (s *Scheduler) earliest() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest time.Time
+	for _, e := range s.entries {
+		if n := e.status.NextRun; !n.IsZero() && (earliest.IsZero() || n.Before(earliest)) {
+			earliest = n
+		}
+	}
+	return earliest
+}
+
+// dispatch 检查重叠和主副本后在工作池中运行任务
+func (s *Scheduler) dispatch(ctx context.Context, e *entry) {
+	s.mu.Lock()
+	if e.status.Running {
+		s.skip(e, ResultOverlap)
+		s.mu.Unlock()
+		return
+	}
+	e.status.Running = true
+	s.mu.Unlock()
+
+	if e.job.LeaderOnly && !s.opts.Leader.IsLeader(ctx) {
+		s.mu.Lock()
+		e.status.Running = false
+		s.skip(e, ResultNotLeader)
+		s.mu.Unlock()
+		return
+	}
+
+	s.wg.Add(1)
+	go s.execute(ctx, e)
+}
+
+// skip 记录跳过的运行，调用方需持有锁
+func (s *Scheduler) skip(e *entry, result string) {
+	e.status.Skipped++
+	e.status.LastResult = result
+	metrics.ObserveJobSkipped(e.job.Name, result)
+}
+
+// execute 占用工作池名额运行任务，隔离 panic 并记录结果
+func (s *Scheduler) execute(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		e.status.Running = false
+		s.mu.Unlock()
+	}()
+
+	select {
+	case s.workers <- struct{}{}:
+		defer func() { <-s.workers }()
+	case <-ctx.Done():
+		return
+	}
+
+	timeout := e.job.Timeout
+	if timeout <= 0 {
+		timeout = s.opts.DefaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	s.mu.Lock()
+	e.status.LastStart = &start
+	s.mu.Unlock()
+
+	err := safeRun(runCtx, e.job.Run)
+	duration := time.Since(start)
+
+	var pe *panicError
+	result := ResultSuccess
+	switch {
+	case err == nil:
+	case errors.As(err, &pe):
+		result = ResultPanic
+		log.Printf("定时任务 %s panic: %v\n%s", e.job.Name, pe.value, pe.stack)
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		result = ResultTimeout
+	case ctx.Err() != nil:
+		result = ResultCanceled
+	default:
+		result = ResultFailed
+	}
+	if err != nil && result != ResultPanic {
+		log.Printf("定时任务 %s 运行失败（%s）: %v", e.job.Name, result, err)
+	}
+	metrics.ObserveJobRun(e.job.Name, result, duration)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.status.Runs++
+	e.status.LastDuration = duration.Milliseconds()
+	e.status.LastResult = result
+	e.status.LastError = ""
+	if err != nil {
+		e.status.Failures++
+		e.status.LastError = err.Error()
+	}
+}
+
+// next 计算下一次运行时间并加入抖动
+func (s *Scheduler) next(job Job, after time.Time) time.Time {
+	t := job.Schedule.Next(after)
+	if t.IsZero() || job.Jitter <= 0 {
+		return t
+	}
+	return t.Add(rand.N(job.Jitter))
+}
+
+// notify 唤醒调度循环重新计算等待时间
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// panicError 任务 panic 转换成的错误
+type panicError struct {
+	value interface{}
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// safeRun 运行任务，panic 转换为错误
+func safeRun(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &panicError{value: r, stack: debug.Stack()}
+		}
+	}()
+	return run(ctx)
+}
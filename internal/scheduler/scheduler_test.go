@@ -0,0 +1,188 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tick 测试用的短间隔调度，Every 的最小间隔为 1s
+type tick time.Duration
+
+func (d tick) Next(t time.Time) time.Time { return t.Add(time.Duration(d)) }
+func (d tick) String() string             { return "tick" }
+
+// startScheduler 运行调度器，测试结束时停止并等待任务退出
+func startScheduler(t *testing.T, opts Options, jobs ...Job) *Scheduler {
+	t.Helper()
+	s := New(opts)
+	for _, job := range jobs {
+		if err := s.Register(job); err != nil {
+			t.Fatalf("Register(%s): %v", job.Name, err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	// 等待调度循环启动，之后才能手动触发
+	for {
+		s.mu.Lock()
+		started := s.runCtx != nil
+		s.mu.Unlock()
+		if started {
+			return s
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitStatus 等待任务状态满足 cond
+func waitStatus(t *testing.T, s *Scheduler, name string, cond func(Status) bool) Status {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		for _, st := range s.Statuses() {
+			if st.Name == name && cond(st) {
+				return st
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("任务 %s 的状态未满足条件: %+v", name, s.Statuses())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRunResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(ctx context.Context) error
+		timeout    time.Duration
+		wantResult string
+	}{
+		{"成功", func(context.Context) error { return nil }, 0, ResultSuccess},
+		{"失败", func(context.Context) error { return errors.New("boom") }, 0, ResultFailed},
+		{"panic", func(context.Context) error { panic("boom") }, 0, ResultPanic},
+		{"超时", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, 10 * time.Millisecond, ResultTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := startScheduler(t, Options{}, Job{Name: "job", Schedule: tick(10 * time.Millisecond), Run: tt.run, Timeout: tt.timeout})
+			st := waitStatus(t, s, "job", func(st Status) bool { return st.Runs >= 2 })
+			if st.LastResult != tt.wantResult {
+				t.Errorf("LastResult = %q, want %q", st.LastResult, tt.wantResult)
+			}
+			if wantFailures := tt.wantResult != ResultSuccess; (st.Failures > 0) != wantFailures {
+				t.Errorf("Failures = %d, want 有失败 = %v", st.Failures, wantFailures)
+			}
+		})
+	}
+}
+
+func TestSkippedRuns(t *testing.T) {
+	tests := []struct {
+		name       string
+		leader     Leader
+		leaderOnly bool
+		block      bool
+		wantResult string
+	}{
+		{"上一次运行未结束时跳过", nil, false, true, ResultOverlap},
+		{"非主副本跳过主副本任务", StaticLeader(false), true, false, ResultNotLeader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			t.Cleanup(func() { close(release) })
+			var runs atomic.Int32
+			s := startScheduler(t, Options{Leader: tt.leader}, Job{
+				Name:       "job",
+				Schedule:   tick(10 * time.Millisecond),
+				LeaderOnly: tt.leaderOnly,
+				Run: func(ctx context.Context) error {
+					runs.Add(1)
+					if tt.block {
+						select {
+						case <-release:
+						case <-ctx.Done():
+						}
+					}
+					return nil
+				},
+			})
+			st := waitStatus(t, s, "job", func(st Status) bool { return st.Skipped >= 2 })
+			if st.LastResult != tt.wantResult {
+				t.Errorf("LastResult = %q, want %q", st.LastResult, tt.wantResult)
+			}
+			// 重叠时只有第一次运行，非主副本时从未运行
+			if maxRuns := map[bool]int32{true: 1, false: 0}[tt.block]; runs.Load() > maxRuns {
+				t.Errorf("任务运行了 %d 次, want 不超过 %d", runs.Load(), maxRuns)
+			}
+		})
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	s := startScheduler(t, Options{}, Job{
+		Name:     "job",
+		Schedule: MustParse("@yearly"),
+		Run: func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	})
+	waitStatus(t, s, "job", func(st Status) bool { return !st.NextRun.IsZero() })
+
+	if err := s.Trigger("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Trigger(missing) = %v, want ErrJobNotFound", err)
+	}
+	if err := s.Trigger("job"); err != nil {
+		t.Fatalf("Trigger(job): %v", err)
+	}
+	waitStatus(t, s, "job", func(st Status) bool { return st.Running })
+	if err := s.Trigger("job"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("运行中再次 Trigger = %v, want ErrJobRunning", err)
+	}
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	run := func(context.Context) error { return nil }
+	tests := []struct {
+		name string
+		job  Job
+	}{
+		{"缺少名称", Job{Schedule: Every(time.Minute), Run: run}},
+		{"缺少调度", Job{Name: "job", Run: run}},
+		{"缺少 Run", Job{Name: "job", Schedule: Every(time.Minute)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New(Options{}).Register(tt.job); err == nil {
+				t.Error("Register 成功, want 错误")
+			}
+		})
+	}
+
+	s := New(Options{})
+	job := Job{Name: "job", Schedule: Every(time.Minute), Run: run}
+	if err := s.Register(job); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(job); err == nil {
+		t.Error("重复注册成功, want 错误")
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/middleware"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// healthSyncInterval grpc.health.v1 状态与就绪检查同步的间隔
+const healthSyncInterval = time.Second
+
+// GRPCServer gRPC 服务器结构
+type GRPCServer struct {
+	server *grpc.Server
+	addr   string
+
+	healthRegistry *health.Registry
+	healthServer   *grpchealth.Server // 未启用 health_service 时为 nil
+	stopHealthSync chan struct{}
+	stopOnce       sync.Once // Shutdown 可能被信号和启动失败两条路径重复调用
+}
+
+// GRPCRegistrar 注册 gRPC 服务，由各业务模块实现
+type GRPCRegistrar interface {
+	RegisterGRPC(s *grpc.Server)
+}
+
+// NewGRPCServer 创建新的 gRPC 服务器实例，业务服务由各模块通过 registrars 注册
+// tenants 用于将请求的租户写入 context
+func NewGRPCServer(
+	cfg *config.Config,
+	healthRegistry *health.Registry,
+	tenants middleware.TenantResolver,
+	registrars ...GRPCRegistrar,
+) *GRPCServer {
+	unary, stream := interceptors(cfg, tenants)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	// 注册服务
+	for _, r := range registrars {
+		r.RegisterGRPC(s)
+	}
+
+	gs := &GRPCServer{
+		server:         s,
+		addr:           cfg.GRPC.Addr(),
+		healthRegistry: healthRegistry,
+		stopHealthSync: make(chan struct{}),
+	}
+
+	if cfg.GRPC.HealthService {
+		gs.healthServer = grpchealth.NewServer()
+		healthpb.RegisterHealthServer(s, gs.healthServer)
+	}
+	if cfg.GRPC.Reflection {
+		reflection.Register(s)
+	}
+
+	return gs
+}
+
+// interceptors 构建拦截器链，顺序与 HTTP 中间件保持一致
+func interceptors(cfg *config.Config, tenants middleware.TenantResolver) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	unary := []grpc.UnaryServerInterceptor{
+		middleware.UnaryTracing(),
+		middleware.UnaryLanguage(),
+		middleware.UnaryRecovery(),
+		middleware.UnaryLogger(),
+		middleware.UnaryMetrics(),
+		middleware.UnaryErrorHandler(),
+		middleware.UnaryDeadline(cfg.GRPC.DefaultTimeout, cfg.GRPC.MaxTimeout),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		middleware.StreamTracing(),
+		middleware.StreamLanguage(),
+		middleware.StreamRecovery(),
+		middleware.StreamLogger(),
+		middleware.StreamMetrics(),
+		middleware.StreamErrorHandler(),
+		middleware.StreamDeadline(cfg.GRPC.DefaultTimeout, cfg.GRPC.MaxTimeout),
+	}
+
+	if cfg.Auth.Enabled {
+		authn := authenticator(cfg.Auth)
+		unary = append(unary, middleware.UnaryAuth(authn, cfg.Auth.SkipMethods))
+		stream = append(stream, middleware.StreamAuth(authn, cfg.Auth.SkipMethods))
+	}
+	opts := tenantOptions(cfg.Tenancy)
+	unary = append(unary, middleware.UnaryTenant(tenants, opts, cfg.Auth.SkipMethods))
+	stream = append(stream, middleware.StreamTenant(tenants, opts, cfg.Auth.SkipMethods))
+	unary = append(unary, middleware.UnaryAuditContext())
+	stream = append(stream, middleware.StreamAuditContext())
+	return unary, stream
+}
+
+// Start 启动 gRPC 服务器，调用 Shutdown 后返回 nil
+func (s *GRPCServer) Start() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("监听 gRPC 端口失败: %w", err)
+	}
+	if s.healthServer != nil {
+		go s.syncHealth()
+	}
+	if err := s.server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// syncHealth 定期将就绪检查结果同步到 grpc.health.v1
+func (s *GRPCServer) syncHealth() {
+	ticker := time.NewTicker(healthSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), healthSyncInterval)
+		report := s.healthRegistry.Readiness(ctx)
+		cancel()
+
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if !report.Healthy() {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		for name := range s.server.GetServiceInfo() {
+			s.healthServer.SetServingStatus(name, servingStatus)
+		}
+		s.healthServer.SetServingStatus("", servingStatus)
+
+		select {
+		case <-s.stopHealthSync:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown 优雅关闭 gRPC 服务器，超时后强制关闭，可重复调用
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stopHealthSync) })
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"testing"
+	"time"
+
+	couponv1 "rich_go/api/proto/coupon/v1"
+	"rich_go/internal/config"
+	"rich_go/internal/health"
+	"rich_go/internal/model"
+	"rich_go/internal/server/rpc"
+	"rich_go/internal/service"
+	"rich_go/internal/tenant"
+	"rich_go/internal/tracing"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// stubTenants 任意租户 ID 均存在
+type stubTenants struct{}
+
+func (stubTenants) ResolveTenant(_ context.Context, id string) (*model.Tenant, error) {
+	return &model.Tenant{ID: id}, nil
+}
+
+// stubCouponService GetCoupon 调用 get，其余方法未实现
+type stubCouponService struct {
+	service.CouponService
+	get func(ctx context.Context, id string) (*model.Coupon, error)
+}
+
+func (s *stubCouponService) GetCoupon(ctx context.Context, id string) (*model.Coupon, error) {
+	return s.get(ctx, id)
+}
+
+type registrarFunc func(s *grpc.Server)
+
+func (f registrarFunc) RegisterGRPC(s *grpc.Server) { f(s) }
+
+// newCouponClient 通过 bufconn 连接使用完整拦截器链的 gRPC 服务器
+func newCouponClient(t *testing.T, cfg *config.Config, svc service.CouponService) couponv1.CouponServiceClient {
+	t.Helper()
+	gs := NewGRPCServer(cfg, health.NewRegistry(time.Second, 0), stubTenants{}, registrarFunc(func(s *grpc.Server) {
+		couponv1.RegisterCouponServiceServer(s, rpc.NewCouponServer(svc))
+	}))
+	lis := bufconn.Listen(1 << 20)
+	go gs.server.Serve(lis)
+	t.Cleanup(func() { gs.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return couponv1.NewCouponServiceClient(conn)
+}
+
+func localized(be *errors.BusinessError) string {
+	message, _ := be.Localize(i18n.LangEnUS)
+	return message
+}
+
+func TestInterceptorChain(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        bool
+		token       string
+		get         func(ctx context.Context, id string) (*model.Coupon, error)
+		wantCode    codes.Code
+		wantMessage string
+		wantCalled  bool
+	}{
+		{
+			name: "处理器 panic 时返回按请求语言本地化的内部错误",
+			get: func(context.Context, string) (*model.Coupon, error) {
+				panic("boom")
+			},
+			wantCode: codes.Internal, wantMessage: localized(errors.ErrInternalError), wantCalled: true,
+		},
+		{
+			name: "业务错误转换为对应状态码",
+			get: func(context.Context, string) (*model.Coupon, error) {
+				return nil, errors.ErrCouponNotFound
+			},
+			wantCode: codes.NotFound, wantMessage: localized(errors.ErrCouponNotFound), wantCalled: true,
+		},
+		{
+			name: "context 超时转换为 DeadlineExceeded",
+			get: func(context.Context, string) (*model.Coupon, error) {
+				return nil, context.DeadlineExceeded
+			},
+			wantCode: codes.DeadlineExceeded, wantCalled: true,
+		},
+		{
+			name: "处理器收到的 context 已经过截止时间、租户和链路追踪拦截器",
+			get: func(ctx context.Context, id string) (*model.Coupon, error) {
+				if _, ok := ctx.Deadline(); !ok {
+					return nil, stderrors.New("缺少截止时间")
+				}
+				if tenant.ID(ctx) != "default" {
+					return nil, stderrors.New("缺少租户")
+				}
+				if tracing.TraceID(ctx) == "" {
+					return nil, stderrors.New("缺少 span")
+				}
+				if i18n.LanguageFromContext(ctx) != i18n.LangEnUS {
+					return nil, stderrors.New("语言未解析")
+				}
+				return &model.Coupon{Name: "summer"}, nil
+			},
+			wantCode: codes.OK, wantCalled: true,
+		},
+		{
+			name: "认证在错误转换之内，未认证时不调用处理器并返回本地化错误",
+			auth: true,
+			get: func(context.Context, string) (*model.Coupon, error) {
+				return &model.Coupon{}, nil
+			},
+			wantCode: codes.Unauthenticated, wantMessage: localized(errors.ErrUnauthorized),
+		},
+		{
+			name: "认证通过后调用处理器",
+			auth: true, token: "ops-token",
+			get: func(context.Context, string) (*model.Coupon, error) {
+				return &model.Coupon{}, nil
+			},
+			wantCode: codes.OK, wantCalled: true,
+		},
+	}
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Auth.Enabled = tt.auth
+			cfg.Auth.Tokens = []config.AuthToken{{Name: "ops", Token: "ops-token", Tenant: "default"}}
+
+			called := false
+			client := newCouponClient(t, cfg, &stubCouponService{get: func(ctx context.Context, id string) (*model.Coupon, error) {
+				called = true
+				return tt.get(ctx, id)
+			}})
+
+			md := metadata.Pairs("accept-language", "en-US")
+			if tt.token != "" {
+				md.Append("authorization", "Bearer "+tt.token)
+			}
+			ctx := metadata.NewOutgoingContext(context.Background(), md)
+			_, err := client.GetCoupon(ctx, &couponv1.GetCouponRequest{CouponId: 1})
+
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("状态码 = %v (%v), want %v", st.Code(), err, tt.wantCode)
+			}
+			if tt.wantMessage != "" && st.Message() != tt.wantMessage {
+				t.Errorf("错误信息 = %q, want %q", st.Message(), tt.wantMessage)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("处理器被调用 = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestGRPCServerShutdownTwice(t *testing.T) {
+	cfg := config.Default()
+	cfg.GRPC.Port = 0
+	s := NewGRPCServer(cfg, health.NewRegistry(time.Second, 0), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if err := s.Shutdown(ctx); err != nil {
+			t.Errorf("第 %d 次 Shutdown: %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler 审计日志处理器
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+// AuditEntryList 审计日志分页列表响应
+type AuditEntryList struct {
+	Entries []*model.AuditEntry `json:"entries"`
+	Page    Page                `json:"page"`
+}
+
+// NewAuditHandler 创建审计日志处理器实例
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListEntries 按 tenant、actor、action、resourceType、resourceId、from、to 过滤并分页获取审计日志
+func (h *AuditHandler) ListEntries(c *gin.Context) {
+	page, pageSize, err := pageParams(c)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	var query service.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	entries, err := h.auditService.ListEntries(c.Request.Context(), &query)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	items, info := paginate(entries, page, pageSize)
+	response.Success(c, AuditEntryList{Entries: items, Page: info})
+}
+
+// VerifyChain 校验审计日志哈希链
+func (h *AuditHandler) VerifyChain(c *gin.Context) {
+	result, err := h.auditService.VerifyChain(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
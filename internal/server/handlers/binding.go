@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"rich_go/pkg/errors"
+	"rich_go/pkg/validation"
+)
+
+// bindError 将请求绑定错误转换为带字段详情的参数错误，不暴露解析器的原始信息
+// 业务规则校验由服务层通过 validation 包完成，这里只处理 JSON 解析错误
+func bindError(err error) *errors.BusinessError {
+	if be := validation.FromError(err); be != nil {
+		return be
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.As(err, &maxBytesErr) {
+		return errors.ErrBodyTooLarge.WithCause(err)
+	}
+
+	if be := validation.FromJSONError(err); be != nil {
+		return be
+	}
+
+	return errors.ErrInvalidParam
+}
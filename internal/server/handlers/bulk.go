@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"rich_go/internal/service"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importRequest 解析导入参数与格式，格式取自 format 查询参数，未指定时取自 Content-Type
+func importRequest(c *gin.Context) (*service.ImportOptions, *bulk.Decoder, error) {
+	var opts service.ImportOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		return nil, nil, bindError(err)
+	}
+	format, err := requestFormat(c, c.ContentType(), "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return &opts, bulk.NewDecoder(c.Request.Body, format), nil
+}
+
+// writeImportResult 输出导入结果，读取请求体超过大小上限时返回 ErrBodyTooLarge
+func writeImportResult(c *gin.Context, result *service.ImportResult, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.As(err, &maxBytesErr) {
+		err = errors.ErrBodyTooLarge.WithCause(err)
+	}
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, service.ImportMessageID(result), result)
+}
+
+// exportEncoder 设置下载响应头并创建编码器，格式取自 format 查询参数，未指定时按 Accept 协商，默认 CSV
+func exportEncoder(c *gin.Context, name string, sample interface{}) (*bulk.Encoder, error) {
+	format, err := requestFormat(c, c.GetHeader("Accept"), bulk.CSV)
+	if err != nil {
+		return nil, err
+	}
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="`+name+"."+string(format)+`"`)
+	return bulk.NewEncoder(c.Writer, format, sample), nil
+}
+
+// writeExportError 导出失败时，尚未输出数据则返回错误响应，否则只能中断输出
+func writeExportError(c *gin.Context, err error) {
+	if c.Writer.Written() {
+		_ = c.Error(err)
+		return
+	}
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	response.ErrorFrom(c, err)
+}
+
+// requestFormat 按 format 查询参数或媒体类型确定数据格式，都无法确定时使用 fallback，fallback 为空时返回错误
+func requestFormat(c *gin.Context, mediaType string, fallback bulk.Format) (bulk.Format, error) {
+	if name := c.Query("format"); name != "" {
+		format, err := bulk.ParseFormat(name)
+		if err != nil {
+			return "", errors.ErrUnsupportedFormat.WithCause(err)
+		}
+		return format, nil
+	}
+	if format, ok := bulk.FormatFromContentType(mediaType); ok {
+		return format, nil
+	}
+	if fallback == "" {
+		return "", errors.ErrUnsupportedFormat
+	}
+	return fallback, nil
+}
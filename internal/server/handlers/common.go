@@ -0,0 +1,6 @@
+package handlers
+
+// Deleted 删除成功响应，返回被删除资源的 ID
+type Deleted struct {
+	ID string `json:"id"`
+}
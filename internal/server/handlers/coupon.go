@@ -1,8 +1,8 @@
 package handlers
 
 import (
+	"rich_go/internal/model"
 	"rich_go/internal/service"
-	"rich_go/pkg/errors"
 	"rich_go/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +13,11 @@ type CouponHandler struct {
 	couponService service.CouponService
 }
 
+// CouponList 优惠券列表响应
+type CouponList struct {
+	Coupons []*model.Coupon `json:"coupons"`
+}
+
 // NewCouponHandler 创建优惠券处理器实例
 func NewCouponHandler(couponService service.CouponService) *CouponHandler {
 	return &CouponHandler{
@@ -24,14 +29,10 @@ func NewCouponHandler(couponService service.CouponService) *CouponHandler {
 func (h *CouponHandler) ListCoupons(c *gin.Context) {
 	coupons, err := h.couponService.ListCoupons(c.Request.Context())
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			response.Error(c, be.Code, be.Message)
-			return
-		}
-		response.InternalServerError(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
-	response.Success(c, gin.H{"coupons": coupons})
+	response.Success(c, CouponList{Coupons: coupons})
 }
 
 // GetCoupon 获取单个优惠券
@@ -39,15 +40,7 @@ func (h *CouponHandler) GetCoupon(c *gin.Context) {
 	id := c.Param("id")
 	coupon, err := h.couponService.GetCoupon(c.Request.Context(), id)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			if be.Code == errors.CodeCouponNotFound || be.Code == errors.CodeInvalidCouponID {
-				response.NotFound(c, be.Message)
-			} else {
-				response.Error(c, be.Code, be.Message)
-			}
-			return
-		}
-		response.InternalServerError(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
 	response.Success(c, coupon)
@@ -55,83 +48,37 @@ func (h *CouponHandler) GetCoupon(c *gin.Context) {
 
 // CreateCoupon 创建优惠券
 func (h *CouponHandler) CreateCoupon(c *gin.Context) {
-	var req struct {
-		Name         string  `json:"name" binding:"required"`
-		Description  string  `json:"description"`
-		DiscountType string  `json:"discountType" binding:"required,oneof=fixed percent"`
-		DiscountValue float64 `json:"discountValue" binding:"required,gt=0"`
-		MinAmount    float64 `json:"minAmount" binding:"gte=0"`
-		Status       string  `json:"status" binding:"omitempty,oneof=active inactive"`
-	}
-
+	var req service.CreateCouponRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, bindError(err))
 		return
 	}
 
-	createReq := &service.CreateCouponRequest{
-		Name:         req.Name,
-		Description:  req.Description,
-		DiscountType: req.DiscountType,
-		DiscountValue: req.DiscountValue,
-		MinAmount:    req.MinAmount,
-		Status:       req.Status,
-	}
-
-	coupon, err := h.couponService.CreateCoupon(c.Request.Context(), createReq)
+	coupon, err := h.couponService.CreateCoupon(c.Request.Context(), &req)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			response.Error(c, be.Code, be.Message)
-			return
-		}
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
 
-	response.SuccessWithMessage(c, "优惠券创建成功", coupon)
+	response.SuccessWithMessageID(c, "coupon.created", coupon)
 }
 
 // UpdateCoupon 更新优惠券
 func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
 	id := c.Param("id")
-	var req struct {
-		Name         string  `json:"name"`
-		Description  string  `json:"description"`
-		DiscountType string  `json:"discountType" binding:"omitempty,oneof=fixed percent"`
-		DiscountValue float64 `json:"discountValue" binding:"omitempty,gt=0"`
-		MinAmount    float64 `json:"minAmount" binding:"omitempty,gte=0"`
-		Status       string  `json:"status" binding:"omitempty,oneof=active inactive"`
-	}
-
+	var req service.UpdateCouponRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, bindError(err))
 		return
 	}
 
-	updateReq := &service.UpdateCouponRequest{
-		Name:         req.Name,
-		Description:  req.Description,
-		DiscountType: req.DiscountType,
-		DiscountValue: req.DiscountValue,
-		MinAmount:    req.MinAmount,
-		Status:       req.Status,
-	}
-
-	coupon, err := h.couponService.UpdateCoupon(c.Request.Context(), id, updateReq)
+	coupon, err := h.couponService.UpdateCoupon(c.Request.Context(), id, &req)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			if be.Code == errors.CodeCouponNotFound || be.Code == errors.CodeInvalidCouponID {
-				response.NotFound(c, be.Message)
-			} else {
-				response.Error(c, be.Code, be.Message)
-			}
-			return
-		}
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
 
-	response.SuccessWithMessage(c, "优惠券更新成功", coupon)
+	response.SuccessWithMessageID(c, "coupon.updated", coupon)
 }
 
 // DeleteCoupon 删除优惠券
@@ -139,18 +86,54 @@ func (h *CouponHandler) DeleteCoupon(c *gin.Context) {
 	id := c.Param("id")
 	err := h.couponService.DeleteCoupon(c.Request.Context(), id)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			if be.Code == errors.CodeCouponNotFound || be.Code == errors.CodeInvalidCouponID {
-				response.NotFound(c, be.Message)
-			} else {
-				response.Error(c, be.Code, be.Message)
-			}
-			return
-		}
-		response.InternalServerError(c, err.Error())
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.SuccessWithMessageID(c, "coupon.deleted", Deleted{ID: id})
+}
+
+// ImportCoupons 从 CSV 或 NDJSON 批量导入优惠券，通过 mode、dryRun 查询参数控制写入方式
+func (h *CouponHandler) ImportCoupons(c *gin.Context) {
+	opts, dec, err := importRequest(c)
+	if err != nil {
+		response.ErrorFrom(c, err)
 		return
 	}
+	result, err := h.couponService.ImportCoupons(c.Request.Context(), dec, opts)
+	writeImportResult(c, result, err)
+}
 
-	response.SuccessWithMessage(c, "优惠券删除成功", gin.H{"id": id})
+// ExportCoupons 以 CSV 或 NDJSON 流式导出符合条件的优惠券
+func (h *CouponHandler) ExportCoupons(c *gin.Context) {
+	var filter service.CouponFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+	enc, err := exportEncoder(c, "coupons", model.Coupon{})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if _, err := h.couponService.ExportCoupons(c.Request.Context(), &filter, enc); err != nil {
+		writeExportError(c, err)
+	}
 }
 
+// BatchCoupons 批量创建、更新、删除优惠券，逐项返回结果
+func (h *CouponHandler) BatchCoupons(c *gin.Context) {
+	var req service.BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	result, err := h.couponService.BatchCoupons(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.SuccessWithMessageID(c, service.BatchMessageID(result), result)
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"math"
+
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currencyExponents 最小货币单位不是 1/100 的币种，值为小数位数（ISO 4217），未列出的币种为 2
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// minorUnits 返回币种一个主单位包含的最小货币单位数，例如 CNY 为 100，JPY 为 1
+func minorUnits(currency string) float64 {
+	exp, ok := currencyExponents[currency]
+	if !ok {
+		exp = 2
+	}
+	return math.Pow10(exp)
+}
+
+// Money v2 金额表示，Amount 为最小货币单位（例如人民币的分、日元的円），避免浮点误差
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// DiscountV2 v2 折扣表示，percent 类型使用 Percent，fixed 类型使用 Amount
+type DiscountV2 struct {
+	Type    string  `json:"type"`
+	Percent float64 `json:"percent,omitempty"`
+	Amount  *Money  `json:"amount,omitempty"`
+}
+
+// CouponV2 v2 优惠券表示
+type CouponV2 struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Discount    DiscountV2 `json:"discount"`
+	MinAmount   Money      `json:"minAmount"`
+	Status      string     `json:"status"`
+}
+
+// CouponListV2 v2 优惠券列表响应，带分页信息
+type CouponListV2 struct {
+	Items []CouponV2 `json:"items"`
+	Page  Page       `json:"page"`
+}
+
+// CreateCouponRequestV2 v2 创建优惠券请求
+type CreateCouponRequestV2 struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Discount    DiscountV2 `json:"discount"`
+	MinAmount   *Money     `json:"minAmount"`
+	Status      string     `json:"status"`
+}
+
+// UpdateCouponRequestV2 v2 更新优惠券请求，未提供的字段表示不修改
+type UpdateCouponRequestV2 struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Discount    *DiscountV2 `json:"discount"`
+	MinAmount   *Money      `json:"minAmount"`
+	Status      string      `json:"status"`
+}
+
+// couponFieldsV2 服务层校验错误的字段名到 v2 字段名的映射
+var couponFieldsV2 = map[string]string{
+	"discountType":  "discount.type",
+	"discountValue": "discount",
+	"minAmount":     "minAmount.amount",
+}
+
+// CouponHandlerV2 v2 优惠券处理器，与 v1 共用 CouponService，只负责请求与响应的转换
+type CouponHandlerV2 struct {
+	couponService service.CouponService
+}
+
+// NewCouponHandlerV2 创建 v2 优惠券处理器实例
+func NewCouponHandlerV2(couponService service.CouponService) *CouponHandlerV2 {
+	return &CouponHandlerV2{
+		couponService: couponService,
+	}
+}
+
+// ListCoupons 分页获取优惠券列表
+func (h *CouponHandlerV2) ListCoupons(c *gin.Context) {
+	page, pageSize, err := pageParams(c)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	coupons, err := h.couponService.ListCoupons(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	items, info := paginate(coupons, page, pageSize)
+	out := CouponListV2{Items: make([]CouponV2, 0, len(items)), Page: info}
+	for _, coupon := range items {
+		out.Items = append(out.Items, toCouponV2(coupon))
+	}
+	response.Success(c, out)
+}
+
+// GetCoupon 获取单个优惠券
+func (h *CouponHandlerV2) GetCoupon(c *gin.Context) {
+	coupon, err := h.couponService.GetCoupon(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, toCouponV2(coupon))
+}
+
+// CreateCoupon 创建优惠券
+func (h *CouponHandlerV2) CreateCoupon(c *gin.Context) {
+	var req CreateCouponRequestV2
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	createReq, err := req.toService(service.TenantCurrency(c.Request.Context()))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	coupon, err := h.couponService.CreateCoupon(c.Request.Context(), createReq)
+	if err != nil {
+		response.ErrorFrom(c, renameFields(err, couponFieldsV2))
+		return
+	}
+	response.SuccessWithMessageID(c, "coupon.created", toCouponV2(coupon))
+}
+
+// UpdateCoupon 更新优惠券
+func (h *CouponHandlerV2) UpdateCoupon(c *gin.Context) {
+	var req UpdateCouponRequestV2
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	updateReq, err := req.toService(service.TenantCurrency(c.Request.Context()))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	coupon, err := h.couponService.UpdateCoupon(c.Request.Context(), c.Param("id"), updateReq)
+	if err != nil {
+		response.ErrorFrom(c, renameFields(err, couponFieldsV2))
+		return
+	}
+	response.SuccessWithMessageID(c, "coupon.updated", toCouponV2(coupon))
+}
+
+// DeleteCoupon 删除优惠券
+func (h *CouponHandlerV2) DeleteCoupon(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.couponService.DeleteCoupon(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "coupon.deleted", Deleted{ID: id})
+}
+
+// toService 转换为服务层请求，金额须使用租户币种 currency
+func (r *CreateCouponRequestV2) toService(currency string) (*service.CreateCouponRequest, error) {
+	var fields []errors.FieldError
+	discountValue := r.Discount.value(currency, "discount", &fields)
+	minAmount := moneyValue(r.MinAmount, currency, "minAmount", &fields)
+	if len(fields) > 0 {
+		return nil, errors.NewValidationError(fields...)
+	}
+	return &service.CreateCouponRequest{
+		Name:          r.Name,
+		Description:   r.Description,
+		DiscountType:  r.Discount.Type,
+		DiscountValue: discountValue,
+		MinAmount:     minAmount,
+		Status:        r.Status,
+	}, nil
+}
+
+// toService 转换为服务层请求，金额须使用租户币种 currency
+func (r *UpdateCouponRequestV2) toService(currency string) (*service.UpdateCouponRequest, error) {
+	var fields []errors.FieldError
+	req := &service.UpdateCouponRequest{
+		Name:        r.Name,
+		Description: r.Description,
+		Status:      r.Status,
+	}
+	if r.MinAmount != nil {
+		minAmount := moneyValue(r.MinAmount, currency, "minAmount", &fields)
+		req.MinAmount = &minAmount
+	}
+	if r.Discount != nil {
+		req.DiscountType = r.Discount.Type
+		req.DiscountValue = r.Discount.value(currency, "discount", &fields)
+	}
+	if len(fields) > 0 {
+		return nil, errors.NewValidationError(fields...)
+	}
+	return req, nil
+}
+
+// value 返回服务层使用的折扣值：百分比原样返回，固定金额换算为主货币单位
+func (d DiscountV2) value(currency, field string, fields *[]errors.FieldError) float64 {
+	if d.Type == "fixed" {
+		if d.Amount == nil {
+			*fields = append(*fields, errors.NewFieldError(field+".amount", "required", ""))
+			return 0
+		}
+		return moneyValue(d.Amount, currency, field+".amount", fields)
+	}
+	return d.Percent
+}
+
+// moneyValue 将金额换算为主货币单位，币种与租户币种 currency 不一致时记录字段错误，未指定币种时视为租户币种
+func moneyValue(m *Money, currency, field string, fields *[]errors.FieldError) float64 {
+	if m == nil {
+		return 0
+	}
+	if m.Currency != "" && m.Currency != currency {
+		*fields = append(*fields, errors.NewFieldError(field+".currency", "oneof", currency))
+		return 0
+	}
+	return float64(m.Amount) / minorUnits(currency)
+}
+
+// toMoney 将主货币单位的金额换算为最小货币单位
+func toMoney(value float64, currency string) Money {
+	return Money{Amount: int64(math.Round(value * minorUnits(currency))), Currency: currency}
+}
+
+// toCouponV2 模型转换为 v2 表示，金额使用优惠券创建时的租户币种
+func toCouponV2(coupon *model.Coupon) CouponV2 {
+	currency := coupon.Currency
+	if currency == "" {
+		currency = service.DefaultTenantCurrency
+	}
+	discount := DiscountV2{Type: coupon.DiscountType}
+	if coupon.DiscountType == "fixed" {
+		amount := toMoney(coupon.DiscountValue, currency)
+		discount.Amount = &amount
+	} else {
+		discount.Percent = coupon.DiscountValue
+	}
+	return CouponV2{
+		ID:          coupon.ID,
+		Name:        coupon.Name,
+		Description: coupon.Description,
+		Discount:    discount,
+		MinAmount:   toMoney(coupon.MinAmount, currency),
+		Status:      coupon.Status,
+	}
+}
+
+// renameFields 将服务层字段错误中的字段名替换为当前 API 版本的字段名
+func renameFields(err error, names map[string]string) error {
+	be, ok := errors.AsBusinessError(err)
+	if !ok || len(be.Fields) == 0 {
+		return err
+	}
+	fields := make([]errors.FieldError, 0, len(be.Fields))
+	for _, f := range be.Fields {
+		name := f.Field
+		if renamed, ok := names[name]; ok {
+			name = renamed
+		}
+		fields = append(fields, errors.NewFieldError(name, f.Rule, f.Param))
+	}
+	return errors.NewValidationError(fields...)
+}
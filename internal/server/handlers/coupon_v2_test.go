@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubCouponService 只实现 ListCoupons 和 CreateCoupon，其余方法未实现
+type stubCouponService struct {
+	service.CouponService
+	coupons []*model.Coupon
+}
+
+func (s stubCouponService) ListCoupons(ctx context.Context) ([]*model.Coupon, error) {
+	return s.coupons, nil
+}
+
+func (s stubCouponService) CreateCoupon(ctx context.Context, req *service.CreateCouponRequest) (*model.Coupon, error) {
+	return &model.Coupon{
+		ID:            1,
+		Name:          req.Name,
+		DiscountType:  req.DiscountType,
+		DiscountValue: req.DiscountValue,
+		MinAmount:     req.MinAmount,
+		Currency:      service.TenantCurrency(ctx),
+		Status:        "active",
+	}, nil
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		name     string
+		page     int
+		pageSize int
+		want     []int
+	}{
+		{"第一页", 1, 2, []int{1, 2}},
+		{"最后一页不满", 3, 2, []int{5}},
+		{"超出总页数", 4, 2, []int{}},
+		{"一页容纳全部", 1, 10, []int{1, 2, 3, 4, 5}},
+		{"page 很大时不溢出", 500000000000000000, 100, []int{}},
+		{"page 为最大整数", int(^uint(0) >> 1), maxPageSize, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, info := paginate(items, tt.page, tt.pageSize)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paginate = %v, want %v", got, tt.want)
+			}
+			want := Page{Page: tt.page, PageSize: tt.pageSize, Total: len(items)}
+			if info != want {
+				t.Errorf("分页信息 = %+v, want %+v", info, want)
+			}
+		})
+	}
+	if got, _ := paginate([]int{}, 1, 20); len(got) != 0 {
+		t.Errorf("空列表 paginate = %v, want 空", got)
+	}
+}
+
+func TestListCouponsV2Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var coupons []*model.Coupon
+	for i := 1; i <= 3; i++ {
+		coupons = append(coupons, &model.Coupon{ID: uint(i), Name: "券" + strconv.Itoa(i), DiscountType: "percent", DiscountValue: 10, Currency: "CNY"})
+	}
+	engine := gin.New()
+	engine.GET("/api/v2/coupons", NewCouponHandlerV2(stubCouponService{coupons: coupons}).ListCoupons)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantIDs  []uint
+	}{
+		{"默认分页", "", http.StatusOK, []uint{1, 2, 3}},
+		{"指定页大小", "?page=2&pageSize=2", http.StatusOK, []uint{3}},
+		{"page 很大时返回空页", "?page=500000000000000000", http.StatusOK, []uint{}},
+		{"page 超出 int 范围", "?page=99999999999999999999", http.StatusBadRequest, nil},
+		{"page 小于 1", "?page=0", http.StatusBadRequest, nil},
+		{"pageSize 超过上限", "?pageSize=101", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/coupons"+tt.query, nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("状态码 = %d, want %d, body = %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var body struct {
+				Data CouponListV2 `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("解析响应失败: %v", err)
+			}
+			ids := []uint{}
+			for _, c := range body.Data.Items {
+				ids = append(ids, c.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if body.Data.Page.Total != len(coupons) {
+				t.Errorf("total = %d, want %d", body.Data.Page.Total, len(coupons))
+			}
+		})
+	}
+}
+
+func TestToCouponV2(t *testing.T) {
+	tests := []struct {
+		name   string
+		coupon *model.Coupon
+		want   CouponV2
+	}{
+		{"百分比折扣", &model.Coupon{
+			ID: 1, Name: "九折", DiscountType: "percent", DiscountValue: 10, MinAmount: 99.9, Currency: "CNY", Status: "active",
+		}, CouponV2{
+			ID: 1, Name: "九折", Discount: DiscountV2{Type: "percent", Percent: 10},
+			MinAmount: Money{Amount: 9990, Currency: "CNY"}, Status: "active",
+		}},
+		{"固定金额折扣", &model.Coupon{
+			ID: 2, Name: "满减", DiscountType: "fixed", DiscountValue: 12.34, MinAmount: 100, Currency: "CNY", Status: "inactive",
+		}, CouponV2{
+			ID: 2, Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 1234, Currency: "CNY"}},
+			MinAmount: Money{Amount: 10000, Currency: "CNY"}, Status: "inactive",
+		}},
+		{"使用优惠券的币种", &model.Coupon{
+			ID: 3, Name: "满减", DiscountType: "fixed", DiscountValue: 5.5, MinAmount: 20, Currency: "USD", Status: "active",
+		}, CouponV2{
+			ID: 3, Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 550, Currency: "USD"}},
+			MinAmount: Money{Amount: 2000, Currency: "USD"}, Status: "active",
+		}},
+		{"没有小数位的币种", &model.Coupon{
+			ID: 4, Name: "满减", DiscountType: "fixed", DiscountValue: 500, MinAmount: 3000, Currency: "JPY", Status: "active",
+		}, CouponV2{
+			ID: 4, Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 500, Currency: "JPY"}},
+			MinAmount: Money{Amount: 3000, Currency: "JPY"}, Status: "active",
+		}},
+		{"三位小数的币种", &model.Coupon{
+			ID: 5, Name: "满减", DiscountType: "fixed", DiscountValue: 1.25, MinAmount: 10, Currency: "KWD", Status: "active",
+		}, CouponV2{
+			ID: 5, Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 1250, Currency: "KWD"}},
+			MinAmount: Money{Amount: 10000, Currency: "KWD"}, Status: "active",
+		}},
+		{"未记录币种时使用默认币种", &model.Coupon{
+			ID: 6, Name: "九折", DiscountType: "percent", DiscountValue: 10, MinAmount: 1, Status: "active",
+		}, CouponV2{
+			ID: 6, Name: "九折", Discount: DiscountV2{Type: "percent", Percent: 10},
+			MinAmount: Money{Amount: 100, Currency: service.DefaultTenantCurrency}, Status: "active",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toCouponV2(tt.coupon); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toCouponV2 = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCouponRequestV2ToService(t *testing.T) {
+	tests := []struct {
+		name       string
+		currency   string
+		req        CreateCouponRequestV2
+		want       *service.CreateCouponRequest
+		wantFields []string
+	}{
+		{"百分比折扣", "CNY", CreateCouponRequestV2{
+			Name: "九折", Discount: DiscountV2{Type: "percent", Percent: 10}, MinAmount: &Money{Amount: 9990, Currency: "CNY"}, Status: "active",
+		}, &service.CreateCouponRequest{
+			Name: "九折", DiscountType: "percent", DiscountValue: 10, MinAmount: 99.9, Status: "active",
+		}, nil},
+		{"固定金额折扣，币种可省略", "CNY", CreateCouponRequestV2{
+			Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 1234}},
+		}, &service.CreateCouponRequest{
+			Name: "满减", DiscountType: "fixed", DiscountValue: 12.34,
+		}, nil},
+		{"没有小数位的币种", "JPY", CreateCouponRequestV2{
+			Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 500, Currency: "JPY"}}, MinAmount: &Money{Amount: 3000},
+		}, &service.CreateCouponRequest{
+			Name: "满减", DiscountType: "fixed", DiscountValue: 500, MinAmount: 3000,
+		}, nil},
+		{"三位小数的币种", "BHD", CreateCouponRequestV2{
+			Name: "满减", Discount: DiscountV2{Type: "fixed", Amount: &Money{Amount: 1250}},
+		}, &service.CreateCouponRequest{
+			Name: "满减", DiscountType: "fixed", DiscountValue: 1.25,
+		}, nil},
+		{"固定金额缺少金额", "CNY", CreateCouponRequestV2{
+			Name: "满减", Discount: DiscountV2{Type: "fixed"},
+		}, nil, []string{"discount.amount"}},
+		{"币种与租户币种不一致", "CNY", CreateCouponRequestV2{
+			Name:      "满减",
+			Discount:  DiscountV2{Type: "fixed", Amount: &Money{Amount: 100, Currency: "USD"}},
+			MinAmount: &Money{Amount: 100, Currency: "USD"},
+		}, nil, []string{"discount.amount.currency", "minAmount.currency"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.toService(tt.currency)
+			if len(tt.wantFields) > 0 {
+				if fields := fieldNames(err); !reflect.DeepEqual(fields, tt.wantFields) {
+					t.Errorf("字段错误 = %v, want %v", fields, tt.wantFields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toService 返回错误: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toService = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCouponV2TenantCurrency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		tenant   *model.Tenant
+		body     string
+		wantCode int
+		want     DiscountV2
+	}{
+		{"使用租户币种", &model.Tenant{ID: "jp", Currency: "JPY"},
+			`{"name":"满减","discount":{"type":"fixed","amount":{"amount":500,"currency":"JPY"}}}`,
+			http.StatusOK, DiscountV2{Type: "fixed", Amount: &Money{Amount: 500, Currency: "JPY"}}},
+		{"未指定币种时使用租户币种", &model.Tenant{ID: "kw", Currency: "KWD"},
+			`{"name":"满减","discount":{"type":"fixed","amount":{"amount":1250}}}`,
+			http.StatusOK, DiscountV2{Type: "fixed", Amount: &Money{Amount: 1250, Currency: "KWD"}}},
+		{"未解析租户时使用默认币种", nil,
+			`{"name":"满减","discount":{"type":"fixed","amount":{"amount":1234,"currency":"CNY"}}}`,
+			http.StatusOK, DiscountV2{Type: "fixed", Amount: &Money{Amount: 1234, Currency: "CNY"}}},
+		{"与租户币种不一致", &model.Tenant{ID: "us", Currency: "USD"},
+			`{"name":"满减","discount":{"type":"fixed","amount":{"amount":500,"currency":"CNY"}}}`,
+			http.StatusBadRequest, DiscountV2{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				if tt.tenant != nil {
+					c.Request = c.Request.WithContext(tenant.WithTenant(c.Request.Context(), tt.tenant))
+				}
+			})
+			engine.POST("/api/v2/coupons", NewCouponHandlerV2(stubCouponService{}).CreateCoupon)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v2/coupons", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.wantCode {
+				t.Fatalf("状态码 = %d, want %d, body = %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var body struct {
+				Data CouponV2 `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("解析响应失败: %v", err)
+			}
+			if !reflect.DeepEqual(body.Data.Discount, tt.want) {
+				t.Errorf("discount = %+v, want %+v", body.Data.Discount, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCouponRequestV2ToService(t *testing.T) {
+	minAmount := 50.0
+	tests := []struct {
+		name       string
+		req        UpdateCouponRequestV2
+		want       *service.UpdateCouponRequest
+		wantFields []string
+	}{
+		{"只修改名称", UpdateCouponRequestV2{Name: "新名称"}, &service.UpdateCouponRequest{Name: "新名称"}, nil},
+		{"修改折扣和最低消费", UpdateCouponRequestV2{
+			Discount:  &DiscountV2{Type: "fixed", Amount: &Money{Amount: 500, Currency: "CNY"}},
+			MinAmount: &Money{Amount: 5000},
+		}, &service.UpdateCouponRequest{DiscountType: "fixed", DiscountValue: 5, MinAmount: &minAmount}, nil},
+		{"币种与租户币种不一致", UpdateCouponRequestV2{
+			MinAmount: &Money{Amount: 5000, Currency: "USD"},
+		}, nil, []string{"minAmount.currency"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.req.toService("CNY")
+			if len(tt.wantFields) > 0 {
+				if fields := fieldNames(err); !reflect.DeepEqual(fields, tt.wantFields) {
+					t.Errorf("字段错误 = %v, want %v", fields, tt.wantFields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toService 返回错误: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toService = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameFields(t *testing.T) {
+	err := errors.NewValidationError(
+		errors.NewFieldError("discountType", "oneof", "fixed percent"),
+		errors.NewFieldError("minAmount", "gte", "0"),
+		errors.NewFieldError("name", "required", ""),
+	)
+	got := fieldNames(renameFields(err, couponFieldsV2))
+	want := []string{"discount.type", "minAmount.amount", "name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("renameFields = %v, want %v", got, want)
+	}
+
+	notFound := errors.ErrNotFound
+	if got := renameFields(notFound, couponFieldsV2); got != notFound {
+		t.Errorf("没有字段错误时 renameFields = %v, want 原错误", got)
+	}
+}
+
+// fieldNames 返回校验错误中的字段名
+func fieldNames(err error) []string {
+	be, ok := errors.AsBusinessError(err)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, f := range be.Fields {
+		names = append(names, f.Field)
+	}
+	return names
+}
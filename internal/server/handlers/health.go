@@ -1,15 +1,45 @@
 package handlers
 
 import (
+	"rich_go/internal/health"
+	"rich_go/pkg/i18n"
 	"rich_go/pkg/response"
 
 	"github.com/gin-gonic/gin"
 )
 
-// HealthCheck 健康检查接口
-func HealthCheck(c *gin.Context) {
-	response.SuccessWithMessage(c, "服务运行正常", gin.H{
-		"status": "ok",
-	})
+// HealthHandler 健康检查处理器
+type HealthHandler struct {
+	registry *health.Registry
 }
 
+// NewHealthHandler 创建健康检查处理器实例
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	return &HealthHandler{
+		registry: registry,
+	}
+}
+
+// Livez 存活检查接口，失败时应重启进程
+func (h *HealthHandler) Livez(c *gin.Context) {
+	writeReport(c, h.registry.Liveness(c.Request.Context()), "health.live")
+}
+
+// Readyz 就绪检查接口，失败时负载均衡应摘除流量
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	writeReport(c, h.registry.Readiness(c.Request.Context()), "health.ready")
+}
+
+// HealthCheck 健康检查接口（兼容旧路径，等同于就绪检查）
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	writeReport(c, h.registry.Readiness(c.Request.Context()), "health.ok")
+}
+
+// writeReport 输出健康检查报告，不健康时返回 503
+func writeReport(c *gin.Context, report health.Report, okMessageID string) {
+	if !report.Healthy() {
+		response.ServiceUnavailable(c, i18n.T(c.Request.Context(), "health.unavailable", nil), report)
+		return
+	}
+	response.SuccessWithMessageID(c, okMessageID, report)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"rich_go/internal/scheduler"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler 定时任务管理处理器
+type JobHandler struct {
+	scheduler *scheduler.Scheduler
+}
+
+// JobList 定时任务状态列表响应
+type JobList struct {
+	Jobs []scheduler.Status `json:"jobs"`
+}
+
+// NewJobHandler 创建定时任务管理处理器实例
+func NewJobHandler(s *scheduler.Scheduler) *JobHandler {
+	return &JobHandler{
+		scheduler: s,
+	}
+}
+
+// ListJobs 获取定时任务状态
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	response.Success(c, JobList{Jobs: h.scheduler.Statuses()})
+}
+
+// RunJob 立即运行一次定时任务
+func (h *JobHandler) RunJob(c *gin.Context) {
+	name := c.Param("name")
+	switch err := h.scheduler.Trigger(name); {
+	case err == nil:
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		response.ErrorFrom(c, errors.ErrJobNotFound.WithCause(err))
+		return
+	case errors.Is(err, scheduler.ErrJobRunning):
+		response.ErrorFrom(c, errors.ErrJobRunning.WithCause(err))
+		return
+	default:
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "job.triggered", JobList{Jobs: h.scheduler.Statuses()})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"strconv"
+
+	"rich_go/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 分页参数
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Page v2 列表响应的分页信息
+type Page struct {
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+	Total    int `json:"total"`
+}
+
+// pageParams 解析 page、pageSize 查询参数，page 从 1 开始
+func pageParams(c *gin.Context) (page, pageSize int, err error) {
+	page, pageSize = 1, defaultPageSize
+	var fields []errors.FieldError
+	if v := c.Query("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil || page < 1 {
+			fields = append(fields, errors.NewFieldError("page", "gte", "1"))
+		}
+	}
+	if v := c.Query("pageSize"); v != "" {
+		pageSize, err = strconv.Atoi(v)
+		switch {
+		case err != nil || pageSize < 1:
+			fields = append(fields, errors.NewFieldError("pageSize", "gte", "1"))
+		case pageSize > maxPageSize:
+			fields = append(fields, errors.NewFieldError("pageSize", "lte", strconv.Itoa(maxPageSize)))
+		}
+	}
+	if len(fields) > 0 {
+		return 0, 0, errors.NewValidationError(fields...)
+	}
+	return page, pageSize, nil
+}
+
+// paginate 返回 items 中第 page 页的元素
+// 先与总页数比较再计算偏移，page 很大时 (page-1)*pageSize 不会溢出
+func paginate[T any](items []T, page, pageSize int) ([]T, Page) {
+	info := Page{Page: page, PageSize: pageSize, Total: len(items)}
+	pages := (len(items) + pageSize - 1) / pageSize
+	if page-1 >= pages {
+		return []T{}, info
+	}
+	start := (page - 1) * pageSize
+	end := min(start+pageSize, len(items))
+	return items[start:end], info
+}
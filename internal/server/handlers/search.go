@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"rich_go/internal/service"
+	"rich_go/pkg/response"
+	"rich_go/pkg/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler 全文搜索处理器
+type SearchHandler struct {
+	searchService service.SearchService
+}
+
+// SearchResults 搜索结果分页列表响应
+type SearchResults struct {
+	Hits []search.Hit `json:"hits"`
+	Page Page         `json:"page"`
+}
+
+// NewSearchHandler 创建全文搜索处理器实例
+func NewSearchHandler(searchService service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search 按 q 搜索用户和优惠券，结果按相关度排序并分页
+func (h *SearchHandler) Search(c *gin.Context) {
+	page, pageSize, err := pageParams(c)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	var query service.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	hits, err := h.searchService.Search(c.Request.Context(), &query)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	items, info := paginate(hits, page, pageSize)
+	response.Success(c, SearchResults{Hits: items, Page: info})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantHandler 租户管理处理器
+type TenantHandler struct {
+	tenantService service.TenantService
+}
+
+// TenantList 租户列表响应
+type TenantList struct {
+	Tenants []*model.Tenant `json:"tenants"`
+}
+
+// NewTenantHandler 创建租户管理处理器实例
+func NewTenantHandler(tenantService service.TenantService) *TenantHandler {
+	return &TenantHandler{
+		tenantService: tenantService,
+	}
+}
+
+// ListTenants 获取租户列表
+func (h *TenantHandler) ListTenants(c *gin.Context) {
+	tenants, err := h.tenantService.ListTenants(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, TenantList{Tenants: tenants})
+}
+
+// GetTenant 获取单个租户
+func (h *TenantHandler) GetTenant(c *gin.Context) {
+	t, err := h.tenantService.GetTenant(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, t)
+}
+
+// CreateTenant 创建租户
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	var req service.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	t, err := h.tenantService.CreateTenant(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "tenant.created", t)
+}
+
+// UpdateTenant 更新租户配置或状态
+func (h *TenantHandler) UpdateTenant(c *gin.Context) {
+	var req service.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	t, err := h.tenantService.UpdateTenant(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "tenant.updated", t)
+}
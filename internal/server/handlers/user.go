@@ -1,8 +1,8 @@
 package handlers
 
 import (
+	"rich_go/internal/model"
 	"rich_go/internal/service"
-	"rich_go/pkg/errors"
 	"rich_go/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +13,11 @@ type UserHandler struct {
 	userService service.UserService
 }
 
+// UserList 用户列表响应
+type UserList struct {
+	Users []*model.User `json:"users"`
+}
+
 // NewUserHandler 创建用户处理器实例
 func NewUserHandler(userService service.UserService) *UserHandler {
 	return &UserHandler{
@@ -24,14 +29,10 @@ func NewUserHandler(userService service.UserService) *UserHandler {
 func (h *UserHandler) ListUsers(c *gin.Context) {
 	users, err := h.userService.ListUsers(c.Request.Context())
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			response.Error(c, be.Code, be.Message)
-			return
-		}
-		response.InternalServerError(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
-	response.Success(c, gin.H{"users": users})
+	response.Success(c, UserList{Users: users})
 }
 
 // GetUser 获取单个用户
@@ -39,15 +40,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	id := c.Param("id")
 	user, err := h.userService.GetUser(c.Request.Context(), id)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			if be.Code == errors.CodeUserNotFound || be.Code == errors.CodeInvalidUserID {
-				response.NotFound(c, be.Message)
-			} else {
-				response.Error(c, be.Code, be.Message)
-			}
-			return
-		}
-		response.InternalServerError(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
 	response.Success(c, user)
@@ -55,57 +48,37 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 // CreateUser 创建用户
 func (h *UserHandler) CreateUser(c *gin.Context) {
-	var req struct {
-		Name  string `json:"name" binding:"required"`
-		Email string `json:"email" binding:"required,email"`
-	}
-
+	var req service.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, bindError(err))
 		return
 	}
 
-	user, err := h.userService.CreateUser(c.Request.Context(), req.Name, req.Email)
+	user, err := h.userService.CreateUser(c.Request.Context(), &req)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			response.Error(c, be.Code, be.Message)
-			return
-		}
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
 
-	response.SuccessWithMessage(c, "用户创建成功", user)
+	response.SuccessWithMessageID(c, "user.created", user)
 }
 
 // UpdateUser 更新用户
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	id := c.Param("id")
-	var req struct {
-		Name  string `json:"name"`
-		Email string `json:"email" binding:"omitempty,email"`
-	}
-
+	var req service.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, bindError(err))
 		return
 	}
 
-	user, err := h.userService.UpdateUser(c.Request.Context(), id, req.Name, req.Email)
+	user, err := h.userService.UpdateUser(c.Request.Context(), id, &req)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			if be.Code == errors.CodeUserNotFound || be.Code == errors.CodeInvalidUserID {
-				response.NotFound(c, be.Message)
-			} else {
-				response.Error(c, be.Code, be.Message)
-			}
-			return
-		}
-		response.BadRequest(c, err.Error())
+		response.ErrorFrom(c, err)
 		return
 	}
 
-	response.SuccessWithMessage(c, "用户更新成功", user)
+	response.SuccessWithMessageID(c, "user.updated", user)
 }
 
 // DeleteUser 删除用户
@@ -113,18 +86,54 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	id := c.Param("id")
 	err := h.userService.DeleteUser(c.Request.Context(), id)
 	if err != nil {
-		if be, ok := errors.AsBusinessError(err); ok {
-			if be.Code == errors.CodeUserNotFound || be.Code == errors.CodeInvalidUserID {
-				response.NotFound(c, be.Message)
-			} else {
-				response.Error(c, be.Code, be.Message)
-			}
-			return
-		}
-		response.InternalServerError(c, err.Error())
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.SuccessWithMessageID(c, "user.deleted", Deleted{ID: id})
+}
+
+// ImportUsers 从 CSV 或 NDJSON 批量导入用户，通过 mode、dryRun 查询参数控制写入方式
+func (h *UserHandler) ImportUsers(c *gin.Context) {
+	opts, dec, err := importRequest(c)
+	if err != nil {
+		response.ErrorFrom(c, err)
 		return
 	}
+	result, err := h.userService.ImportUsers(c.Request.Context(), dec, opts)
+	writeImportResult(c, result, err)
+}
 
-	response.SuccessWithMessage(c, "用户删除成功", gin.H{"id": id})
+// ExportUsers 以 CSV 或 NDJSON 流式导出符合条件的用户
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	var filter service.UserFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+	enc, err := exportEncoder(c, "users", model.User{})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	if _, err := h.userService.ExportUsers(c.Request.Context(), &filter, enc); err != nil {
+		writeExportError(c, err)
+	}
 }
 
+// BatchUsers 批量创建、更新、删除用户，逐项返回结果
+func (h *UserHandler) BatchUsers(c *gin.Context) {
+	var req service.BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	result, err := h.userService.BatchUsers(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.SuccessWithMessageID(c, service.BatchMessageID(result), result)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserV2 v2 用户表示
+type UserV2 struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UserListV2 v2 用户列表响应，带分页信息
+type UserListV2 struct {
+	Items []UserV2 `json:"items"`
+	Page  Page     `json:"page"`
+}
+
+// UserHandlerV2 v2 用户处理器，与 v1 共用 UserService，只负责请求与响应的转换
+type UserHandlerV2 struct {
+	userService service.UserService
+}
+
+// NewUserHandlerV2 创建 v2 用户处理器实例
+func NewUserHandlerV2(userService service.UserService) *UserHandlerV2 {
+	return &UserHandlerV2{
+		userService: userService,
+	}
+}
+
+// ListUsers 分页获取用户列表
+func (h *UserHandlerV2) ListUsers(c *gin.Context) {
+	page, pageSize, err := pageParams(c)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	users, err := h.userService.ListUsers(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	items, info := paginate(users, page, pageSize)
+	out := UserListV2{Items: make([]UserV2, 0, len(items)), Page: info}
+	for _, u := range items {
+		out.Items = append(out.Items, toUserV2(u))
+	}
+	response.Success(c, out)
+}
+
+// GetUser 获取单个用户
+func (h *UserHandlerV2) GetUser(c *gin.Context) {
+	user, err := h.userService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, toUserV2(user))
+}
+
+// CreateUser 创建用户
+func (h *UserHandlerV2) CreateUser(c *gin.Context) {
+	var req service.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	user, err := h.userService.CreateUser(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "user.created", toUserV2(user))
+}
+
+// UpdateUser 更新用户
+func (h *UserHandlerV2) UpdateUser(c *gin.Context) {
+	var req service.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	user, err := h.userService.UpdateUser(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "user.updated", toUserV2(user))
+}
+
+// DeleteUser 删除用户
+func (h *UserHandlerV2) DeleteUser(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.userService.DeleteUser(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "user.deleted", Deleted{ID: id})
+}
+
+// toUserV2 模型转换为 v2 表示
+func toUserV2(u *model.User) UserV2 {
+	return UserV2{ID: u.ID, Name: u.Name, Email: u.Email}
+}
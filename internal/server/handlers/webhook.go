@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler webhook 订阅处理器
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// WebhookList webhook 列表响应
+type WebhookList struct {
+	Webhooks []*model.Webhook `json:"webhooks"`
+}
+
+// WebhookCreated 创建 webhook 响应，签名密钥只在创建时返回
+type WebhookCreated struct {
+	model.Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryList 投递记录列表响应
+type WebhookDeliveryList struct {
+	Deliveries []*model.WebhookDelivery `json:"deliveries"`
+}
+
+// NewWebhookHandler 创建 webhook 订阅处理器实例
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// ListWebhooks 获取 webhook 列表
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, WebhookList{Webhooks: webhooks})
+}
+
+// GetWebhook 获取单个 webhook
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, webhook)
+}
+
+// CreateWebhook 创建 webhook
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req service.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "webhook.created", WebhookCreated{Webhook: *webhook, Secret: webhook.Secret})
+}
+
+// UpdateWebhook 更新 webhook
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var req service.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorFrom(c, bindError(err))
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "webhook.updated", webhook)
+}
+
+// DeleteWebhook 删除 webhook
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "webhook.deleted", Deleted{ID: id})
+}
+
+// ListDeliveries 获取投递记录，?status=dead 返回死信列表
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), c.Param("id"), c.Query("status"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, WebhookDeliveryList{Deliveries: deliveries})
+}
+
+// Redeliver 手动重新投递
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.SuccessWithMessageID(c, "webhook.redelivery_scheduled", delivery)
+}
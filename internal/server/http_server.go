@@ -1,11 +1,15 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"net/http"
+
+	"rich_go/internal/config"
+	"rich_go/internal/health"
 	"rich_go/internal/middleware"
-	"rich_go/internal/repository"
 	"rich_go/internal/router"
 	"rich_go/internal/server/handlers"
-	"rich_go/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,59 +17,131 @@ import (
 // HTTPServer HTTP 服务器结构
 type HTTPServer struct {
 	router *gin.Engine
-	port   string
+	server *http.Server
+	routes gin.RoutesInfo
 }
 
-// NewHTTPServer 创建新的 HTTP 服务器实例（使用依赖注入）
-func NewHTTPServer(port string) *HTTPServer {
+// NewHTTPServer 创建新的 HTTP 服务器实例，业务路由由各模块通过 registrars 注册
+// tenants 用于将请求的租户写入 context
+func NewHTTPServer(
+	cfg *config.Config,
+	healthRegistry *health.Registry,
+	tenants middleware.TenantResolver,
+	registrars ...router.Registrar,
+) *HTTPServer {
 	// 设置 Gin 模式
-	// gin.SetMode(gin.ReleaseMode) // 生产环境使用
-	gin.SetMode(gin.DebugMode) // 开发环境使用
+	if cfg.App.Env == "production" {
+		gin.SetMode(gin.ReleaseMode) // 生产环境使用
+	} else {
+		gin.SetMode(gin.DebugMode) // 开发环境使用
+	}
 
-	engine := gin.Default()
+	engine := gin.New()
 
 	// 添加全局中间件
-	setupMiddleware(engine)
-
-	// 初始化 Repository 层
-	userRepo := repository.NewUserRepository()
-	couponRepo := repository.NewCouponRepository()
-
-	// 初始化 Service 层
-	userService := service.NewUserService(userRepo)
-	couponService := service.NewCouponService(couponRepo)
-
-	// 初始化 Handler 层
-	userHandler := handlers.NewUserHandler(userService)
-	couponHandler := handlers.NewCouponHandler(couponService)
+	setupMiddleware(engine, cfg, tenants)
 
 	// 注册路由
-	router.SetupRoutes(engine, userHandler, couponHandler)
+	routes := router.SetupRoutes(engine, cfg.API, handlers.NewHealthHandler(healthRegistry), registrars...)
 
 	return &HTTPServer{
 		router: engine,
-		port:   port,
+		routes: routes,
+		server: &http.Server{
+			Addr:         cfg.Server.Addr(),
+			Handler:      engine,
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+		},
 	}
 }
 
 // setupMiddleware 设置中间件
-func setupMiddleware(router *gin.Engine) {
+func setupMiddleware(engine *gin.Engine, cfg *config.Config, tenants middleware.TenantResolver) {
+	// 链路追踪中间件（最先执行，保证后续日志都能带上 trace ID）
+	engine.Use(middleware.Tracing())
+
+	// 语言解析中间件（错误消息与提示按请求语言本地化）
+	engine.Use(middleware.Language())
+
 	// 使用自定义恢复中间件
-	router.Use(middleware.Recovery())
-	
-	// 使用自定义日志中间件（可选，Gin 默认也有）
-	// router.Use(middleware.Logger())
-	
+	engine.Use(middleware.Recovery())
+
+	// 使用自定义日志中间件（输出 trace ID）
+	engine.Use(middleware.Logger())
+
+	// 请求指标中间件
+	engine.Use(middleware.Metrics())
+
 	// 错误处理中间件
-	router.Use(middleware.ErrorHandler())
-	
-	// 可以在这里添加其他中间件
-	// router.Use(middleware.Auth())
-	// router.Use(middleware.CORS())
+	engine.Use(middleware.ErrorHandler())
+
+	// 安全响应头
+	if sec := cfg.Server.Security; sec.Enabled {
+		engine.Use(middleware.SecurityHeaders(middleware.SecurityOptions{
+			HSTSMaxAge:            sec.HSTSMaxAge,
+			HSTSIncludeSubdomains: sec.HSTSIncludeSubdomains,
+			FrameOptions:          sec.FrameOptions,
+			ReferrerPolicy:        sec.ReferrerPolicy,
+			ContentSecurityPolicy: sec.ContentSecurityPolicy,
+			PathPolicies:          map[string]string{router.DocsPath: sec.DocsContentSecurity},
+		}))
+	}
+
+	// 跨域中间件（在认证之前，预检请求无需认证）
+	if cors := cfg.Server.CORS; cors.Enabled {
+		engine.Use(middleware.CORS(middleware.CORSOptions{
+			AllowOrigins:     cors.AllowOrigins,
+			AllowMethods:     cors.AllowMethods,
+			AllowHeaders:     cors.AllowHeaders,
+			ExposeHeaders:    cors.ExposeHeaders,
+			AllowCredentials: cors.AllowCredentials,
+			MaxAge:           cors.MaxAge,
+		}))
+	}
+
+	// 请求体大小限制与处理时限
+	if cfg.Server.MaxBodyBytes > 0 {
+		engine.Use(middleware.BodyLimit(cfg.Server.MaxBodyBytes))
+	}
+	if cfg.Server.RequestTimeout > 0 {
+		engine.Use(middleware.Timeout(cfg.Server.RequestTimeout))
+	}
+
+	// 认证中间件，未启用认证时平台管理接口仍需认证，没有配置 token 时拒绝所有请求
+	if cfg.Auth.Enabled {
+		engine.Use(middleware.Auth(authenticator(cfg.Auth), cfg.Auth.SkipPaths))
+	} else {
+		engine.Use(middleware.RequireAuth(authenticator(cfg.Auth), router.PlatformPaths()))
+	}
+
+	// 租户解析（在认证之后，token 可绑定租户）
+	engine.Use(middleware.Tenant(tenants, tenantOptions(cfg.Tenancy), cfg.Auth.SkipPaths))
+
+	// 审计信息（在认证之后，记录调用方）
+	engine.Use(middleware.AuditContext())
 }
 
-// Start 启动 HTTP 服务器
+// Start 启动 HTTP 服务器，调用 Shutdown 后返回 nil
 func (s *HTTPServer) Start() error {
-	return s.router.Run(":" + s.port)
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown 优雅关闭 HTTP 服务器，等待进行中的请求完成
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// Handler 返回包含全部中间件和路由的 HTTP 处理器，可用于 httptest
+func (s *HTTPServer) Handler() http.Handler {
+	return s.server.Handler
+}
+
+// Routes 返回已注册的路由
+func (s *HTTPServer) Routes() gin.RoutesInfo {
+	return s.routes
 }
 
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"rich_go/internal/config"
+	"rich_go/internal/middleware"
+	"rich_go/internal/router"
+)
+
+// authenticator 根据配置的静态 token 创建认证器，HTTP 与 gRPC 共用
+func authenticator(cfg config.AuthConfig) middleware.Authenticator {
+	tokens := make(map[string]middleware.Principal, len(cfg.Tokens))
+	for _, t := range cfg.Tokens {
+		tokens[t.Token] = middleware.Principal{Name: t.Name, Tenant: t.Tenant}
+	}
+	return middleware.NewStaticTokenAuthenticator(tokens)
+}
+
+// tenantOptions 根据配置生成租户解析参数，未启用多租户时所有请求属于默认租户
+func tenantOptions(cfg config.TenancyConfig) middleware.TenantOptions {
+	opts := middleware.TenantOptions{
+		Default:       cfg.Default,
+		PlatformPaths: router.PlatformPaths(),
+	}
+	if cfg.Enabled {
+		opts.Header = cfg.Header
+		opts.BaseDomain = cfg.BaseDomain
+	}
+	return opts
+}
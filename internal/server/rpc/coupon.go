@@ -0,0 +1,346 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"strconv"
+	"strings"
+
+	couponv1 "rich_go/api/proto/coupon/v1"
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+)
+
+const (
+	// defaultPageSize ListCoupons 默认每页条数
+	defaultPageSize = 100
+	// maxPageSize ListCoupons 最大每页条数
+	maxPageSize = 1000
+	// pageTokenPrefix 分页 token 前缀，token 内容为上一页最后一条记录的 ID
+	pageTokenPrefix = "after:"
+	// bulkCreateChunkSize BulkCreateCoupons 每个分块的记录数，每个分块在独立事务中创建
+	bulkCreateChunkSize = 500
+)
+
+var (
+	errInvalidPageToken    = errors.NewLocalizedError(errors.CodeInvalidParam, "pagination.invalid_page_token", nil)
+	errInvalidCouponStatus = errors.NewLocalizedError(errors.CodeInvalidParam, "coupon.invalid_status", nil)
+)
+
+// CouponServer 优惠券 gRPC 服务，委托给 service.CouponService
+type CouponServer struct {
+	couponv1.UnimplementedCouponServiceServer
+	couponService service.CouponService
+}
+
+// NewCouponServer 创建优惠券 gRPC 服务实例
+func NewCouponServer(couponService service.CouponService) *CouponServer {
+	return &CouponServer{
+		couponService: couponService,
+	}
+}
+
+// GetCoupon 获取优惠券
+func (s *CouponServer) GetCoupon(ctx context.Context, req *couponv1.GetCouponRequest) (*couponv1.GetCouponResponse, error) {
+	coupon, err := s.couponService.GetCoupon(ctx, formatID(req.GetCouponId()))
+	if err != nil {
+		return nil, err
+	}
+	return &couponv1.GetCouponResponse{Coupon: toProtoCoupon(coupon)}, nil
+}
+
+// CreateCoupon 创建优惠券
+func (s *CouponServer) CreateCoupon(ctx context.Context, req *couponv1.CreateCouponRequest) (*couponv1.CreateCouponResponse, error) {
+	coupon, err := s.createCoupon(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &couponv1.CreateCouponResponse{Coupon: toProtoCoupon(coupon)}, nil
+}
+
+// UpdateCoupon 更新优惠券
+func (s *CouponServer) UpdateCoupon(ctx context.Context, req *couponv1.UpdateCouponRequest) (*couponv1.UpdateCouponResponse, error) {
+	status, err := fromProtoStatus(req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+
+	updateReq := &service.UpdateCouponRequest{
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		DiscountType:  fromProtoDiscountType(req.GetDiscountType()),
+		DiscountValue: req.GetDiscountValue(),
+		Status:        status,
+	}
+	// min_amount 声明为 optional，按字段是否设置区分不修改和改为 0，与 HTTP 接口一致
+	if req.MinAmount != nil {
+		minAmount := req.GetMinAmount()
+		updateReq.MinAmount = &minAmount
+	}
+
+	coupon, err := s.couponService.UpdateCoupon(ctx, formatID(req.GetCouponId()), updateReq)
+	if err != nil {
+		return nil, err
+	}
+	return &couponv1.UpdateCouponResponse{Coupon: toProtoCoupon(coupon)}, nil
+}
+
+// DeleteCoupon 删除优惠券
+func (s *CouponServer) DeleteCoupon(ctx context.Context, req *couponv1.DeleteCouponRequest) (*couponv1.DeleteCouponResponse, error) {
+	if err := s.couponService.DeleteCoupon(ctx, formatID(req.GetCouponId())); err != nil {
+		return nil, err
+	}
+	return &couponv1.DeleteCouponResponse{CouponId: req.GetCouponId()}, nil
+}
+
+// ListCoupons 按页流式返回优惠券列表，从 page_token 指向的位置开始直到末尾
+func (s *CouponServer) ListCoupons(req *couponv1.ListCouponsRequest, stream couponv1.CouponService_ListCouponsServer) error {
+	afterID, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	coupons, err := s.couponService.ListCoupons(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	// 仓储按 ID 升序返回，跳过 token 之前的记录
+	start := 0
+	for start < len(coupons) && coupons[start].ID <= afterID {
+		start++
+	}
+	remaining := coupons[start:]
+
+	for len(remaining) > 0 {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+
+		n := pageSize
+		if n > len(remaining) {
+			n = len(remaining)
+		}
+		page := remaining[:n]
+		remaining = remaining[n:]
+
+		resp := &couponv1.ListCouponsResponse{
+			Coupons: make([]*couponv1.Coupon, 0, len(page)),
+		}
+		for _, coupon := range page {
+			resp.Coupons = append(resp.Coupons, toProtoCoupon(coupon))
+		}
+		if len(remaining) > 0 {
+			resp.NextPageToken = encodePageToken(page[len(page)-1].ID)
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BulkCreateCoupons 批量创建优惠券，记录数不设上限
+// 按接收顺序每 bulkCreateChunkSize 条记录为一个分块，分块接收完即在独立事务中创建，全部成功或全部不创建，分块之间互不影响
+// 响应按分块列出是否创建，并列出无效或写入失败的记录；读取请求流或写入时出现非业务错误时返回错误，已创建的分块不会回滚
+func (s *CouponServer) BulkCreateCoupons(stream couponv1.CouponService_BulkCreateCouponsServer) error {
+	var (
+		resp     = &couponv1.BulkCreateCouponsResponse{}
+		start    int
+		reqs     []*service.CreateCouponRequest
+		failures []service.BulkCreateFailure
+	)
+	for index := 0; ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		createReq, err := toCreateCouponRequest(req)
+		if err != nil {
+			failures = append(failures, service.BulkCreateFailure{Index: len(reqs), Err: err})
+		}
+		reqs = append(reqs, createReq)
+		if len(reqs) == bulkCreateChunkSize {
+			if err := s.createChunk(stream.Context(), resp, start, reqs, failures); err != nil {
+				return err
+			}
+			start, reqs, failures = index+1, nil, nil
+		}
+	}
+	if len(reqs) > 0 {
+		if err := s.createChunk(stream.Context(), resp, start, reqs, failures); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(resp)
+}
+
+// createChunk 在独立事务中创建一个分块并将结果追加到 resp，start 为分块第一条记录在请求流中的序号
+// failures 为分块中无法转换的记录，序号相对于分块，有这样的记录时不再调用服务层
+// 业务错误记录在响应中，其他错误直接返回
+func (s *CouponServer) createChunk(ctx context.Context, resp *couponv1.BulkCreateCouponsResponse, start int, reqs []*service.CreateCouponRequest, failures []service.BulkCreateFailure) error {
+	lang := i18n.LanguageFromContext(ctx)
+	chunk := &couponv1.BulkCreateChunk{StartIndex: int64(start), Count: int64(len(reqs))}
+	resp.Chunks = append(resp.Chunks, chunk)
+	if len(failures) == 0 {
+		coupons, err := s.couponService.CreateCoupons(ctx, reqs)
+		var bulkErr *service.BulkCreateError
+		switch {
+		case errors.As(err, &bulkErr):
+			failures = bulkErr.Failures
+		case err != nil:
+			be, ok := errors.AsBusinessError(err)
+			if !ok {
+				return err
+			}
+			chunk.Code = int32(be.Code)
+			chunk.Message, _ = be.Localize(lang)
+			return nil
+		default:
+			chunk.Committed = true
+			resp.CreatedCount += int64(len(coupons))
+			for _, coupon := range coupons {
+				resp.CreatedIds = append(resp.CreatedIds, uint64(coupon.ID))
+			}
+			return nil
+		}
+	}
+
+	resp.FailedCount += int64(len(failures))
+	for _, f := range failures {
+		be, ok := errors.AsBusinessError(f.Err)
+		if !ok {
+			be = errors.ErrInternalError
+		}
+		message, _ := be.Localize(lang)
+		resp.Failures = append(resp.Failures, &couponv1.BulkCreateFailure{
+			Index:   int64(start + f.Index),
+			Code:    int32(be.Code),
+			Message: message,
+		})
+	}
+	return nil
+}
+
+// createCoupon 转换请求并调用服务层创建优惠券
+func (s *CouponServer) createCoupon(ctx context.Context, req *couponv1.CreateCouponRequest) (*model.Coupon, error) {
+	createReq, err := toCreateCouponRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.couponService.CreateCoupon(ctx, createReq)
+}
+
+// toCreateCouponRequest protobuf 创建请求转换为服务层请求
+func toCreateCouponRequest(req *couponv1.CreateCouponRequest) (*service.CreateCouponRequest, error) {
+	status, err := fromProtoStatus(req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	return &service.CreateCouponRequest{
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		DiscountType:  fromProtoDiscountType(req.GetDiscountType()),
+		DiscountValue: req.GetDiscountValue(),
+		MinAmount:     req.GetMinAmount(),
+		Status:        status,
+	}, nil
+}
+
+// toProtoCoupon 模型转换为 protobuf 消息
+func toProtoCoupon(coupon *model.Coupon) *couponv1.Coupon {
+	return &couponv1.Coupon{
+		Id:            uint64(coupon.ID),
+		Name:          coupon.Name,
+		Description:   coupon.Description,
+		DiscountType:  toProtoDiscountType(coupon.DiscountType),
+		DiscountValue: coupon.DiscountValue,
+		MinAmount:     coupon.MinAmount,
+		Status:        toProtoStatus(coupon.Status),
+	}
+}
+
+func toProtoDiscountType(discountType string) couponv1.DiscountType {
+	switch discountType {
+	case "fixed":
+		return couponv1.DiscountType_DISCOUNT_TYPE_FIXED
+	case "percent":
+		return couponv1.DiscountType_DISCOUNT_TYPE_PERCENT
+	default:
+		return couponv1.DiscountType_DISCOUNT_TYPE_UNSPECIFIED
+	}
+}
+
+// fromProtoDiscountType 未指定时返回空字符串，由服务层决定是否允许；未知枚举值原样传递交由服务层拒绝
+func fromProtoDiscountType(discountType couponv1.DiscountType) string {
+	switch discountType {
+	case couponv1.DiscountType_DISCOUNT_TYPE_UNSPECIFIED:
+		return ""
+	case couponv1.DiscountType_DISCOUNT_TYPE_FIXED:
+		return "fixed"
+	case couponv1.DiscountType_DISCOUNT_TYPE_PERCENT:
+		return "percent"
+	default:
+		return discountType.String()
+	}
+}
+
+func toProtoStatus(status string) couponv1.CouponStatus {
+	switch status {
+	case "active":
+		return couponv1.CouponStatus_COUPON_STATUS_ACTIVE
+	case "inactive":
+		return couponv1.CouponStatus_COUPON_STATUS_INACTIVE
+	default:
+		return couponv1.CouponStatus_COUPON_STATUS_UNSPECIFIED
+	}
+}
+
+// fromProtoStatus 未指定时返回空字符串，未知枚举值返回参数错误
+func fromProtoStatus(status couponv1.CouponStatus) (string, error) {
+	switch status {
+	case couponv1.CouponStatus_COUPON_STATUS_UNSPECIFIED:
+		return "", nil
+	case couponv1.CouponStatus_COUPON_STATUS_ACTIVE:
+		return "active", nil
+	case couponv1.CouponStatus_COUPON_STATUS_INACTIVE:
+		return "inactive", nil
+	default:
+		return "", errInvalidCouponStatus
+	}
+}
+
+// encodePageToken 生成指向 lastID 之后的分页 token
+func encodePageToken(lastID uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.FormatUint(uint64(lastID), 10)))
+}
+
+// decodePageToken 解析分页 token，空 token 表示从头开始
+func decodePageToken(token string) (uint, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), pageTokenPrefix) {
+		return 0, errInvalidPageToken
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(raw), pageTokenPrefix), 10, 32)
+	if err != nil {
+		return 0, errInvalidPageToken
+	}
+	return uint(id), nil
+}
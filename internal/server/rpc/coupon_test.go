@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	couponv1 "rich_go/api/proto/coupon/v1"
+	"rich_go/internal/audit"
+	"rich_go/internal/event"
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/service"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// newCouponServer 基于内存仓储的优惠券 gRPC 服务
+func newCouponServer(t *testing.T) (context.Context, *CouponServer, repository.CouponRepository) {
+	t.Helper()
+	coupons := repository.NewCouponRepository()
+	svc := service.NewCouponService(coupons, repository.NewTransactor(),
+		event.NewOutboxRecorder(repository.NewOutboxRepository()), audit.NewRecorder(repository.NewAuditRepository()))
+	return tenant.WithID(context.Background(), "acme"), NewCouponServer(svc), coupons
+}
+
+func TestUpdateCouponMinAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		minAmount *float64
+		want      float64
+	}{
+		{"未设置时不修改", nil, 50},
+		{"设置为 0 时取消最低消费", proto.Float64(0), 0},
+		{"设置为新值", proto.Float64(80), 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, srv, _ := newCouponServer(t)
+			created, err := srv.CreateCoupon(ctx, &couponv1.CreateCouponRequest{
+				Name: "summer", DiscountType: couponv1.DiscountType_DISCOUNT_TYPE_FIXED, DiscountValue: 10, MinAmount: 50,
+			})
+			if err != nil {
+				t.Fatalf("CreateCoupon: %v", err)
+			}
+
+			resp, err := srv.UpdateCoupon(ctx, &couponv1.UpdateCouponRequest{
+				CouponId: created.GetCoupon().GetId(), Name: "winter", MinAmount: tt.minAmount,
+			})
+			if err != nil {
+				t.Fatalf("UpdateCoupon: %v", err)
+			}
+			if got := resp.GetCoupon(); got.GetMinAmount() != tt.want || got.GetName() != "winter" {
+				t.Errorf("更新后 name=%q minAmount=%v, want winter、%v", got.GetName(), got.GetMinAmount(), tt.want)
+			}
+		})
+	}
+}
+
+// bulkCreateStream 按顺序返回 reqs 的客户端流
+type bulkCreateStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*couponv1.CreateCouponRequest
+	resp *couponv1.BulkCreateCouponsResponse
+}
+
+func (s *bulkCreateStream) Context() context.Context { return s.ctx }
+
+func (s *bulkCreateStream) Recv() (*couponv1.CreateCouponRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *bulkCreateStream) SendAndClose(resp *couponv1.BulkCreateCouponsResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func TestBulkCreateCoupons(t *testing.T) {
+	valid := func(name string) *couponv1.CreateCouponRequest {
+		return &couponv1.CreateCouponRequest{Name: name, DiscountType: couponv1.DiscountType_DISCOUNT_TYPE_FIXED, DiscountValue: 10}
+	}
+	invalid := &couponv1.CreateCouponRequest{Name: "", DiscountType: couponv1.DiscountType_DISCOUNT_TYPE_FIXED, DiscountValue: 5}
+	// many 返回 n 条有效记录，replace 中的序号替换为对应记录
+	many := func(n int, replace map[int]*couponv1.CreateCouponRequest) []*couponv1.CreateCouponRequest {
+		reqs := make([]*couponv1.CreateCouponRequest, n)
+		for i := range reqs {
+			reqs[i] = valid(fmt.Sprintf("coupon-%d", i))
+			if r, ok := replace[i]; ok {
+				reqs[i] = r
+			}
+		}
+		return reqs
+	}
+	// chunk 分块结果的简写：起始序号、记录数、是否创建、整体失败的错误码
+	type chunk struct {
+		start, count int64
+		committed    bool
+		code         int32
+	}
+	const size = bulkCreateChunkSize
+	tests := []struct {
+		name        string
+		maxCoupons  int
+		reqs        []*couponv1.CreateCouponRequest
+		wantCreated int64
+		wantFailed  []int64 // 失败记录的序号
+		wantChunks  []chunk
+	}{
+		{"全部成功", 0, []*couponv1.CreateCouponRequest{valid("a"), valid("b"), valid("c")}, 3, nil,
+			[]chunk{{0, 3, true, 0}}},
+		{"校验失败时分块全部不创建", 0, []*couponv1.CreateCouponRequest{
+			valid("a"),
+			{Name: "b", DiscountType: couponv1.DiscountType_DISCOUNT_TYPE_PERCENT, DiscountValue: 150},
+			valid("c"),
+			invalid,
+		}, 0, []int64{1, 3}, []chunk{{0, 4, false, 0}}},
+		{"无效状态时分块全部不创建", 0, []*couponv1.CreateCouponRequest{
+			valid("a"),
+			{Name: "b", DiscountType: couponv1.DiscountType_DISCOUNT_TYPE_FIXED, DiscountValue: 5, Status: couponv1.CouponStatus(9)},
+		}, 0, []int64{1}, []chunk{{0, 2, false, 0}}},
+		{"配额足够", 3, []*couponv1.CreateCouponRequest{valid("a"), valid("b")}, 2, nil,
+			[]chunk{{0, 2, true, 0}}},
+		{"超出配额时分块全部不创建", 2, []*couponv1.CreateCouponRequest{valid("a"), valid("b"), valid("c")}, 0, nil,
+			[]chunk{{0, 3, false, int32(errors.CodeTenantLimitExceeded)}}},
+		{"空请求流", 0, nil, 0, nil, nil},
+		{"按分块创建", 0, many(2*size+1, nil), 2*size + 1, nil,
+			[]chunk{{0, size, true, 0}, {size, size, true, 0}, {2 * size, 1, true, 0}}},
+		{"失败只影响所在分块", 0, many(3*size, map[int]*couponv1.CreateCouponRequest{size + 7: invalid}), 2 * size, []int64{size + 7},
+			[]chunk{{0, size, true, 0}, {size, size, false, 0}, {2 * size, size, true, 0}}},
+		{"超出配额的分块不创建", size + 10, many(2*size+10, nil), size + 10, nil,
+			[]chunk{{0, size, true, 0}, {size, size, false, int32(errors.CodeTenantLimitExceeded)}, {2 * size, 10, true, 0}}},
+		{"记录数超过单次导入上限", 0, many(service.MaxImportRows+size, nil), service.MaxImportRows + size, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, srv, coupons := newCouponServer(t)
+			ctx := tenant.WithTenant(context.Background(), &model.Tenant{ID: "acme", Limits: model.TenantLimits{MaxCoupons: tt.maxCoupons}})
+			stream := &bulkCreateStream{ctx: ctx, reqs: tt.reqs}
+
+			if err := srv.BulkCreateCoupons(stream); err != nil {
+				t.Fatalf("BulkCreateCoupons: %v", err)
+			}
+			resp := stream.resp
+			if resp.GetCreatedCount() != tt.wantCreated || len(resp.GetCreatedIds()) != int(tt.wantCreated) {
+				t.Errorf("created_count=%d len(created_ids)=%d, want %d", resp.GetCreatedCount(), len(resp.GetCreatedIds()), tt.wantCreated)
+			}
+			var failed []int64
+			for _, f := range resp.GetFailures() {
+				failed = append(failed, f.GetIndex())
+			}
+			if resp.GetFailedCount() != int64(len(tt.wantFailed)) || fmt.Sprint(failed) != fmt.Sprint(tt.wantFailed) {
+				t.Errorf("failed_count=%d 失败序号 %v, want %v", resp.GetFailedCount(), failed, tt.wantFailed)
+			}
+			if tt.wantChunks != nil {
+				var chunks []chunk
+				for _, c := range resp.GetChunks() {
+					chunks = append(chunks, chunk{c.GetStartIndex(), c.GetCount(), c.GetCommitted(), c.GetCode()})
+					if c.GetCode() != 0 && c.GetMessage() == "" {
+						t.Errorf("分块 %d 整体失败但没有错误消息", c.GetStartIndex())
+					}
+				}
+				if fmt.Sprint(chunks) != fmt.Sprint(tt.wantChunks) {
+					t.Errorf("分块结果 %v, want %v", chunks, tt.wantChunks)
+				}
+			}
+
+			if n, _ := coupons.Count(ctx); int64(n) != tt.wantCreated {
+				t.Errorf("仓储中有 %d 张优惠券, want %d", n, tt.wantCreated)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+
+	userv1 "rich_go/api/proto/user/v1"
+	"rich_go/internal/model"
+	"rich_go/internal/service"
+)
+
+// UserServer 用户 gRPC 服务，委托给 service.UserService
+type UserServer struct {
+	userv1.UnimplementedUserServiceServer
+	userService service.UserService
+}
+
+// NewUserServer 创建用户 gRPC 服务实例
+func NewUserServer(userService service.UserService) *UserServer {
+	return &UserServer{
+		userService: userService,
+	}
+}
+
+// GetUser 获取用户信息
+func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	user, err := s.userService.GetUser(ctx, formatID(req.GetUserId()))
+	if err != nil {
+		return nil, err
+	}
+	return &userv1.GetUserResponse{User: toProtoUser(user)}, nil
+}
+
+// CreateUser 创建用户
+func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	user, err := s.userService.CreateUser(ctx, &service.CreateUserRequest{
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &userv1.CreateUserResponse{User: toProtoUser(user)}, nil
+}
+
+// UpdateUser 更新用户
+func (s *UserServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
+	user, err := s.userService.UpdateUser(ctx, formatID(req.GetUserId()), &service.UpdateUserRequest{
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &userv1.UpdateUserResponse{User: toProtoUser(user)}, nil
+}
+
+// DeleteUser 删除用户
+func (s *UserServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	if err := s.userService.DeleteUser(ctx, formatID(req.GetUserId())); err != nil {
+		return nil, err
+	}
+	return &userv1.DeleteUserResponse{UserId: req.GetUserId()}, nil
+}
+
+// ListUsers 流式返回用户列表
+func (s *UserServer) ListUsers(req *userv1.ListUsersRequest, stream userv1.UserService_ListUsersServer) error {
+	users, err := s.userService.ListUsers(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	for _, user := range paginate(users, req.GetPage(), req.GetPageSize()) {
+		if err := stream.Send(toProtoUser(user)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toProtoUser 模型转换为 protobuf 消息
+func toProtoUser(user *model.User) *userv1.User {
+	return &userv1.User{
+		Id:    uint64(user.ID),
+		Name:  user.Name,
+		Email: user.Email,
+	}
+}
+
+// formatID 将 protobuf 中的 ID 转换为服务层使用的字符串 ID
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+// paginate 按页截取列表，page 从 1 开始，pageSize 为 0 时返回全部
+func paginate[T any](items []T, page, pageSize int32) []T {
+	if pageSize <= 0 {
+		return items
+	}
+	if page <= 0 {
+		page = 1
+	}
+	start := int(page-1) * int(pageSize)
+	if start >= len(items) {
+		return nil
+	}
+	end := start + int(pageSize)
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
@@ -0,0 +1,236 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	userv1 "rich_go/api/proto/user/v1"
+	"rich_go/internal/audit"
+	"rich_go/internal/event"
+	"rich_go/internal/middleware"
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/service"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newUserService 基于内存仓储的用户服务
+func newUserService() service.UserService {
+	return service.NewUserService(repository.NewUserRepository(), repository.NewTransactor(),
+		event.NewOutboxRecorder(repository.NewOutboxRepository()), audit.NewRecorder(repository.NewAuditRepository()))
+}
+
+// newUserClient 通过 bufconn 连接用户 gRPC 服务，请求属于租户 acme，错误经 ErrorHandler 拦截器转换为 gRPC status
+func newUserClient(t *testing.T, svc service.UserService) userv1.UserServiceClient {
+	t.Helper()
+	withTenant := func(ctx context.Context) context.Context { return tenant.WithID(ctx, "acme") }
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return handler(withTenant(ctx), req)
+			},
+			middleware.UnaryErrorHandler(),
+		),
+		grpc.ChainStreamInterceptor(
+			func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, &tenantStream{ServerStream: ss, ctx: withTenant(ss.Context())})
+			},
+			middleware.StreamErrorHandler(),
+		),
+	)
+	userv1.RegisterUserServiceServer(s, NewUserServer(svc))
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return userv1.NewUserServiceClient(conn)
+}
+
+// tenantStream 替换流的 context
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context { return s.ctx }
+
+// listUsers 读取 ListUsers 返回的全部用户名称
+func listUsers(t *testing.T, client userv1.UserServiceClient, req *userv1.ListUsersRequest) []string {
+	t.Helper()
+	stream, err := client.ListUsers(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	var names []string
+	for {
+		user, err := stream.Recv()
+		if err == io.EOF {
+			return names
+		}
+		if err != nil {
+			t.Fatalf("ListUsers Recv: %v", err)
+		}
+		names = append(names, user.GetName())
+	}
+}
+
+func TestUserServerCRUD(t *testing.T) {
+	client := newUserClient(t, newUserService())
+	ctx := context.Background()
+
+	var ids []uint64
+	for _, name := range []string{"张三", "李四", "王五"} {
+		resp, err := client.CreateUser(ctx, &userv1.CreateUserRequest{Name: name, Email: name + "@example.com"})
+		if err != nil {
+			t.Fatalf("CreateUser(%s): %v", name, err)
+		}
+		if resp.GetUser().GetId() == 0 || resp.GetUser().GetName() != name {
+			t.Errorf("CreateUser 返回 %v", resp.GetUser())
+		}
+		ids = append(ids, resp.GetUser().GetId())
+	}
+
+	got, err := client.GetUser(ctx, &userv1.GetUserRequest{UserId: ids[0]})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got.GetUser().GetName() != "张三" || got.GetUser().GetEmail() != "张三@example.com" {
+		t.Errorf("GetUser 返回 %v", got.GetUser())
+	}
+
+	updated, err := client.UpdateUser(ctx, &userv1.UpdateUserRequest{UserId: ids[0], Name: "张三丰"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.GetUser().GetName() != "张三丰" || updated.GetUser().GetEmail() != "张三@example.com" {
+		t.Errorf("UpdateUser 返回 %v, want 只修改名称", updated.GetUser())
+	}
+
+	if names := listUsers(t, client, &userv1.ListUsersRequest{}); fmt.Sprint(names) != "[张三丰 李四 王五]" {
+		t.Errorf("ListUsers = %v", names)
+	}
+	if names := listUsers(t, client, &userv1.ListUsersRequest{Page: 2, PageSize: 2}); fmt.Sprint(names) != "[王五]" {
+		t.Errorf("ListUsers 第 2 页 = %v", names)
+	}
+
+	deleted, err := client.DeleteUser(ctx, &userv1.DeleteUserRequest{UserId: ids[1]})
+	if err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if deleted.GetUserId() != ids[1] {
+		t.Errorf("DeleteUser 返回 user_id %d, want %d", deleted.GetUserId(), ids[1])
+	}
+	if _, err := client.GetUser(ctx, &userv1.GetUserRequest{UserId: ids[1]}); status.Code(err) != codes.NotFound {
+		t.Errorf("删除后 GetUser 错误为 %v, want NotFound", err)
+	}
+	if names := listUsers(t, client, &userv1.ListUsersRequest{}); fmt.Sprint(names) != "[张三丰 王五]" {
+		t.Errorf("删除后 ListUsers = %v", names)
+	}
+}
+
+// conflictUserService CreateUser 总是返回用户已存在，其余方法使用内存实现
+// 用户服务本身不校验邮箱唯一，以此覆盖 AlreadyExists 的映射
+type conflictUserService struct {
+	service.UserService
+}
+
+func (conflictUserService) CreateUser(context.Context, *service.CreateUserRequest) (*model.User, error) {
+	return nil, errors.ErrUserAlreadyExists
+}
+
+func TestUserServerErrors(t *testing.T) {
+	client := newUserClient(t, newUserService())
+	ctx := context.Background()
+	created, err := client.CreateUser(ctx, &userv1.CreateUserRequest{Name: "张三", Email: "zhangsan@example.com"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	id := created.GetUser().GetId()
+
+	tests := []struct {
+		name       string
+		call       func(client userv1.UserServiceClient) error
+		wantCode   codes.Code
+		wantBiz    int
+		wantFields []string // BadRequest 详情中的字段
+	}{
+		{"获取不存在的用户", func(c userv1.UserServiceClient) error {
+			_, err := c.GetUser(ctx, &userv1.GetUserRequest{UserId: id + 100})
+			return err
+		}, codes.NotFound, errors.CodeUserNotFound, nil},
+		{"更新不存在的用户", func(c userv1.UserServiceClient) error {
+			_, err := c.UpdateUser(ctx, &userv1.UpdateUserRequest{UserId: id + 100, Name: "李四"})
+			return err
+		}, codes.NotFound, errors.CodeUserNotFound, nil},
+		{"删除不存在的用户", func(c userv1.UserServiceClient) error {
+			_, err := c.DeleteUser(ctx, &userv1.DeleteUserRequest{UserId: id + 100})
+			return err
+		}, codes.NotFound, errors.CodeUserNotFound, nil},
+		{"创建时缺少字段", func(c userv1.UserServiceClient) error {
+			_, err := c.CreateUser(ctx, &userv1.CreateUserRequest{})
+			return err
+		}, codes.InvalidArgument, errors.CodeInvalidParam, []string{"name", "email"}},
+		{"创建时邮箱无效", func(c userv1.UserServiceClient) error {
+			_, err := c.CreateUser(ctx, &userv1.CreateUserRequest{Name: "李四", Email: "lisi"})
+			return err
+		}, codes.InvalidArgument, errors.CodeInvalidParam, []string{"email"}},
+		{"更新时邮箱无效", func(c userv1.UserServiceClient) error {
+			_, err := c.UpdateUser(ctx, &userv1.UpdateUserRequest{UserId: id, Email: "zhangsan"})
+			return err
+		}, codes.InvalidArgument, errors.CodeInvalidParam, []string{"email"}},
+		{"用户 ID 超出范围", func(c userv1.UserServiceClient) error {
+			_, err := c.GetUser(ctx, &userv1.GetUserRequest{UserId: 1 << 33})
+			return err
+		}, codes.InvalidArgument, errors.CodeInvalidUserID, nil},
+		{"用户已存在", func(userv1.UserServiceClient) error {
+			conflict := newUserClient(t, conflictUserService{newUserService()})
+			_, err := conflict.CreateUser(ctx, &userv1.CreateUserRequest{Name: "张三", Email: "zhangsan@example.com"})
+			return err
+		}, codes.AlreadyExists, errors.CodeUserAlreadyExists, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(client)
+			st, ok := status.FromError(err)
+			if !ok || st.Code() != tt.wantCode {
+				t.Fatalf("错误为 %v, want %s", err, tt.wantCode)
+			}
+
+			var fields []string
+			for _, d := range st.Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					for _, v := range br.GetFieldViolations() {
+						fields = append(fields, v.GetField())
+					}
+				}
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.wantFields) {
+				t.Errorf("字段错误 = %v, want %v", fields, tt.wantFields)
+			}
+
+			be, ok := errors.AsBusinessError(errors.FromGRPCStatus(err))
+			if !ok || be.Code != tt.wantBiz {
+				t.Errorf("还原的业务错误为 %v, want 错误码 %d", be, tt.wantBiz)
+			}
+		})
+	}
+}
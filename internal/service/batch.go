@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"rich_go/internal/repository"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+	"rich_go/pkg/validation"
+)
+
+// MaxBatchOperations 单次批量请求的最大操作数
+const MaxBatchOperations = 100
+
+// 批量模式
+const (
+	BatchModeAtomic      = "atomic"      // 全部成功或全部不执行
+	BatchModeIndependent = "independent" // 各操作独立执行，互不影响
+)
+
+// 批量操作类型
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// batchMessages 操作成功时的消息 ID 后缀，与单条接口一致
+var batchMessages = map[string]string{
+	BatchCreate: "created",
+	BatchUpdate: "updated",
+	BatchDelete: "deleted",
+}
+
+// BatchRequest 批量请求
+type BatchRequest struct {
+	Mode       string           `json:"mode" validate:"omitempty,oneof=atomic independent"` // 默认 atomic
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,max=100"`
+}
+
+// BatchOperation 批量请求中的单个操作，body 与单条创建、更新接口的请求体一致
+type BatchOperation struct {
+	Method string          `json:"method" validate:"required,oneof=create update delete"`
+	ID     string          `json:"id,omitempty"` // update、delete 时为资源 ID
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResult 批量请求结果，results 与 operations 按顺序一一对应
+type BatchResult struct {
+	Mode      string            `json:"mode"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult 单个操作的结果，status、code、message、errors 与单条接口的响应一致
+type BatchItemResult struct {
+	Index   int                 `json:"index"`
+	Method  string              `json:"method"`
+	ID      string              `json:"id,omitempty"`
+	Status  int                 `json:"status"` // 对应单条接口的 HTTP 状态码
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Data    interface{}         `json:"data,omitempty"`
+	Errors  []errors.FieldError `json:"errors,omitempty"`
+}
+
+// batchActions 批量操作对应的单条操作，保证校验和业务错误与单条接口一致
+type batchActions[C, U any] struct {
+	resource string // 成功消息 ID 的前缀，例如 coupon 对应 coupon.created
+	create   func(ctx context.Context, req *C) (interface{}, error)
+	update   func(ctx context.Context, id string, req *U) (interface{}, error)
+	delete   func(ctx context.Context, id string) error
+	// find 检查 update、delete 的资源 ID 是否有效且存在，atomic 模式预检时使用
+	find func(ctx context.Context, id string) error
+}
+
+// batchRun 预检通过的操作
+type batchRun func(ctx context.Context) (interface{}, error)
+
+// prepare 校验操作、请求体及资源是否存在，返回执行函数
+func (a batchActions[C, U]) prepare(ctx context.Context, op BatchOperation) (batchRun, error) {
+	if err := validation.Struct(&op); err != nil {
+		return nil, err
+	}
+	if op.Method != BatchCreate && a.find != nil {
+		if err := a.find(ctx, op.ID); err != nil {
+			return nil, err
+		}
+	}
+	switch op.Method {
+	case BatchCreate:
+		req := new(C)
+		if err := decodeBatchBody(op.Body, req); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) (interface{}, error) { return a.create(ctx, req) }, nil
+	case BatchUpdate:
+		req := new(U)
+		if err := decodeBatchBody(op.Body, req); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) (interface{}, error) { return a.update(ctx, op.ID, req) }, nil
+	default:
+		return func(ctx context.Context) (interface{}, error) { return nil, a.delete(ctx, op.ID) }, nil
+	}
+}
+
+// decodeBatchBody 解析并校验请求体
+func decodeBatchBody(body json.RawMessage, v interface{}) error {
+	if err := validation.DecodeJSON(body, v); err != nil {
+		return err
+	}
+	return validation.Struct(v)
+}
+
+// runBatch 执行批量请求
+// atomic 模式先预检所有操作（含 update、delete 的资源是否存在），任一失败则不执行；预检通过后在同一事务中依次执行，失败时事务回滚，
+// 已执行的操作随事务撤销，与未执行的操作一起标记为 ErrBatchAborted
+// independent 模式逐个执行，失败不影响其他操作
+func runBatch[C, U any](ctx context.Context, tx repository.Transactor, req *BatchRequest, actions batchActions[C, U]) (*BatchResult, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	result := &BatchResult{Mode: req.Mode, Results: make([]BatchItemResult, len(req.Operations))}
+	if result.Mode == "" {
+		result.Mode = BatchModeAtomic
+	}
+	if result.Mode == BatchModeIndependent {
+		// 逐个执行时资源可能由之前的操作创建，不做存在性预检
+		actions.find = nil
+	}
+	lang := i18n.LanguageFromContext(ctx)
+	item := func(i int, data interface{}, err error) {
+		r := &result.Results[i]
+		r.Data, r.Errors = nil, nil
+		if err == nil {
+			r.Status, r.Code, r.Data = http.StatusOK, errors.CodeSuccess, data
+			r.Message = i18n.Translate(lang, actions.resource+"."+batchMessages[req.Operations[i].Method], nil)
+			return
+		}
+		be, ok := errors.AsBusinessError(err)
+		if !ok {
+			be = errors.Internal(err)
+		}
+		r.Status, r.Code = be.HTTPStatus(), be.Code
+		r.Message, r.Errors = be.Localize(lang)
+	}
+
+	runs := make([]batchRun, len(req.Operations))
+	failed := false
+	for i, op := range req.Operations {
+		result.Results[i] = BatchItemResult{Index: i, Method: op.Method, ID: op.ID}
+		run, err := actions.prepare(ctx, op)
+		if err != nil {
+			item(i, nil, err)
+			failed = true
+			continue
+		}
+		runs[i] = run
+	}
+
+	switch {
+	case result.Mode == BatchModeIndependent:
+		for i, run := range runs {
+			if run != nil {
+				data, err := run(ctx)
+				item(i, data, err)
+			}
+		}
+	case failed:
+		for i, run := range runs {
+			if run != nil {
+				item(i, nil, errors.ErrBatchAborted)
+			}
+		}
+	default:
+		failedAt := -1
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			for i, run := range runs {
+				data, err := run(ctx)
+				item(i, data, err)
+				if err != nil {
+					failedAt = i
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			for i := range runs {
+				if i != failedAt {
+					item(i, nil, errors.ErrBatchAborted)
+				}
+			}
+		}
+	}
+
+	for _, r := range result.Results {
+		if r.Code == errors.CodeSuccess {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// BatchMessageID 批量结果对应的响应消息 ID
+func BatchMessageID(result *BatchResult) string {
+	if result.Mode == BatchModeAtomic && result.Failed > 0 {
+		return "batch.rejected"
+	}
+	return "batch.completed"
+}
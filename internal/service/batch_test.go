@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"rich_go/internal/audit"
+	"rich_go/internal/event"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/search"
+)
+
+// couponFixture 使用内存仓储、事务、outbox、审计日志和搜索索引的优惠券服务
+type couponFixture struct {
+	ctx     context.Context
+	service CouponService
+	coupons repository.CouponRepository
+	outbox  repository.OutboxRepository
+	audits  repository.AuditRepository
+	index   *search.Index
+}
+
+func newCouponFixture(t *testing.T) *couponFixture {
+	t.Helper()
+	f := &couponFixture{
+		ctx:    tenant.WithID(context.Background(), "acme"),
+		outbox: repository.NewOutboxRepository(),
+		audits: repository.NewAuditRepository(),
+		index:  search.NewIndex(),
+	}
+	f.coupons = repository.NewIndexingCouponRepository(repository.NewCouponRepository(), f.index)
+	f.service = NewCouponService(f.coupons, repository.NewTransactor(),
+		event.NewOutboxRecorder(f.outbox), audit.NewRecorder(f.audits))
+	return f
+}
+
+// search 返回当前租户下命中 q 的文档 ID
+func (f *couponFixture) search(t *testing.T, q string) []string {
+	t.Helper()
+	query, err := search.ParseQuery(q)
+	if err != nil {
+		t.Fatalf("解析查询 %q: %v", q, err)
+	}
+	query.Scope = tenant.ID(f.ctx)
+	var ids []string
+	for _, hit := range f.index.Search(query, 10) {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func mustJSON(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestBatchCouponsAtomicFailureRollsBack(t *testing.T) {
+	f := newCouponFixture(t)
+	existing, err := f.service.CreateCoupon(f.ctx, &CreateCouponRequest{Name: "summer", DiscountType: "fixed", DiscountValue: 10})
+	if err != nil {
+		t.Fatalf("创建优惠券: %v", err)
+	}
+	pending, _ := f.outbox.Pending(f.ctx)
+
+	// 预检时 1 存在，执行到 update 时已被前一个操作删除
+	result, err := f.service.BatchCoupons(f.ctx, &BatchRequest{Operations: []BatchOperation{
+		{Method: BatchCreate, Body: mustJSON(t, CreateCouponRequest{Name: "winter", DiscountType: "fixed", DiscountValue: 5})},
+		{Method: BatchDelete, ID: "1"},
+		{Method: BatchUpdate, ID: "1", Body: mustJSON(t, UpdateCouponRequest{Name: "autumn"})},
+	}})
+	if err != nil {
+		t.Fatalf("BatchCoupons: %v", err)
+	}
+
+	if result.Succeeded != 0 || result.Failed != 3 {
+		t.Errorf("succeeded=%d failed=%d, want 0 and 3", result.Succeeded, result.Failed)
+	}
+	wantCodes := []int{errors.ErrBatchAborted.Code, errors.ErrBatchAborted.Code, errors.ErrCouponNotFound.Code}
+	for i, r := range result.Results {
+		if r.Code != wantCodes[i] {
+			t.Errorf("results[%d].code = %d, want %d", i, r.Code, wantCodes[i])
+		}
+	}
+
+	coupons, _ := f.coupons.FindAll(f.ctx)
+	if len(coupons) != 1 || *coupons[0] != *existing {
+		t.Errorf("回滚后优惠券为 %+v, want 只有 %+v", coupons, existing)
+	}
+	if ids := f.search(t, "summer"); len(ids) != 1 || ids[0] != "1" {
+		t.Errorf("搜索 summer = %v, want [1]", ids)
+	}
+	if ids := f.search(t, "winter"); len(ids) != 0 {
+		t.Errorf("搜索 winter = %v, want 无结果", ids)
+	}
+	if entries, _ := f.audits.FindAll(f.ctx); len(entries) != 1 {
+		t.Errorf("审计日志 %d 条, want 1", len(entries))
+	}
+	if n, _ := f.outbox.Pending(f.ctx); n != pending {
+		t.Errorf("outbox 待投递 %d 条, want %d", n, pending)
+	}
+
+	// 回滚后 ID 不复用也不影响后续写入
+	created, err := f.service.CreateCoupon(f.ctx, &CreateCouponRequest{Name: "spring", DiscountType: "fixed", DiscountValue: 1})
+	if err != nil {
+		t.Fatalf("回滚后创建优惠券: %v", err)
+	}
+	if created.ID == existing.ID {
+		t.Errorf("回滚后创建的优惠券复用了 ID %d", created.ID)
+	}
+}
+
+func TestBatchCouponsAtomicSuccess(t *testing.T) {
+	f := newCouponFixture(t)
+	result, err := f.service.BatchCoupons(f.ctx, &BatchRequest{Operations: []BatchOperation{
+		{Method: BatchCreate, Body: mustJSON(t, CreateCouponRequest{Name: "summer", DiscountType: "fixed", DiscountValue: 10})},
+		{Method: BatchCreate, Body: mustJSON(t, CreateCouponRequest{Name: "winter", DiscountType: "percent", DiscountValue: 20})},
+	}})
+	if err != nil {
+		t.Fatalf("BatchCoupons: %v", err)
+	}
+	if result.Succeeded != 2 || result.Failed != 0 {
+		t.Errorf("succeeded=%d failed=%d, want 2 and 0", result.Succeeded, result.Failed)
+	}
+	if entries, _ := f.audits.FindAll(f.ctx); len(entries) != 2 {
+		t.Errorf("审计日志 %d 条, want 2", len(entries))
+	}
+	if ids := f.search(t, "winter"); len(ids) != 1 {
+		t.Errorf("搜索 winter = %v, want 1 条", ids)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"strings"
+
+	"rich_go/internal/repository"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+	"rich_go/pkg/validation"
+)
+
+// MaxImportRows 单次导入的最大行数
+const MaxImportRows = 10000
+
+// 导入模式
+const (
+	ImportModeAtomic     = "atomic"      // 所有行都通过校验才写入，写入在同一事务中，任一行写入失败则全部回滚
+	ImportModeBestEffort = "best_effort" // 逐行写入，跳过失败的行
+)
+
+// ImportOptions 批量导入参数
+type ImportOptions struct {
+	Mode   string `json:"mode" form:"mode" validate:"omitempty,oneof=atomic best_effort"` // 默认 atomic
+	DryRun bool   `json:"dryRun" form:"dryRun"`                                           // 在事务中执行写入后回滚，配额等规则与实际导入一致
+}
+
+// ImportResult 批量导入结果
+type ImportResult struct {
+	Mode     string           `json:"mode"`
+	DryRun   bool             `json:"dryRun"`
+	Total    int              `json:"total"`    // 数据行数
+	Imported int              `json:"imported"` // 写入的行数，试运行时为可写入的行数，atomic 模式失败时为 0
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ImportRowError 单行导入错误
+type ImportRowError struct {
+	Line    int                 `json:"line"` // 行号，CSV 表头为第 1 行
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Fields  []errors.FieldError `json:"fields,omitempty"`
+}
+
+// errImportRollback 试运行或 atomic 模式有行写入失败时，用于回滚导入事务
+var errImportRollback = stderrors.New("import rolled back")
+
+// importRow 通过校验、等待写入的行
+type importRow[T any] struct {
+	line int
+	req  *T
+}
+
+// importRows 逐行读取、校验并通过 create 写入，create 应为对应的单条创建方法，保证规则一致
+// atomic 模式和试运行在同一事务中写入，写入时的业务错误（如超出租户配额）记录在对应行；
+// atomic 模式遇到第一个失败的行即回滚全部写入，试运行在全部写入后回滚
+// 文件无法继续解析或写入时出现非业务错误时返回错误，此时没有行被写入（best_effort 模式已写入的行除外）
+func importRows[T any](ctx context.Context, tx repository.Transactor, dec *bulk.Decoder, opts *ImportOptions, create func(ctx context.Context, req *T) error) (*ImportResult, error) {
+	if err := validation.Struct(opts); err != nil {
+		return nil, err
+	}
+	result := &ImportResult{Mode: opts.Mode, DryRun: opts.DryRun, Errors: []ImportRowError{}}
+	if result.Mode == "" {
+		result.Mode = ImportModeAtomic
+	}
+	lang := i18n.LanguageFromContext(ctx)
+	fail := func(line int, err error) {
+		be, ok := errors.AsBusinessError(err)
+		if !ok {
+			be = errors.Internal(err)
+		}
+		message, fields := be.Localize(lang)
+		result.Failed++
+		result.Errors = append(result.Errors, ImportRowError{Line: line, Code: be.Code, Message: message, Fields: fields})
+	}
+
+	var valid []importRow[T]
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		req := new(T)
+		line, err := dec.Next(req)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *bulk.RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return nil, errors.ErrInvalidFile.WithCause(err)
+		}
+		if result.Total++; result.Total > MaxImportRows {
+			return nil, errors.ErrTooManyRows
+		}
+
+		if rowErr != nil {
+			fail(line, rowDecodeError(rowErr))
+			continue
+		}
+		if err := validation.Struct(req); err != nil {
+			fail(line, err)
+			continue
+		}
+		switch {
+		case opts.DryRun || result.Mode == ImportModeAtomic:
+			valid = append(valid, importRow[T]{line: line, req: req})
+		default:
+			if err := create(ctx, req); err != nil {
+				fail(line, err)
+				continue
+			}
+			result.Imported++
+		}
+	}
+
+	atomic := result.Mode == ImportModeAtomic
+	if atomic && result.Failed > 0 || !atomic && !opts.DryRun {
+		return result, nil
+	}
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, row := range valid {
+			if err := create(ctx, row.req); err != nil {
+				if _, ok := errors.AsBusinessError(err); !ok {
+					return err
+				}
+				fail(row.line, err)
+				if atomic {
+					return errImportRollback
+				}
+				continue
+			}
+			result.Imported++
+		}
+		if opts.DryRun {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, err
+	}
+	if atomic && result.Failed > 0 {
+		result.Imported = 0
+	}
+	return result, nil
+}
+
+// rowDecodeError 将行解析错误转换为参数错误
+func rowDecodeError(err *bulk.RowError) error {
+	if err.Field != "" {
+		return errors.NewValidationError(errors.NewFieldError(err.Field, "type", err.Type))
+	}
+	return errors.NewLocalizedError(errors.CodeInvalidParam, "validation.invalid_row", nil)
+}
+
+// ImportMessageID 导入结果对应的响应消息 ID
+func ImportMessageID(result *ImportResult) string {
+	switch {
+	case result.DryRun:
+		return "import.dry_run"
+	case result.Mode == ImportModeAtomic && result.Failed > 0:
+		return "import.rejected"
+	default:
+		return "import.completed"
+	}
+}
+
+// containsFold 判断 s 是否包含 substr，不区分大小写，substr 为空时为 true
+func containsFold(s, substr string) bool {
+	return substr == "" || strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
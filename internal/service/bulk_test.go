@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"rich_go/internal/model"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/errors"
+)
+
+const couponCSV = `name,discountType,discountValue
+winter,fixed,5
+spring,fixed,6
+autumn,fixed,7
+`
+
+// withCouponLimit 将 context 中的租户替换为最多 limit 张优惠券的租户
+func withCouponLimit(ctx context.Context, limit int) context.Context {
+	return tenant.WithTenant(ctx, &model.Tenant{ID: tenant.ID(ctx), Limits: model.TenantLimits{MaxCoupons: limit}})
+}
+
+func TestImportCouponsQuota(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         ImportOptions
+		wantImported int
+		wantLines    []int // 失败的行号
+		wantCoupons  int   // 导入后的优惠券数
+	}{
+		{"atomic 写入失败时全部回滚", ImportOptions{Mode: ImportModeAtomic}, 0, []int{3}, 1},
+		{"atomic 试运行检查配额", ImportOptions{Mode: ImportModeAtomic, DryRun: true}, 0, []int{3}, 1},
+		{"best_effort 试运行检查配额", ImportOptions{Mode: ImportModeBestEffort, DryRun: true}, 1, []int{3, 4}, 1},
+		{"best_effort 写入配额内的行", ImportOptions{Mode: ImportModeBestEffort}, 1, []int{3, 4}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCouponFixture(t)
+			ctx := withCouponLimit(f.ctx, 2)
+			if _, err := f.service.CreateCoupon(ctx, &CreateCouponRequest{Name: "summer", DiscountType: "fixed", DiscountValue: 10}); err != nil {
+				t.Fatalf("创建优惠券: %v", err)
+			}
+
+			result, err := f.service.ImportCoupons(ctx, bulk.NewDecoder(strings.NewReader(couponCSV), bulk.CSV), &tt.opts)
+			if err != nil {
+				t.Fatalf("ImportCoupons: %v", err)
+			}
+			if result.Total != 3 || result.Imported != tt.wantImported || result.Failed != len(tt.wantLines) {
+				t.Errorf("total=%d imported=%d failed=%d, want 3, %d, %d",
+					result.Total, result.Imported, result.Failed, tt.wantImported, len(tt.wantLines))
+			}
+			for i, e := range result.Errors {
+				if i >= len(tt.wantLines) || e.Line != tt.wantLines[i] || e.Code != errors.CodeTenantLimitExceeded {
+					t.Errorf("errors[%d] = line %d code %d, want lines %v code %d", i, e.Line, e.Code, tt.wantLines, errors.CodeTenantLimitExceeded)
+				}
+			}
+
+			coupons, _ := f.coupons.FindAll(ctx)
+			if len(coupons) != tt.wantCoupons {
+				t.Errorf("导入后优惠券 %d 张, want %d", len(coupons), tt.wantCoupons)
+			}
+			if entries, _ := f.audits.FindAll(ctx); len(entries) != tt.wantCoupons {
+				t.Errorf("审计日志 %d 条, want %d", len(entries), tt.wantCoupons)
+			}
+			if ids := f.search(t, "winter"); len(ids) != tt.wantCoupons-1 {
+				t.Errorf("搜索 winter = %v, want %d 条", ids, tt.wantCoupons-1)
+			}
+		})
+	}
+}
+
+func TestImportCouponsAtomicRejectsInvalidRows(t *testing.T) {
+	f := newCouponFixture(t)
+	csv := "name,discountType,discountValue\nwinter,fixed,5\n,fixed,abc\n"
+	result, err := f.service.ImportCoupons(f.ctx, bulk.NewDecoder(strings.NewReader(csv), bulk.CSV), &ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportCoupons: %v", err)
+	}
+	if result.Imported != 0 || result.Failed != 1 || ImportMessageID(result) != "import.rejected" {
+		t.Errorf("imported=%d failed=%d message=%s, want 0, 1, import.rejected", result.Imported, result.Failed, ImportMessageID(result))
+	}
+	if coupons, _ := f.coupons.FindAll(f.ctx); len(coupons) != 0 {
+		t.Errorf("导入后优惠券 %d 张, want 0", len(coupons))
+	}
+}
@@ -2,9 +2,15 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"rich_go/internal/audit"
+	"rich_go/internal/event"
 	"rich_go/internal/model"
 	"rich_go/internal/repository"
+	"rich_go/pkg/bulk"
 	"rich_go/pkg/errors"
+	"rich_go/pkg/validation"
 	"strconv"
 )
 
@@ -13,133 +19,356 @@ type CouponService interface {
 	ListCoupons(ctx context.Context) ([]*model.Coupon, error)
 	GetCoupon(ctx context.Context, idStr string) (*model.Coupon, error)
 	CreateCoupon(ctx context.Context, req *CreateCouponRequest) (*model.Coupon, error)
+	// CreateCoupons 在同一事务中创建多张优惠券，全部成功或全部不创建
+	// 有请求未通过校验或写入失败时返回 *BulkCreateError，超出租户配额等整体错误直接返回
+	CreateCoupons(ctx context.Context, reqs []*CreateCouponRequest) ([]*model.Coupon, error)
 	UpdateCoupon(ctx context.Context, idStr string, req *UpdateCouponRequest) (*model.Coupon, error)
 	DeleteCoupon(ctx context.Context, idStr string) error
+	// ImportCoupons 从 dec 批量创建优惠券，每行按 CreateCouponRequest 校验
+	ImportCoupons(ctx context.Context, dec *bulk.Decoder, opts *ImportOptions) (*ImportResult, error)
+	// ExportCoupons 将符合条件的优惠券逐行写入 enc，返回写入数量
+	ExportCoupons(ctx context.Context, filter *CouponFilter, enc *bulk.Encoder) (int, error)
+	// BatchCoupons 批量创建、更新、删除优惠券，每个操作的校验和错误与单条接口一致
+	BatchCoupons(ctx context.Context, req *BatchRequest) (*BatchResult, error)
 }
 
 // CreateCouponRequest 创建优惠券请求
 type CreateCouponRequest struct {
-	Name         string
-	Description  string
-	DiscountType string
-	DiscountValue float64
-	MinAmount    float64
-	Status       string
+	Name          string  `json:"name" validate:"required"`
+	Description   string  `json:"description"`
+	DiscountType  string  `json:"discountType" validate:"required,oneof=fixed percent"`
+	DiscountValue float64 `json:"discountValue" validate:"required,gt=0"`
+	MinAmount     float64 `json:"minAmount" validate:"gte=0"`
+	Status        string  `json:"status" validate:"omitempty,oneof=active inactive"`
 }
 
-// UpdateCouponRequest 更新优惠券请求
+// UpdateCouponRequest 更新优惠券请求，零值字段表示不修改；MinAmount 可以为 0，未提供时不修改
 type UpdateCouponRequest struct {
-	Name         string
-	Description  string
-	DiscountType string
-	DiscountValue float64
-	MinAmount    float64
-	Status       string
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	DiscountType  string   `json:"discountType" validate:"omitempty,oneof=fixed percent"`
+	DiscountValue float64  `json:"discountValue" validate:"gte=0"`
+	MinAmount     *float64 `json:"minAmount" validate:"omitempty,gte=0"`
+	Status        string   `json:"status" validate:"omitempty,oneof=active inactive"`
+}
+
+// apply 将请求中提供的字段合并到 coupon
+func (r *UpdateCouponRequest) apply(coupon *model.Coupon) {
+	if r.Name != "" {
+		coupon.Name = r.Name
+	}
+	if r.Description != "" {
+		coupon.Description = r.Description
+	}
+	if r.DiscountType != "" {
+		coupon.DiscountType = r.DiscountType
+	}
+	if r.DiscountValue > 0 {
+		coupon.DiscountValue = r.DiscountValue
+	}
+	if r.MinAmount != nil {
+		coupon.MinAmount = *r.MinAmount
+	}
+	if r.Status != "" {
+		coupon.Status = r.Status
+	}
+}
+
+// CouponFilter 优惠券导出条件，空字段表示不限制
+type CouponFilter struct {
+	Name         string `json:"name" form:"name"` // 名称包含，不区分大小写
+	Status       string `json:"status" form:"status" validate:"omitempty,oneof=active inactive"`
+	DiscountType string `json:"discountType" form:"discountType" validate:"omitempty,oneof=fixed percent"`
+}
+
+// match 判断优惠券是否符合条件
+func (f *CouponFilter) match(c *model.Coupon) bool {
+	return containsFold(c.Name, f.Name) &&
+		(f.Status == "" || c.Status == f.Status) &&
+		(f.DiscountType == "" || c.DiscountType == f.DiscountType)
+}
+
+// maxPercentDiscount 百分比折扣的最大值
+const maxPercentDiscount = 100
+
+func init() {
+	validation.RegisterStructRule(func(r validation.Reporter) {
+		req := r.Current().(CreateCouponRequest)
+		checkPercentDiscount(r, req.DiscountType, req.DiscountValue)
+	}, CreateCouponRequest{})
+	validation.RegisterStructRule(func(r validation.Reporter) {
+		req := r.Current().(UpdateCouponRequest)
+		checkPercentDiscount(r, req.DiscountType, req.DiscountValue)
+	}, UpdateCouponRequest{})
+}
+
+// checkPercentDiscount 百分比折扣的折扣值不能超过 100
+func checkPercentDiscount(r validation.Reporter, discountType string, discountValue float64) {
+	if discountType == "percent" && discountValue > maxPercentDiscount {
+		r.Report("DiscountValue", "lte", strconv.Itoa(maxPercentDiscount))
+	}
 }
 
 // couponService 优惠券服务实现
 type couponService struct {
 	couponRepo repository.CouponRepository
+	tx         repository.Transactor
+	events     event.Recorder
+	audit      audit.Recorder
 }
 
-// NewCouponService 创建优惠券服务实例
-func NewCouponService(couponRepo repository.CouponRepository) CouponService {
+// NewCouponService 创建优惠券服务实例，写操作在事务中同时记录领域事件和审计日志
+func NewCouponService(couponRepo repository.CouponRepository, tx repository.Transactor, events event.Recorder, auditor audit.Recorder) CouponService {
 	return &couponService{
 		couponRepo: couponRepo,
+		tx:         tx,
+		events:     events,
+		audit:      auditor,
 	}
 }
 
 func (s *couponService) ListCoupons(ctx context.Context) ([]*model.Coupon, error) {
-	return s.couponRepo.FindAll(ctx)
+	coupons, err := s.couponRepo.FindAll(ctx)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrCouponNotFound)
+	}
+	return coupons, nil
 }
 
 func (s *couponService) GetCoupon(ctx context.Context, idStr string) (*model.Coupon, error) {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		return nil, errors.ErrInvalidCouponID
+		return nil, errors.ErrInvalidCouponID.WithCause(err)
 	}
 	coupon, err := s.couponRepo.FindByID(ctx, uint(id))
-	if err == repository.ErrNotFound {
-		return nil, errors.ErrCouponNotFound
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrCouponNotFound)
 	}
-	return coupon, err
+	return coupon, nil
 }
 
 func (s *couponService) CreateCoupon(ctx context.Context, req *CreateCouponRequest) (*model.Coupon, error) {
-	// 业务逻辑验证
-	if req.Name == "" {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "优惠券名称不能为空")
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var created *model.Coupon
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := checkQuota(ctx, tenantLimits(ctx).MaxCoupons, "tenant.coupon_limit_exceeded", s.couponRepo.Count); err != nil {
+			return err
+		}
+		var err error
+		created, err = s.create(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrCouponNotFound)
+	}
+	return created, nil
+}
+
+// BulkCreateError 批量创建失败的请求，按序号升序，此时没有优惠券被创建
+type BulkCreateError struct {
+	Failures []BulkCreateFailure
+}
+
+// BulkCreateFailure 单个请求的失败原因
+type BulkCreateFailure struct {
+	Index int // 请求序号，从 0 开始
+	Err   error
+}
+
+func (e *BulkCreateError) Error() string {
+	return fmt.Sprintf("bulk create: %d request(s) failed, first at index %d: %v", len(e.Failures), e.Failures[0].Index, e.Failures[0].Err)
+}
+
+// errBulkRollback 批量创建中有请求写入失败时，用于回滚事务
+var errBulkRollback = stderrors.New("bulk create rolled back")
+
+func (s *couponService) CreateCoupons(ctx context.Context, reqs []*CreateCouponRequest) ([]*model.Coupon, error) {
+	if len(reqs) > MaxImportRows {
+		return nil, errors.ErrTooManyRows
 	}
-	if req.DiscountType != "fixed" && req.DiscountType != "percent" {
-		return nil, errors.ErrInvalidDiscountType
+	bulkErr := &BulkCreateError{}
+	for i, req := range reqs {
+		if err := validation.Struct(req); err != nil {
+			bulkErr.Failures = append(bulkErr.Failures, BulkCreateFailure{Index: i, Err: err})
+		}
 	}
-	if req.DiscountValue <= 0 {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "折扣值必须大于 0")
+	if len(bulkErr.Failures) > 0 {
+		return nil, bulkErr
 	}
-	if req.MinAmount < 0 {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "最低使用金额不能小于 0")
+
+	created := make([]*model.Coupon, 0, len(reqs))
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// 配额按本次创建的总数只检查一次，事务串行执行，期间不会有其他创建
+		if err := checkQuotaFor(ctx, tenantLimits(ctx).MaxCoupons, "tenant.coupon_limit_exceeded", s.couponRepo.Count, len(reqs)); err != nil {
+			return err
+		}
+		for i, req := range reqs {
+			coupon, err := s.create(ctx, req)
+			if err != nil {
+				err = translateRepoError(err, errors.ErrCouponNotFound)
+				if _, ok := errors.AsBusinessError(err); !ok {
+					return err
+				}
+				bulkErr.Failures = append(bulkErr.Failures, BulkCreateFailure{Index: i, Err: err})
+				return errBulkRollback
+			}
+			created = append(created, coupon)
+		}
+		return nil
+	})
+	if errors.Is(err, errBulkRollback) {
+		return nil, bulkErr
+	}
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrCouponNotFound)
 	}
+	return created, nil
+}
 
+// create 写入已通过校验的优惠券并记录审计日志和领域事件，需在事务中调用，不检查配额
+func (s *couponService) create(ctx context.Context, req *CreateCouponRequest) (*model.Coupon, error) {
 	// 设置默认状态
 	status := req.Status
 	if status == "" {
 		status = "active"
 	}
 
-	coupon := &model.Coupon{
-		Name:         req.Name,
-		Description:  req.Description,
-		DiscountType: req.DiscountType,
+	created, err := s.couponRepo.Create(ctx, &model.Coupon{
+		Name:          req.Name,
+		Description:   req.Description,
+		DiscountType:  req.DiscountType,
 		DiscountValue: req.DiscountValue,
-		MinAmount:    req.MinAmount,
-		Status:       status,
+		MinAmount:     req.MinAmount,
+		Currency:      TenantCurrency(ctx),
+		Status:        status,
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return s.couponRepo.Create(ctx, coupon)
+	if err := s.recordAudit(ctx, model.AuditActionCreate, created.ID, nil, created); err != nil {
+		return nil, err
+	}
+	if err := s.events.Record(ctx, event.CouponCreated{Coupon: *created}); err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 func (s *couponService) UpdateCoupon(ctx context.Context, idStr string, req *UpdateCouponRequest) (*model.Coupon, error) {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		return nil, errors.ErrInvalidCouponID
-	}
-
-	// 业务逻辑验证
-	if req.DiscountType != "" && req.DiscountType != "fixed" && req.DiscountType != "percent" {
-		return nil, errors.ErrInvalidDiscountType
-	}
-	if req.DiscountValue < 0 {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "折扣值不能小于 0")
-	}
-	if req.MinAmount < 0 {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "最低使用金额不能小于 0")
+		return nil, errors.ErrInvalidCouponID.WithCause(err)
 	}
 
-	coupon := &model.Coupon{
-		Name:         req.Name,
-		Description:  req.Description,
-		DiscountType: req.DiscountType,
-		DiscountValue: req.DiscountValue,
-		MinAmount:    req.MinAmount,
-		Status:       req.Status,
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
-	result, err := s.couponRepo.Update(ctx, uint(id), coupon)
-	if err == repository.ErrNotFound {
-		return nil, errors.ErrCouponNotFound
+	var result *model.Coupon
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.couponRepo.FindByID(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+		// 按合并后的折扣类型和折扣值校验百分比上限，例如只把固定金额 150 改为百分比
+		coupon := *before
+		req.apply(&coupon)
+		if coupon.DiscountType == "percent" && coupon.DiscountValue > maxPercentDiscount {
+			return errors.NewValidationError(
+				errors.NewFieldError("discountValue", "lte", strconv.Itoa(maxPercentDiscount)))
+		}
+		if result, err = s.couponRepo.Update(ctx, uint(id), &coupon); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, model.AuditActionUpdate, result.ID, before, result); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, event.CouponUpdated{Coupon: *result})
+	})
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrCouponNotFound)
 	}
-	return result, err
+	return result, nil
 }
 
 func (s *couponService) DeleteCoupon(ctx context.Context, idStr string) error {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		return errors.ErrInvalidCouponID
+		return errors.ErrInvalidCouponID.WithCause(err)
 	}
-	err = s.couponRepo.Delete(ctx, uint(id))
-	if err == repository.ErrNotFound {
-		return errors.ErrCouponNotFound
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.couponRepo.FindByID(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+		if err := s.couponRepo.Delete(ctx, uint(id)); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, model.AuditActionDelete, uint(id), before, nil); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, event.CouponDeleted{CouponID: uint(id)})
+	})
+	return translateRepoError(err, errors.ErrCouponNotFound)
+}
+
+func (s *couponService) ImportCoupons(ctx context.Context, dec *bulk.Decoder, opts *ImportOptions) (*ImportResult, error) {
+	return importRows(ctx, s.tx, dec, opts, func(ctx context.Context, req *CreateCouponRequest) error {
+		_, err := s.CreateCoupon(ctx, req)
+		return err
+	})
+}
+
+func (s *couponService) BatchCoupons(ctx context.Context, req *BatchRequest) (*BatchResult, error) {
+	return runBatch(ctx, s.tx, req, batchActions[CreateCouponRequest, UpdateCouponRequest]{
+		resource: "coupon",
+		create: func(ctx context.Context, req *CreateCouponRequest) (interface{}, error) {
+			return s.CreateCoupon(ctx, req)
+		},
+		update: func(ctx context.Context, id string, req *UpdateCouponRequest) (interface{}, error) {
+			return s.UpdateCoupon(ctx, id, req)
+		},
+		delete: s.DeleteCoupon,
+		find: func(ctx context.Context, id string) error {
+			_, err := s.GetCoupon(ctx, id)
+			return err
+		},
+	})
+}
+
+func (s *couponService) ExportCoupons(ctx context.Context, filter *CouponFilter, enc *bulk.Encoder) (int, error) {
+	if err := validation.Struct(filter); err != nil {
+		return 0, err
+	}
+	coupons, err := s.couponRepo.FindAll(ctx)
+	if err != nil {
+		return 0, translateRepoError(err, errors.ErrCouponNotFound)
 	}
-	return err
+
+	exported := 0
+	for _, coupon := range coupons {
+		if !filter.match(coupon) {
+			continue
+		}
+		if err := enc.Encode(coupon); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+	return exported, enc.Flush()
+}
+
+// recordAudit 记录优惠券变更的审计日志
+func (s *couponService) recordAudit(ctx context.Context, action string, id uint, before, after *model.Coupon) error {
+	return s.audit.Record(ctx, audit.Change{
+		Action:       action,
+		ResourceType: audit.ResourceCoupon,
+		ResourceID:   strconv.FormatUint(uint64(id), 10),
+		Before:       snapshot(before),
+		After:        snapshot(after),
+	})
 }
 
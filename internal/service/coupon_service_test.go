@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"rich_go/pkg/errors"
+)
+
+func TestUpdateCouponValidatesMergedPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		create  CreateCouponRequest
+		update  UpdateCouponRequest
+		wantErr bool
+	}{
+		{"固定金额 150 只改为百分比", CreateCouponRequest{DiscountType: "fixed", DiscountValue: 150}, UpdateCouponRequest{DiscountType: "percent"}, true},
+		{"百分比只修改折扣值", CreateCouponRequest{DiscountType: "percent", DiscountValue: 10}, UpdateCouponRequest{DiscountValue: 150}, true},
+		{"同时修改类型和折扣值", CreateCouponRequest{DiscountType: "fixed", DiscountValue: 150}, UpdateCouponRequest{DiscountType: "percent", DiscountValue: 20}, false},
+		{"固定金额只修改折扣值", CreateCouponRequest{DiscountType: "fixed", DiscountValue: 10}, UpdateCouponRequest{DiscountValue: 150}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCouponFixture(t)
+			tt.create.Name = "summer"
+			created, err := f.service.CreateCoupon(f.ctx, &tt.create)
+			if err != nil {
+				t.Fatalf("创建优惠券: %v", err)
+			}
+
+			_, err = f.service.UpdateCoupon(f.ctx, fmt.Sprint(created.ID), &tt.update)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("UpdateCoupon: %v", err)
+				}
+				return
+			}
+			be, ok := errors.AsBusinessError(err)
+			if !ok || be.Code != errors.CodeInvalidParam || len(be.Fields) != 1 || be.Fields[0].Field != "discountValue" {
+				t.Fatalf("UpdateCoupon 错误为 %v, want discountValue 校验错误", err)
+			}
+			got, _ := f.coupons.FindByID(f.ctx, created.ID)
+			if *got != *created {
+				t.Errorf("校验失败后优惠券为 %+v, want 不变的 %+v", got, created)
+			}
+		})
+	}
+}
+
+func TestUpdateCouponMinAmount(t *testing.T) {
+	f := newCouponFixture(t)
+	created, err := f.service.CreateCoupon(f.ctx, &CreateCouponRequest{Name: "summer", DiscountType: "fixed", DiscountValue: 10, MinAmount: 50})
+	if err != nil {
+		t.Fatalf("创建优惠券: %v", err)
+	}
+	id := fmt.Sprint(created.ID)
+
+	updated, err := f.service.UpdateCoupon(f.ctx, id, &UpdateCouponRequest{Name: "winter"})
+	if err != nil {
+		t.Fatalf("UpdateCoupon: %v", err)
+	}
+	if updated.Name != "winter" || updated.MinAmount != 50 {
+		t.Errorf("未提供 minAmount 时更新为 %+v, want minAmount 保持 50", updated)
+	}
+
+	zero := 0.0
+	updated, err = f.service.UpdateCoupon(f.ctx, id, &UpdateCouponRequest{MinAmount: &zero})
+	if err != nil {
+		t.Fatalf("UpdateCoupon: %v", err)
+	}
+	if updated.MinAmount != 0 || updated.Name != "winter" {
+		t.Errorf("minAmount 设为 0 时更新为 %+v, want 只修改 minAmount", updated)
+	}
+
+	negative := -1.0
+	_, err = f.service.UpdateCoupon(f.ctx, id, &UpdateCouponRequest{MinAmount: &negative})
+	if be, ok := errors.AsBusinessError(err); !ok || be.Code != errors.CodeInvalidParam {
+		t.Errorf("minAmount 为负数时错误为 %v, want 校验错误", err)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"rich_go/internal/repository"
+	"rich_go/pkg/errors"
+)
+
+// translateRepoError 将仓储错误转换为业务错误，原始错误作为 cause 保留用于日志
+// 记录不存在时返回 notFound，未解析租户时返回 ErrTenantRequired，已是业务错误时原样返回，其他错误统一视为内部错误
+func translateRepoError(err error, notFound *errors.BusinessError) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repository.ErrNotFound):
+		return notFound.WithCause(err)
+	case errors.Is(err, repository.ErrTenantRequired):
+		return errors.ErrTenantRequired.WithCause(err)
+	case errors.IsBusinessError(err):
+		// 事务中的业务检查（如租户配额）返回的错误原样返回
+		return err
+	default:
+		return errors.Internal(err)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/search"
+	"rich_go/pkg/validation"
+)
+
+// maxSearchHits 单次搜索返回的最大结果数
+const maxSearchHits = 1000
+
+// SearchService 全文搜索服务接口
+type SearchService interface {
+	// Search 搜索当前租户的用户和优惠券，结果按相关度排序
+	Search(ctx context.Context, req *SearchQuery) ([]search.Hit, error)
+}
+
+// SearchQuery 搜索请求，q 支持 name:、email:、description:、status:、discountType:、type: 限定字段
+type SearchQuery struct {
+	Q    string `json:"q" form:"q" validate:"required,max=200"`
+	Type string `json:"type" form:"type" validate:"omitempty,oneof=user coupon"`
+}
+
+// searchService 全文搜索服务实现
+type searchService struct {
+	index *search.Index
+}
+
+// NewSearchService 创建全文搜索服务实例
+func NewSearchService(index *search.Index) SearchService {
+	return &searchService{index: index}
+}
+
+func (s *searchService) Search(ctx context.Context, req *SearchQuery) ([]search.Hit, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	tenantID := tenant.ID(ctx)
+	if tenantID == "" {
+		return nil, errors.ErrTenantRequired
+	}
+	query, err := search.ParseQuery(req.Q)
+	if err != nil {
+		return nil, errors.NewLocalizedError(errors.CodeInvalidParam, "search.invalid_query", nil).WithCause(err)
+	}
+	for _, field := range query.Fields() {
+		if !knownSearchField(field) {
+			return nil, errors.NewLocalizedError(errors.CodeInvalidParam, "search.unknown_field", map[string]interface{}{
+				"field":  field,
+				"fields": strings.Join(repository.SearchFields, ", "),
+			})
+		}
+	}
+	if req.Type != "" {
+		query.Types = []string{req.Type}
+	}
+	query.Scope = tenantID
+	return s.index.Search(query, maxSearchHits), nil
+}
+
+// knownSearchField 判断查询中的字段是否可搜索，不区分大小写
+func knownSearchField(field string) bool {
+	for _, f := range repository.SearchFields {
+		if strings.EqualFold(f, field) {
+			return true
+		}
+	}
+	return false
+}
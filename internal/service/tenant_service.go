@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+
+	"rich_go/internal/audit"
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+	"rich_go/pkg/validation"
+)
+
+// DefaultTenantCurrency 创建租户时未指定币种使用的币种
+const DefaultTenantCurrency = "CNY"
+
+// TenantService 租户管理服务接口，租户本身属于平台数据，不按租户隔离
+type TenantService interface {
+	ListTenants(ctx context.Context) ([]*model.Tenant, error)
+	GetTenant(ctx context.Context, id string) (*model.Tenant, error)
+	CreateTenant(ctx context.Context, req *CreateTenantRequest) (*model.Tenant, error)
+	UpdateTenant(ctx context.Context, id string, req *UpdateTenantRequest) (*model.Tenant, error)
+	// ResolveTenant 查找请求所属的租户，租户不存在或已停用时返回错误，供 HTTP 中间件和 gRPC 拦截器使用
+	ResolveTenant(ctx context.Context, id string) (*model.Tenant, error)
+}
+
+// TenantLimits 租户配额，0 表示不限制
+type TenantLimits struct {
+	MaxUsers   int `json:"maxUsers" validate:"gte=0"`
+	MaxCoupons int `json:"maxCoupons" validate:"gte=0"`
+}
+
+// CreateTenantRequest 创建租户请求，ID 同时用作子域名，创建后不可修改
+type CreateTenantRequest struct {
+	ID       string       `json:"id" validate:"required,dns_rfc1035_label"`
+	Name     string       `json:"name" validate:"required,max=100"`
+	Currency string       `json:"currency" validate:"omitempty,iso4217"`          // 默认 CNY
+	Locale   string       `json:"locale" validate:"omitempty,bcp47_language_tag"` // 默认 zh-CN
+	Limits   TenantLimits `json:"limits"`
+}
+
+// UpdateTenantRequest 更新租户请求，零值字段表示不修改
+type UpdateTenantRequest struct {
+	Name     string        `json:"name" validate:"max=100"`
+	Currency string        `json:"currency" validate:"omitempty,iso4217"`
+	Locale   string        `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	Limits   *TenantLimits `json:"limits"`
+	Status   string        `json:"status" validate:"omitempty,oneof=active suspended"`
+}
+
+// tenantService 租户管理服务实现
+type tenantService struct {
+	tenantRepo repository.TenantRepository
+	tx         repository.Transactor
+	audit      audit.Recorder
+}
+
+// NewTenantService 创建租户管理服务实例，写操作记录审计日志
+func NewTenantService(tenantRepo repository.TenantRepository, tx repository.Transactor, auditor audit.Recorder) TenantService {
+	return &tenantService{
+		tenantRepo: tenantRepo,
+		tx:         tx,
+		audit:      auditor,
+	}
+}
+
+func (s *tenantService) ListTenants(ctx context.Context) ([]*model.Tenant, error) {
+	tenants, err := s.tenantRepo.FindAll(ctx)
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return tenants, nil
+}
+
+func (s *tenantService) GetTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	t, err := s.tenantRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrTenantNotFound)
+	}
+	return t, nil
+}
+
+func (s *tenantService) CreateTenant(ctx context.Context, req *CreateTenantRequest) (*model.Tenant, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	t := &model.Tenant{
+		ID:       req.ID,
+		Name:     req.Name,
+		Currency: req.Currency,
+		Locale:   req.Locale,
+		Limits:   model.TenantLimits(req.Limits),
+		Status:   model.TenantActive,
+	}
+	if t.Currency == "" {
+		t.Currency = DefaultTenantCurrency
+	}
+	if t.Locale == "" {
+		t.Locale = i18n.DefaultLanguage
+	}
+
+	var created *model.Tenant
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.tenantRepo.Create(ctx, t); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, model.AuditActionCreate, created.ID, nil, created)
+	})
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return nil, errors.ErrTenantAlreadyExists.WithCause(err)
+	}
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return created, nil
+}
+
+func (s *tenantService) UpdateTenant(ctx context.Context, id string, req *UpdateTenantRequest) (*model.Tenant, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var result *model.Tenant
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.tenantRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		t := *before
+		if req.Name != "" {
+			t.Name = req.Name
+		}
+		if req.Currency != "" {
+			t.Currency = req.Currency
+		}
+		if req.Locale != "" {
+			t.Locale = req.Locale
+		}
+		if req.Limits != nil {
+			t.Limits = model.TenantLimits(*req.Limits)
+		}
+		if req.Status != "" {
+			t.Status = req.Status
+		}
+		if result, err = s.tenantRepo.Update(ctx, id, &t); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, model.AuditActionUpdate, id, before, result)
+	})
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrTenantNotFound)
+	}
+	return result, nil
+}
+
+func (s *tenantService) ResolveTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	t, err := s.GetTenant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status == model.TenantSuspended {
+		return nil, errors.ErrTenantSuspended
+	}
+	return t, nil
+}
+
+// recordAudit 记录租户变更的审计日志
+func (s *tenantService) recordAudit(ctx context.Context, action, id string, before, after *model.Tenant) error {
+	return s.audit.Record(ctx, audit.Change{
+		Action:       action,
+		ResourceType: audit.ResourceTenant,
+		ResourceID:   id,
+		Before:       snapshot(before),
+		After:        snapshot(after),
+	})
+}
+
+// tenantLimits 返回 context 中租户的配额，未解析租户时不限制
+func tenantLimits(ctx context.Context) model.TenantLimits {
+	if t, ok := tenant.FromContext(ctx); ok {
+		return t.Limits
+	}
+	return model.TenantLimits{}
+}
+
+// TenantCurrency 返回 context 中租户的币种，未解析租户或租户未设置币种时使用 DefaultTenantCurrency
+func TenantCurrency(ctx context.Context) string {
+	if t, ok := tenant.FromContext(ctx); ok && t.Currency != "" {
+		return t.Currency
+	}
+	return DefaultTenantCurrency
+}
+
+// checkQuota 创建前检查租户配额，limit 为 0 时不限制
+// 应在与创建相同的事务中调用，事务串行执行，并发创建不会超出配额
+func checkQuota(ctx context.Context, limit int, messageID string, count func(ctx context.Context) (int, error)) error {
+	return checkQuotaFor(ctx, limit, messageID, count, 1)
+}
+
+// checkQuotaFor 一次创建 adding 个资源前检查租户配额，只统计一次现有数量
+func checkQuotaFor(ctx context.Context, limit int, messageID string, count func(ctx context.Context) (int, error), adding int) error {
+	if limit <= 0 {
+		return nil
+	}
+	n, err := count(ctx)
+	if err != nil {
+		return err
+	}
+	if n+adding > limit {
+		return errors.NewLocalizedError(errors.CodeTenantLimitExceeded, messageID, map[string]interface{}{"limit": limit})
+	}
+	return nil
+}
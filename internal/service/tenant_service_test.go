@@ -0,0 +1,40 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"rich_go/pkg/errors"
+)
+
+func TestCreateCouponQuotaConcurrent(t *testing.T) {
+	f := newCouponFixture(t)
+	ctx := withCouponLimit(f.ctx, 5)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.service.CreateCoupon(ctx, &CreateCouponRequest{Name: "summer", DiscountType: "fixed", DiscountValue: 10})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		be, ok := errors.AsBusinessError(err)
+		switch {
+		case err == nil:
+			created++
+		case !ok || be.Code != errors.CodeTenantLimitExceeded:
+			t.Errorf("CreateCoupon = %v, want 配额错误", err)
+		}
+	}
+	if n, _ := f.coupons.Count(ctx); created != 5 || n != 5 {
+		t.Errorf("创建 %d 张，仓储中 %d 张, want 5", created, n)
+	}
+}
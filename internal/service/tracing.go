@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"rich_go/internal/audit"
+	"rich_go/internal/model"
+	"rich_go/internal/tracing"
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/search"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracingUserService 为 UserService 添加链路追踪的装饰器
+type tracingUserService struct {
+	next UserService
+}
+
+// NewTracingUserService 创建带链路追踪的用户服务
+func NewTracingUserService(next UserService) UserService {
+	return &tracingUserService{next: next}
+}
+
+func (s *tracingUserService) ListUsers(ctx context.Context) (users []*model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListUsers(ctx)
+}
+
+func (s *tracingUserService) GetUser(ctx context.Context, idStr string) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser", attribute.String("user.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUser(ctx, idStr)
+}
+
+func (s *tracingUserService) CreateUser(ctx context.Context, req *CreateUserRequest) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateUser(ctx, req)
+}
+
+func (s *tracingUserService) UpdateUser(ctx context.Context, idStr string, req *UpdateUserRequest) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser", attribute.String("user.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateUser(ctx, idStr, req)
+}
+
+func (s *tracingUserService) DeleteUser(ctx context.Context, idStr string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser", attribute.String("user.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteUser(ctx, idStr)
+}
+
+func (s *tracingUserService) ImportUsers(ctx context.Context, dec *bulk.Decoder, opts *ImportOptions) (result *ImportResult, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ImportUsers",
+		attribute.String("import.mode", opts.Mode), attribute.Bool("import.dry_run", opts.DryRun))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int("import.total", result.Total), attribute.Int("import.failed", result.Failed))
+		}
+		tracing.End(span, err)
+	}()
+	return s.next.ImportUsers(ctx, dec, opts)
+}
+
+func (s *tracingUserService) ExportUsers(ctx context.Context, filter *UserFilter, enc *bulk.Encoder) (exported int, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ExportUsers")
+	defer func() {
+		span.SetAttributes(attribute.Int("export.rows", exported))
+		tracing.End(span, err)
+	}()
+	return s.next.ExportUsers(ctx, filter, enc)
+}
+
+func (s *tracingUserService) BatchUsers(ctx context.Context, req *BatchRequest) (result *BatchResult, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.BatchUsers",
+		attribute.String("batch.mode", req.Mode), attribute.Int("batch.operations", len(req.Operations)))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int("batch.failed", result.Failed))
+		}
+		tracing.End(span, err)
+	}()
+	return s.next.BatchUsers(ctx, req)
+}
+
+// tracingCouponService 为 CouponService 添加链路追踪的装饰器
+type tracingCouponService struct {
+	next CouponService
+}
+
+// NewTracingCouponService 创建带链路追踪的优惠券服务
+func NewTracingCouponService(next CouponService) CouponService {
+	return &tracingCouponService{next: next}
+}
+
+func (s *tracingCouponService) ListCoupons(ctx context.Context) (coupons []*model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.ListCoupons")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListCoupons(ctx)
+}
+
+func (s *tracingCouponService) GetCoupon(ctx context.Context, idStr string) (coupon *model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.GetCoupon", attribute.String("coupon.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetCoupon(ctx, idStr)
+}
+
+func (s *tracingCouponService) CreateCoupon(ctx context.Context, req *CreateCouponRequest) (coupon *model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.CreateCoupon")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateCoupon(ctx, req)
+}
+
+func (s *tracingCouponService) CreateCoupons(ctx context.Context, reqs []*CreateCouponRequest) (coupons []*model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.CreateCoupons", attribute.Int("coupon.count", len(reqs)))
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateCoupons(ctx, reqs)
+}
+
+func (s *tracingCouponService) UpdateCoupon(ctx context.Context, idStr string, req *UpdateCouponRequest) (coupon *model.Coupon, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.UpdateCoupon", attribute.String("coupon.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateCoupon(ctx, idStr, req)
+}
+
+func (s *tracingCouponService) DeleteCoupon(ctx context.Context, idStr string) (err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.DeleteCoupon", attribute.String("coupon.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteCoupon(ctx, idStr)
+}
+
+func (s *tracingCouponService) ImportCoupons(ctx context.Context, dec *bulk.Decoder, opts *ImportOptions) (result *ImportResult, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.ImportCoupons",
+		attribute.String("import.mode", opts.Mode), attribute.Bool("import.dry_run", opts.DryRun))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int("import.total", result.Total), attribute.Int("import.failed", result.Failed))
+		}
+		tracing.End(span, err)
+	}()
+	return s.next.ImportCoupons(ctx, dec, opts)
+}
+
+func (s *tracingCouponService) ExportCoupons(ctx context.Context, filter *CouponFilter, enc *bulk.Encoder) (exported int, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.ExportCoupons")
+	defer func() {
+		span.SetAttributes(attribute.Int("export.rows", exported))
+		tracing.End(span, err)
+	}()
+	return s.next.ExportCoupons(ctx, filter, enc)
+}
+
+func (s *tracingCouponService) BatchCoupons(ctx context.Context, req *BatchRequest) (result *BatchResult, err error) {
+	ctx, span := tracing.Start(ctx, "CouponService.BatchCoupons",
+		attribute.String("batch.mode", req.Mode), attribute.Int("batch.operations", len(req.Operations)))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int("batch.failed", result.Failed))
+		}
+		tracing.End(span, err)
+	}()
+	return s.next.BatchCoupons(ctx, req)
+}
+
+// tracingWebhookService 为 WebhookService 添加链路追踪的装饰器
+type tracingWebhookService struct {
+	next WebhookService
+}
+
+// NewTracingWebhookService 创建带链路追踪的 webhook 订阅服务
+func NewTracingWebhookService(next WebhookService) WebhookService {
+	return &tracingWebhookService{next: next}
+}
+
+func (s *tracingWebhookService) ListWebhooks(ctx context.Context) (webhooks []*model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListWebhooks")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListWebhooks(ctx)
+}
+
+func (s *tracingWebhookService) GetWebhook(ctx context.Context, idStr string) (webhook *model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetWebhook", attribute.String("webhook.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetWebhook(ctx, idStr)
+}
+
+func (s *tracingWebhookService) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (webhook *model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.CreateWebhook")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateWebhook(ctx, req)
+}
+
+func (s *tracingWebhookService) UpdateWebhook(ctx context.Context, idStr string, req *UpdateWebhookRequest) (webhook *model.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.UpdateWebhook", attribute.String("webhook.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateWebhook(ctx, idStr, req)
+}
+
+func (s *tracingWebhookService) DeleteWebhook(ctx context.Context, idStr string) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeleteWebhook", attribute.String("webhook.id", idStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteWebhook(ctx, idStr)
+}
+
+func (s *tracingWebhookService) ListDeliveries(ctx context.Context, idStr string, status string) (deliveries []*model.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListDeliveries",
+		attribute.String("webhook.id", idStr), attribute.String("delivery.status", status))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListDeliveries(ctx, idStr, status)
+}
+
+func (s *tracingWebhookService) Redeliver(ctx context.Context, idStr, deliveryIDStr string) (delivery *model.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver",
+		attribute.String("webhook.id", idStr), attribute.String("delivery.id", deliveryIDStr))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Redeliver(ctx, idStr, deliveryIDStr)
+}
+
+func (s *tracingWebhookService) PurgeDeliveries(ctx context.Context, retention time.Duration) (deleted int, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.PurgeDeliveries")
+	defer func() {
+		span.SetAttributes(attribute.Int("delivery.deleted", deleted))
+		tracing.End(span, err)
+	}()
+	return s.next.PurgeDeliveries(ctx, retention)
+}
+
+// tracingAuditService 为 AuditService 添加链路追踪的装饰器
+type tracingAuditService struct {
+	next AuditService
+}
+
+// NewTracingAuditService 创建带链路追踪的审计日志查询服务
+func NewTracingAuditService(next AuditService) AuditService {
+	return &tracingAuditService{next: next}
+}
+
+func (s *tracingAuditService) ListEntries(ctx context.Context, query *AuditQuery) (entries []*model.AuditEntry, err error) {
+	ctx, span := tracing.Start(ctx, "AuditService.ListEntries")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListEntries(ctx, query)
+}
+
+func (s *tracingAuditService) VerifyChain(ctx context.Context) (result *audit.Verification, err error) {
+	ctx, span := tracing.Start(ctx, "AuditService.VerifyChain")
+	defer func() { tracing.End(span, err) }()
+	return s.next.VerifyChain(ctx)
+}
+
+// tracingSearchService 为 SearchService 添加链路追踪的装饰器
+type tracingSearchService struct {
+	next SearchService
+}
+
+// NewTracingSearchService 创建带链路追踪的全文搜索服务
+func NewTracingSearchService(next SearchService) SearchService {
+	return &tracingSearchService{next: next}
+}
+
+func (s *tracingSearchService) Search(ctx context.Context, req *SearchQuery) (hits []search.Hit, err error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search", attribute.String("search.type", req.Type))
+	defer func() {
+		span.SetAttributes(attribute.Int("search.hits", len(hits)))
+		tracing.End(span, err)
+	}()
+	return s.next.Search(ctx, req)
+}
+
+// tracingTenantService 为 TenantService 添加链路追踪的装饰器
+type tracingTenantService struct {
+	next TenantService
+}
+
+// NewTracingTenantService 创建带链路追踪的租户管理服务
+func NewTracingTenantService(next TenantService) TenantService {
+	return &tracingTenantService{next: next}
+}
+
+func (s *tracingTenantService) ListTenants(ctx context.Context) (tenants []*model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantService.ListTenants")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ListTenants(ctx)
+}
+
+func (s *tracingTenantService) GetTenant(ctx context.Context, id string) (t *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantService.GetTenant", attribute.String("tenant.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetTenant(ctx, id)
+}
+
+func (s *tracingTenantService) CreateTenant(ctx context.Context, req *CreateTenantRequest) (t *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantService.CreateTenant", attribute.String("tenant.id", req.ID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateTenant(ctx, req)
+}
+
+func (s *tracingTenantService) UpdateTenant(ctx context.Context, id string, req *UpdateTenantRequest) (t *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantService.UpdateTenant", attribute.String("tenant.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateTenant(ctx, id, req)
+}
+
+func (s *tracingTenantService) ResolveTenant(ctx context.Context, id string) (t *model.Tenant, err error) {
+	ctx, span := tracing.Start(ctx, "TenantService.ResolveTenant", attribute.String("tenant.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ResolveTenant(ctx, id)
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"rich_go/internal/audit"
+	"rich_go/internal/event"
 	"rich_go/internal/model"
 	"rich_go/internal/repository"
+	"rich_go/pkg/bulk"
 	"rich_go/pkg/errors"
+	"rich_go/pkg/validation"
 	"strconv"
 )
 
@@ -12,83 +16,219 @@ import (
 type UserService interface {
 	ListUsers(ctx context.Context) ([]*model.User, error)
 	GetUser(ctx context.Context, idStr string) (*model.User, error)
-	CreateUser(ctx context.Context, name, email string) (*model.User, error)
-	UpdateUser(ctx context.Context, idStr string, name, email string) (*model.User, error)
+	CreateUser(ctx context.Context, req *CreateUserRequest) (*model.User, error)
+	UpdateUser(ctx context.Context, idStr string, req *UpdateUserRequest) (*model.User, error)
 	DeleteUser(ctx context.Context, idStr string) error
+	// ImportUsers 从 dec 批量创建用户，每行按 CreateUserRequest 校验
+	ImportUsers(ctx context.Context, dec *bulk.Decoder, opts *ImportOptions) (*ImportResult, error)
+	// ExportUsers 将符合条件的用户逐行写入 enc，返回写入数量
+	ExportUsers(ctx context.Context, filter *UserFilter, enc *bulk.Encoder) (int, error)
+	// BatchUsers 批量创建、更新、删除用户，每个操作的校验和错误与单条接口一致
+	BatchUsers(ctx context.Context, req *BatchRequest) (*BatchResult, error)
+}
+
+// CreateUserRequest 创建用户请求
+type CreateUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+// UpdateUserRequest 更新用户请求，零值字段表示不修改
+type UpdateUserRequest struct {
+	Name   string `json:"name"`
+	Email  string `json:"email" validate:"omitempty,email"`
+	Status string `json:"status" validate:"omitempty,oneof=active inactive"`
+}
+
+// UserFilter 用户导出条件，空字段表示不限制
+type UserFilter struct {
+	Name  string `json:"name" form:"name"`   // 姓名包含，不区分大小写
+	Email string `json:"email" form:"email"` // 邮箱包含，不区分大小写
+}
+
+// match 判断用户是否符合条件
+func (f *UserFilter) match(u *model.User) bool {
+	return containsFold(u.Name, f.Name) && containsFold(u.Email, f.Email)
 }
 
 // userService 用户服务实现
 type userService struct {
 	userRepo repository.UserRepository
+	tx       repository.Transactor
+	events   event.Recorder
+	audit    audit.Recorder
 }
 
-// NewUserService 创建用户服务实例
-func NewUserService(userRepo repository.UserRepository) UserService {
+// NewUserService 创建用户服务实例，写操作在事务中同时记录领域事件和审计日志
+func NewUserService(userRepo repository.UserRepository, tx repository.Transactor, events event.Recorder, auditor audit.Recorder) UserService {
 	return &userService{
 		userRepo: userRepo,
+		tx:       tx,
+		events:   events,
+		audit:    auditor,
 	}
 }
 
 func (s *userService) ListUsers(ctx context.Context) ([]*model.User, error) {
-	return s.userRepo.FindAll(ctx)
+	users, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrUserNotFound)
+	}
+	return users, nil
 }
 
 func (s *userService) GetUser(ctx context.Context, idStr string) (*model.User, error) {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		return nil, errors.ErrInvalidUserID
+		return nil, errors.ErrInvalidUserID.WithCause(err)
 	}
 	user, err := s.userRepo.FindByID(ctx, uint(id))
-	if err == repository.ErrNotFound {
-		return nil, errors.ErrUserNotFound
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrUserNotFound)
 	}
-	return user, err
+	return user, nil
 }
 
-func (s *userService) CreateUser(ctx context.Context, name, email string) (*model.User, error) {
-	// 业务逻辑验证
-	if name == "" {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "用户名不能为空")
-	}
-	if email == "" {
-		return nil, errors.NewBusinessError(errors.CodeInvalidParam, "邮箱不能为空")
+func (s *userService) CreateUser(ctx context.Context, req *CreateUserRequest) (*model.User, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
 	user := &model.User{
-		Name:  name,
-		Email: email,
+		Name:   req.Name,
+		Email:  req.Email,
+		Status: "active",
 	}
 
-	return s.userRepo.Create(ctx, user)
+	var created *model.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := checkQuota(ctx, tenantLimits(ctx).MaxUsers, "tenant.user_limit_exceeded", s.userRepo.Count); err != nil {
+			return err
+		}
+		var err error
+		if created, err = s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, model.AuditActionCreate, created.ID, nil, created); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, event.UserCreated{User: *created})
+	})
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrUserNotFound)
+	}
+	return created, nil
 }
 
-func (s *userService) UpdateUser(ctx context.Context, idStr string, name, email string) (*model.User, error) {
+func (s *userService) UpdateUser(ctx context.Context, idStr string, req *UpdateUserRequest) (*model.User, error) {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		return nil, errors.ErrInvalidUserID
+		return nil, errors.ErrInvalidUserID.WithCause(err)
+	}
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
 	user := &model.User{
-		Name:  name,
-		Email: email,
+		Name:   req.Name,
+		Email:  req.Email,
+		Status: req.Status,
 	}
 
-	result, err := s.userRepo.Update(ctx, uint(id), user)
-	if err == repository.ErrNotFound {
-		return nil, errors.ErrUserNotFound
+	var result *model.User
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.userRepo.FindByID(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+		if result, err = s.userRepo.Update(ctx, uint(id), user); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, model.AuditActionUpdate, result.ID, before, result); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, event.UserUpdated{User: *result})
+	})
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrUserNotFound)
 	}
-	return result, err
+	return result, nil
 }
 
 func (s *userService) DeleteUser(ctx context.Context, idStr string) error {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		return errors.ErrInvalidUserID
+		return errors.ErrInvalidUserID.WithCause(err)
 	}
-	err = s.userRepo.Delete(ctx, uint(id))
-	if err == repository.ErrNotFound {
-		return errors.ErrUserNotFound
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.userRepo.FindByID(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+		if err := s.userRepo.Delete(ctx, uint(id)); err != nil {
+			return err
+		}
+		if err := s.recordAudit(ctx, model.AuditActionDelete, uint(id), before, nil); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, event.UserDeleted{UserID: uint(id)})
+	})
+	return translateRepoError(err, errors.ErrUserNotFound)
+}
+
+func (s *userService) ImportUsers(ctx context.Context, dec *bulk.Decoder, opts *ImportOptions) (*ImportResult, error) {
+	return importRows(ctx, s.tx, dec, opts, func(ctx context.Context, req *CreateUserRequest) error {
+		_, err := s.CreateUser(ctx, req)
+		return err
+	})
+}
+
+func (s *userService) BatchUsers(ctx context.Context, req *BatchRequest) (*BatchResult, error) {
+	return runBatch(ctx, s.tx, req, batchActions[CreateUserRequest, UpdateUserRequest]{
+		resource: "user",
+		create: func(ctx context.Context, req *CreateUserRequest) (interface{}, error) {
+			return s.CreateUser(ctx, req)
+		},
+		update: func(ctx context.Context, id string, req *UpdateUserRequest) (interface{}, error) {
+			return s.UpdateUser(ctx, id, req)
+		},
+		delete: s.DeleteUser,
+		find: func(ctx context.Context, id string) error {
+			_, err := s.GetUser(ctx, id)
+			return err
+		},
+	})
+}
+
+func (s *userService) ExportUsers(ctx context.Context, filter *UserFilter, enc *bulk.Encoder) (int, error) {
+	if err := validation.Struct(filter); err != nil {
+		return 0, err
+	}
+	users, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return 0, translateRepoError(err, errors.ErrUserNotFound)
 	}
-	return err
+
+	exported := 0
+	for _, user := range users {
+		if !filter.match(user) {
+			continue
+		}
+		if err := enc.Encode(user); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+	return exported, enc.Flush()
 }
 
+// recordAudit 记录用户变更的审计日志
+func (s *userService) recordAudit(ctx context.Context, action string, id uint, before, after *model.User) error {
+	return s.audit.Record(ctx, audit.Change{
+		Action:       action,
+		ResourceType: audit.ResourceUser,
+		ResourceID:   strconv.FormatUint(uint64(id), 10),
+		Before:       snapshot(before),
+		After:        snapshot(after),
+	})
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/pkg/errors"
+	"rich_go/pkg/validation"
+	whsign "rich_go/pkg/webhook"
+)
+
+// WebhookService webhook 订阅服务接口
+type WebhookService interface {
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	GetWebhook(ctx context.Context, idStr string) (*model.Webhook, error)
+	// CreateWebhook 创建订阅，返回值中的 Secret 只在此时可见
+	CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, idStr string, req *UpdateWebhookRequest) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, idStr string) error
+	// ListDeliveries 返回投递记录，status 为 dead 时即死信列表
+	ListDeliveries(ctx context.Context, idStr string, status string) ([]*model.WebhookDelivery, error)
+	// Redeliver 立即重新投递，重置本轮尝试次数
+	Redeliver(ctx context.Context, idStr, deliveryIDStr string) (*model.WebhookDelivery, error)
+	// PurgeDeliveries 清理投递成功超过 retention 的记录，返回清理数量
+	PurgeDeliveries(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// CreateWebhookRequest 创建 webhook 请求
+// 合作方只能订阅优惠券事件，用户事件包含邮箱、姓名等个人信息，不对外投递
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,http_url"`
+	EventTypes []string `json:"eventTypes" validate:"required,min=1,dive,oneof=coupon.created coupon.updated coupon.deleted"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16"` // 为空时自动生成
+}
+
+// UpdateWebhookRequest 更新 webhook 请求，未提供的字段表示不修改
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" validate:"omitempty,http_url"`
+	EventTypes []string `json:"eventTypes" validate:"omitempty,min=1,dive,oneof=coupon.created coupon.updated coupon.deleted"`
+	Active     *bool    `json:"active"`
+}
+
+// WebhookOptions webhook 订阅服务参数
+type WebhookOptions struct {
+	// AllowPrivateTargets 允许回调 URL 指向环回、私有和链路本地地址，仅用于本地开发和测试
+	AllowPrivateTargets bool
+}
+
+// webhookService webhook 订阅服务实现
+type webhookService struct {
+	webhookRepo  repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	opts         WebhookOptions
+}
+
+// NewWebhookService 创建 webhook 订阅服务实例
+func NewWebhookService(webhookRepo repository.WebhookRepository, deliveryRepo repository.WebhookDeliveryRepository, opts WebhookOptions) WebhookService {
+	return &webhookService{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		opts:         opts,
+	}
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	webhooks, err := s.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrWebhookNotFound)
+	}
+	return webhooks, nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, idStr string) (*model.Webhook, error) {
+	id, err := parseWebhookID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrWebhookNotFound)
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*model.Webhook, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = newWebhookSecret()
+	}
+	webhook := &model.Webhook{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  time.Now(),
+	}
+
+	created, err := s.webhookRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrWebhookNotFound)
+	}
+	return created, nil
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, idStr string, req *UpdateWebhookRequest) (*model.Webhook, error) {
+	id, err := parseWebhookID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	if req.URL != "" {
+		if err := s.checkURL(ctx, req.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrWebhookNotFound)
+	}
+	if req.URL != "" {
+		webhook.URL = req.URL
+	}
+	if len(req.EventTypes) > 0 {
+		webhook.EventTypes = req.EventTypes
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	result, err := s.webhookRepo.Update(ctx, id, webhook)
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrWebhookNotFound)
+	}
+	return result, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, idStr string) error {
+	id, err := parseWebhookID(idStr)
+	if err != nil {
+		return err
+	}
+	err = s.webhookRepo.Delete(ctx, id)
+	return translateRepoError(err, errors.ErrWebhookNotFound)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, idStr string, status string) ([]*model.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case "", model.DeliveryPending, model.DeliveryDelivered, model.DeliveryDead, model.DeliverySkipped:
+	default:
+		return nil, errors.NewValidationError(errors.NewFieldError("status", "oneof",
+			model.DeliveryPending+" "+model.DeliveryDelivered+" "+model.DeliveryDead+" "+model.DeliverySkipped))
+	}
+
+	deliveries, err := s.deliveryRepo.FindByWebhook(ctx, webhook.ID, status)
+	if err != nil {
+		return nil, errors.Internal(err)
+	}
+	return deliveries, nil
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, idStr, deliveryIDStr string) (*model.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(ctx, idStr)
+	if err != nil {
+		return nil, err
+	}
+	deliveryID, err := strconv.ParseUint(deliveryIDStr, 10, 32)
+	if err != nil {
+		return nil, errors.ErrInvalidDeliveryID.WithCause(err)
+	}
+
+	delivery, err := s.deliveryRepo.FindByID(ctx, uint(deliveryID))
+	if err != nil {
+		return nil, translateRepoError(err, errors.ErrDeliveryNotFound)
+	}
+	if delivery.WebhookID != webhook.ID || delivery.TenantID != webhook.TenantID {
+		return nil, errors.ErrDeliveryNotFound
+	}
+
+	delivery.Status = model.DeliveryPending
+	delivery.AttemptCount = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := s.deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, translateRepoError(err, errors.ErrDeliveryNotFound)
+	}
+	return delivery, nil
+}
+
+func (s *webhookService) PurgeDeliveries(ctx context.Context, retention time.Duration) (int, error) {
+	deleted, err := s.deliveryRepo.DeleteDeliveredBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, errors.Internal(err)
+	}
+	return deleted, nil
+}
+
+// checkURL 拒绝指向非公网地址的回调 URL，防止借投递请求探测内网
+func (s *webhookService) checkURL(ctx context.Context, rawURL string) error {
+	if s.opts.AllowPrivateTargets {
+		return nil
+	}
+	if err := whsign.CheckURL(ctx, rawURL); err != nil {
+		return errors.NewValidationError(errors.NewFieldError("url", "public_url", "")).WithCause(err)
+	}
+	return nil
+}
+
+func parseWebhookID(idStr string) (uint, error) {
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, errors.ErrInvalidWebhookID.WithCause(err)
+	}
+	return uint(id), nil
+}
+
+// newWebhookSecret 生成随机签名密钥
+func newWebhookSecret() string {
+	var b [24]byte
+	_, _ = rand.Read(b[:])
+	return "whsec_" + hex.EncodeToString(b[:])
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+	"rich_go/pkg/errors"
+)
+
+func TestCreateWebhookValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       CreateWebhookRequest
+		wantField string
+	}{
+		{"公网地址", CreateWebhookRequest{URL: "https://93.184.215.14/hook", EventTypes: []string{"coupon.created"}}, ""},
+		{"订阅用户事件", CreateWebhookRequest{URL: "https://93.184.215.14/hook", EventTypes: []string{"user.created"}}, "eventTypes[0]"},
+		{"非 http 协议", CreateWebhookRequest{URL: "ftp://93.184.215.14/hook", EventTypes: []string{"coupon.created"}}, "url"},
+		{"环回地址", CreateWebhookRequest{URL: "http://127.0.0.1:8080/hook", EventTypes: []string{"coupon.created"}}, "url"},
+		{"私有地址", CreateWebhookRequest{URL: "http://192.168.0.10/hook", EventTypes: []string{"coupon.created"}}, "url"},
+		{"元数据地址", CreateWebhookRequest{URL: "http://169.254.169.254/latest/meta-data/", EventTypes: []string{"coupon.created"}}, "url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), "acme")
+			svc := NewWebhookService(repository.NewWebhookRepository(), repository.NewWebhookDeliveryRepository(), WebhookOptions{})
+
+			_, err := svc.CreateWebhook(ctx, &tt.req)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("CreateWebhook: %v", err)
+				}
+				return
+			}
+			be, ok := errors.AsBusinessError(err)
+			if !ok || be.Code != errors.CodeInvalidParam || len(be.Fields) != 1 || be.Fields[0].Field != tt.wantField {
+				t.Fatalf("CreateWebhook 错误为 %v, want %s 校验错误", err, tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Package tenant 多租户上下文
+// 租户由 HTTP 中间件和 gRPC 拦截器解析后写入 context，仓储按 context 中的租户隔离数据
+package tenant
+
+import (
+	"context"
+
+	"rich_go/internal/model"
+)
+
+type tenantKey struct{}
+
+// WithTenant 将租户写入 context
+func WithTenant(ctx context.Context, t *model.Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// WithID 将租户 ID 写入 context，用于后台任务等只需按租户隔离数据、不需要租户配置的场景
+func WithID(ctx context.Context, id string) context.Context {
+	return WithTenant(ctx, &model.Tenant{ID: id})
+}
+
+// FromContext 从 context 中获取租户
+func FromContext(ctx context.Context) (*model.Tenant, bool) {
+	t, ok := ctx.Value(tenantKey{}).(*model.Tenant)
+	return t, ok && t != nil
+}
+
+// ID 返回 context 中的租户 ID，未解析租户时为空
+func ID(ctx context.Context) string {
+	if t, ok := FromContext(ctx); ok {
+		return t.ID
+	}
+	return ""
+}
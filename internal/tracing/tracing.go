@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"rich_go/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName 本项目使用的 tracer 名称
+const InstrumentationName = "rich_go"
+
+// ShutdownFunc 关闭追踪并刷新未导出的 span
+type ShutdownFunc func(ctx context.Context) error
+
+// Init 根据配置初始化全局 TracerProvider 和 W3C 传播器
+// 未启用时仍然设置传播器，Tracer() 返回 no-op 实现
+func Init(cfg config.TracingConfig, appVersion string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !cfg.Enabled || cfg.Exporter == "none" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	writer, closeWriter, err := newWriter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+	if err != nil {
+		closeWriter()
+		return nil, fmt.Errorf("创建 trace exporter 失败: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(appVersion),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		defer closeWriter()
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// newWriter 根据 exporter 类型创建输出目标
+func newWriter(cfg config.TracingConfig) (io.Writer, func(), error) {
+	if cfg.Exporter != "file" {
+		return os.Stdout, func() {}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.FilePath), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("创建 trace 输出目录失败: %w", err)
+	}
+	f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("打开 trace 输出文件失败: %w", err)
+	}
+	return f, func() { _ = f.Close() }, nil
+}
+
+// Tracer 返回项目统一的 tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Start 开启一个内部 span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End 根据 err 设置 span 状态并结束 span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID 返回 ctx 中的 trace ID，不存在时返回空字符串
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
@@ -0,0 +1,240 @@
+// Package webhook webhook 投递
+// Dispatcher 作为事件总线的同步订阅者为匹配的订阅创建投递记录，再由后台任务签名并发送，
+// 失败按指数退避重试，超过重试次数后进入死信列表，可通过接口手动重新投递
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"rich_go/internal/event"
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/tenant"
+	whsign "rich_go/pkg/webhook"
+)
+
+// userAgent 投递请求的 User-Agent
+const userAgent = "rich_go-webhook/1.0"
+
+// Options 投递参数
+type Options struct {
+	Interval    time.Duration // 轮询间隔
+	BatchSize   int           // 每次最多发送的投递数
+	MaxAttempts int           // 每轮最大尝试次数，超过后进入死信列表
+	MaxBackoff  time.Duration // 失败重试的最大间隔
+	Timeout     time.Duration // 单次请求超时
+	// AllowPrivateTargets 允许连接环回、私有和链路本地地址，仅用于本地开发和测试
+	AllowPrivateTargets bool
+}
+
+// errInactive webhook 已停用，投递不再发送
+var errInactive = errors.New("webhook 已停用")
+
+// Dispatcher webhook 投递器
+type Dispatcher struct {
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	client     *http.Client
+	opts       Options
+}
+
+// NewDispatcher 创建投递器，client 为 nil 时使用按 Timeout 配置的默认客户端
+// 默认客户端只连接公网地址，且不跟随重定向，避免投递被用于探测内网
+func NewDispatcher(webhooks repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository, client *http.Client, opts Options) *Dispatcher {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 10 * time.Minute
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if client == nil {
+		client = whsign.NewClient(opts.Timeout)
+		if opts.AllowPrivateTargets {
+			// 使用默认 Transport 放开地址限制，仍不跟随重定向
+			client.Transport = nil
+		}
+	}
+	return &Dispatcher{webhooks: webhooks, deliveries: deliveries, client: client, opts: opts}
+}
+
+// Enqueue 为订阅该事件的 webhook 创建投递记录，作为事件总线的同步订阅者使用
+// 事件重复到达时按 (webhook, 事件 ID) 去重，只投递给事件所属租户的订阅
+func (d *Dispatcher) Enqueue(ctx context.Context, env event.Envelope) error {
+	if env.Tenant == "" {
+		// 不属于任何租户的事件没有订阅方
+		return nil
+	}
+	hooks, err := d.webhooks.FindByEventType(tenant.WithID(ctx, env.Tenant), env.Type)
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, hook := range hooks {
+		_, err := d.deliveries.Create(ctx, &model.WebhookDelivery{
+			WebhookID:     hook.ID,
+			TenantID:      hook.TenantID,
+			EventID:       env.ID,
+			EventType:     env.Type,
+			Payload:       body,
+			Status:        model.DeliveryPending,
+			NextAttemptAt: now,
+		})
+		if err != nil && !errors.Is(err, repository.ErrAlreadyExists) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run 按间隔发送到期的投递，ctx 取消后返回
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := d.Flush(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("读取 webhook 投递记录失败: %v", err)
+			}
+		}
+	}
+}
+
+// Flush 发送一批到期的投递，返回成功的数量
+func (d *Dispatcher) Flush(ctx context.Context) (int, error) {
+	due, err := d.deliveries.FindDue(ctx, time.Now(), d.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+		if d.attempt(ctx, delivery) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// attempt 发送一次并更新投递记录
+func (d *Dispatcher) attempt(ctx context.Context, delivery *model.WebhookDelivery) bool {
+	start := time.Now()
+	statusCode, err := d.send(ctx, delivery)
+	if errors.Is(err, errInactive) {
+		delivery.Status = model.DeliverySkipped
+		if uerr := d.deliveries.Update(ctx, delivery); uerr != nil {
+			log.Printf("更新 webhook 投递记录 %d 失败: %v", delivery.ID, uerr)
+		}
+		return false
+	}
+	delivery.Attempts = append(delivery.Attempts, model.DeliveryAttempt{
+		At:         start,
+		StatusCode: statusCode,
+		Error:      errorString(err),
+		DurationMs: time.Since(start).Milliseconds(),
+	})
+	delivery.AttemptCount++
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		delivery.Status = model.DeliveryDelivered
+		delivery.DeliveredAt = &now
+	case delivery.AttemptCount >= d.opts.MaxAttempts || errors.Is(err, repository.ErrNotFound):
+		delivery.Status = model.DeliveryDead
+		log.Printf("webhook 投递 %d（%s）失败 %d 次，已移入死信列表: %v", delivery.ID, delivery.EventType, delivery.AttemptCount, err)
+	default:
+		delivery.NextAttemptAt = time.Now().Add(d.backoff(delivery.AttemptCount))
+	}
+
+	if uerr := d.deliveries.Update(ctx, delivery); uerr != nil {
+		log.Printf("更新 webhook 投递记录 %d 失败: %v", delivery.ID, uerr)
+	}
+	return err == nil
+}
+
+// send 签名并发送投递请求，接收方返回 2xx 视为成功
+// 后台任务的 context 不含租户，按投递记录的租户读取 webhook；每次发送前重新读取，已停用时返回 errInactive
+func (d *Dispatcher) send(ctx context.Context, delivery *model.WebhookDelivery) (int, error) {
+	hook, err := d.webhooks.FindByID(tenant.WithID(ctx, delivery.TenantID), delivery.WebhookID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, fmt.Errorf("webhook %d 已删除: %w", delivery.WebhookID, err)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("读取 webhook %d 失败: %w", delivery.WebhookID, err)
+	}
+	if !hook.Active {
+		return 0, errInactive
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(whsign.HeaderDelivery, fmt.Sprint(delivery.ID))
+	req.Header.Set(whsign.HeaderEvent, delivery.EventType)
+	whsign.SetHeaders(req.Header, hook.Secret, time.Now(), delivery.Payload)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("接收方返回 %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff 指数退避并加入随机抖动，不超过 MaxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.opts.Interval << min(attempts-1, 20)
+	if delay <= 0 || delay > d.opts.MaxBackoff {
+		delay = d.opts.MaxBackoff
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,326 @@
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rich_go/internal/event"
+	"rich_go/internal/model"
+	"rich_go/internal/repository"
+	"rich_go/internal/service"
+	"rich_go/internal/tenant"
+	"rich_go/internal/webhook"
+	whsign "rich_go/pkg/webhook"
+)
+
+const testSecret = "0123456789abcdef"
+
+// receiver 本地接收方，按 statuses 依次返回状态码，用完后返回最后一个
+type receiver struct {
+	*httptest.Server
+	mu         sync.Mutex
+	statuses   []int
+	requests   []*http.Request
+	verifyErrs []error
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, req)
+		r.verifyErrs = append(r.verifyErrs, whsign.Verify(testSecret, req.Header, body, 0))
+		status := r.statuses[min(len(r.requests), len(r.statuses))-1]
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) setStatuses(statuses ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses = statuses
+	r.requests = nil
+	r.verifyErrs = nil
+}
+
+// request 返回第 i 个请求及其签名校验结果
+func (r *receiver) request(i int) (*http.Request, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests[i], r.verifyErrs[i]
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// fixture 订阅了 coupon.created 的 webhook 及其投递器
+type fixture struct {
+	ctx        context.Context
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	dispatcher *webhook.Dispatcher
+	hook       *model.Webhook
+}
+
+func newFixture(t *testing.T, url string, opts webhook.Options) *fixture {
+	t.Helper()
+	f := &fixture{
+		ctx:        tenant.WithID(context.Background(), "acme"),
+		webhooks:   repository.NewWebhookRepository(),
+		deliveries: repository.NewWebhookDeliveryRepository(),
+	}
+	hook, err := f.webhooks.Create(f.ctx, &model.Webhook{URL: url, EventTypes: []string{"coupon.created"}, Secret: testSecret, Active: true})
+	if err != nil {
+		t.Fatalf("创建 webhook: %v", err)
+	}
+	f.hook = hook
+	opts.AllowPrivateTargets = true // 接收方是本地测试服务器
+	f.dispatcher = webhook.NewDispatcher(f.webhooks, f.deliveries, nil, opts)
+	return f
+}
+
+// enqueue 发布一个属于 acme 的 coupon.created 事件，返回投递记录
+func (f *fixture) enqueue(t *testing.T) *model.WebhookDelivery {
+	t.Helper()
+	env, err := event.NewEnvelope(event.CouponCreated{Coupon: model.Coupon{ID: 1, Name: "summer"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.Tenant = "acme"
+	// 后台任务的 context 不含租户
+	if err := f.dispatcher.Enqueue(context.Background(), env); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	deliveries, _ := f.deliveries.FindByWebhook(f.ctx, f.hook.ID, "")
+	if len(deliveries) != 1 {
+		t.Fatalf("投递记录 %d 条, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func (f *fixture) delivery(t *testing.T, id uint) *model.WebhookDelivery {
+	t.Helper()
+	d, err := f.deliveries.FindByID(f.ctx, id)
+	if err != nil {
+		t.Fatalf("读取投递记录 %d: %v", id, err)
+	}
+	return d
+}
+
+// flushUntil 反复发送到期的投递，直到投递记录状态变为 status
+func (f *fixture) flushUntil(t *testing.T, id uint, status string) *model.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := f.dispatcher.Flush(context.Background()); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+		d := f.delivery(t, id)
+		if d.Status == status {
+			return d
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("投递记录状态为 %s（%d 次尝试）, want %s", d.Status, d.AttemptCount, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcherSignsRequests(t *testing.T) {
+	recv := newReceiver(t, http.StatusOK)
+	f := newFixture(t, recv.URL, webhook.Options{})
+	d := f.enqueue(t)
+
+	if n, err := f.dispatcher.Flush(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Flush = %d, %v, want 1", n, err)
+	}
+	if recv.count() != 1 {
+		t.Fatalf("接收方收到 %d 个请求, want 1", recv.count())
+	}
+	req, err := recv.request(0)
+	if err != nil {
+		t.Errorf("签名校验失败: %v", err)
+	}
+	if got := req.Header.Get(whsign.HeaderEvent); got != "coupon.created" {
+		t.Errorf("%s = %q, want coupon.created", whsign.HeaderEvent, got)
+	}
+	if got := req.Header.Get(whsign.HeaderDelivery); got != fmt.Sprint(d.ID) {
+		t.Errorf("%s = %q, want %d", whsign.HeaderDelivery, got, d.ID)
+	}
+
+	d = f.delivery(t, d.ID)
+	if d.Status != model.DeliveryDelivered || d.DeliveredAt == nil || len(d.Attempts) != 1 || d.Attempts[0].StatusCode != http.StatusOK {
+		t.Errorf("投递记录为 %+v, want 已投递且有 1 次 200 的尝试", d)
+	}
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	recv := newReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK)
+	interval := 20 * time.Millisecond
+	f := newFixture(t, recv.URL, webhook.Options{Interval: interval, MaxAttempts: 5})
+	d := f.enqueue(t)
+
+	before := time.Now()
+	if n, _ := f.dispatcher.Flush(context.Background()); n != 0 {
+		t.Fatalf("接收方返回 500 时 Flush = %d, want 0", n)
+	}
+	d = f.delivery(t, d.ID)
+	// 第 1 次失败后等待 [interval/2, interval]
+	if wait := d.NextAttemptAt.Sub(before); d.Status != model.DeliveryPending || wait < interval/2 || wait > interval+10*time.Millisecond {
+		t.Errorf("第 1 次失败后状态 %s、等待 %v, want pending、约 %v", d.Status, wait, interval)
+	}
+	// 未到重试时间不发送
+	f.dispatcher.Flush(context.Background())
+	if recv.count() != 1 {
+		t.Errorf("未到重试时间时接收方收到 %d 个请求, want 1", recv.count())
+	}
+
+	d = f.flushUntil(t, d.ID, model.DeliveryDelivered)
+	if d.AttemptCount != 3 || len(d.Attempts) != 3 {
+		t.Fatalf("尝试 %d 次, want 3", d.AttemptCount)
+	}
+	wantCodes := []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK}
+	for i, a := range d.Attempts {
+		if a.StatusCode != wantCodes[i] {
+			t.Errorf("attempts[%d].statusCode = %d, want %d", i, a.StatusCode, wantCodes[i])
+		}
+	}
+	// 第 2 次重试的间隔按指数增长
+	if gap := d.Attempts[2].At.Sub(d.Attempts[1].At); gap < interval {
+		t.Errorf("第 2 次重试间隔 %v, want 至少 %v", gap, interval)
+	}
+}
+
+func TestDispatcherDeadLettersAndRedelivers(t *testing.T) {
+	recv := newReceiver(t, http.StatusInternalServerError)
+	f := newFixture(t, recv.URL, webhook.Options{Interval: time.Millisecond, MaxAttempts: 3})
+	d := f.enqueue(t)
+
+	d = f.flushUntil(t, d.ID, model.DeliveryDead)
+	if d.AttemptCount != 3 || recv.count() != 3 {
+		t.Errorf("进入死信列表前尝试 %d 次、接收方收到 %d 个请求, want 3", d.AttemptCount, recv.count())
+	}
+	// 死信不再自动重试
+	time.Sleep(5 * time.Millisecond)
+	f.dispatcher.Flush(context.Background())
+	if recv.count() != 3 {
+		t.Errorf("死信被再次发送，接收方收到 %d 个请求", recv.count())
+	}
+	svc := service.NewWebhookService(f.webhooks, f.deliveries, service.WebhookOptions{})
+	if dead, _ := svc.ListDeliveries(f.ctx, fmt.Sprint(f.hook.ID), model.DeliveryDead); len(dead) != 1 {
+		t.Errorf("死信列表 %d 条, want 1", len(dead))
+	}
+
+	// 接收方恢复后手动重新投递
+	recv.setStatuses(http.StatusOK)
+	redelivered, err := svc.Redeliver(f.ctx, fmt.Sprint(f.hook.ID), fmt.Sprint(d.ID))
+	if err != nil {
+		t.Fatalf("Redeliver: %v", err)
+	}
+	if redelivered.Status != model.DeliveryPending || redelivered.AttemptCount != 0 {
+		t.Errorf("重新投递后状态 %s、尝试次数 %d, want pending、0", redelivered.Status, redelivered.AttemptCount)
+	}
+	d = f.flushUntil(t, d.ID, model.DeliveryDelivered)
+	if len(d.Attempts) != 4 {
+		t.Errorf("保留 %d 次尝试记录, want 4", len(d.Attempts))
+	}
+	if req, _ := recv.request(0); req.Header.Get(whsign.HeaderDelivery) != fmt.Sprint(d.ID) {
+		got := req.Header.Get(whsign.HeaderDelivery)
+		t.Errorf("重新投递的 %s = %q, want 不变的 %d", whsign.HeaderDelivery, got, d.ID)
+	}
+
+	// 其他租户不能重新投递
+	other := tenant.WithID(context.Background(), "globex")
+	if _, err := svc.Redeliver(other, fmt.Sprint(f.hook.ID), fmt.Sprint(d.ID)); err == nil {
+		t.Error("其他租户重新投递成功, want 错误")
+	}
+}
+
+func TestDispatcherDeadLettersDeletedWebhook(t *testing.T) {
+	recv := newReceiver(t, http.StatusOK)
+	f := newFixture(t, recv.URL, webhook.Options{})
+	d := f.enqueue(t)
+	if err := f.webhooks.Delete(f.ctx, f.hook.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	f.dispatcher.Flush(context.Background())
+	d = f.delivery(t, d.ID)
+	if d.Status != model.DeliveryDead || recv.count() != 0 {
+		t.Errorf("webhook 删除后状态 %s、接收方收到 %d 个请求, want dead、0", d.Status, recv.count())
+	}
+	if len(d.Attempts) != 1 || !strings.Contains(d.Attempts[0].Error, "已删除") {
+		t.Errorf("尝试记录为 %+v, want 注明 webhook 已删除", d.Attempts)
+	}
+}
+
+func TestDispatcherSkipsInactiveWebhook(t *testing.T) {
+	recv := newReceiver(t, http.StatusOK)
+	f := newFixture(t, recv.URL, webhook.Options{})
+	d := f.enqueue(t)
+	f.hook.Active = false
+	if _, err := f.webhooks.Update(f.ctx, f.hook.ID, f.hook); err != nil {
+		t.Fatal(err)
+	}
+
+	f.dispatcher.Flush(context.Background())
+	d = f.delivery(t, d.ID)
+	if d.Status != model.DeliverySkipped || recv.count() != 0 || len(d.Attempts) != 0 {
+		t.Errorf("webhook 停用后状态 %s、%d 次尝试、接收方收到 %d 个请求, want skipped、0、0", d.Status, len(d.Attempts), recv.count())
+	}
+
+	// 停用期间手动重新投递同样跳过
+	svc := service.NewWebhookService(f.webhooks, f.deliveries, service.WebhookOptions{})
+	if _, err := svc.Redeliver(f.ctx, fmt.Sprint(f.hook.ID), fmt.Sprint(d.ID)); err != nil {
+		t.Fatalf("Redeliver: %v", err)
+	}
+	f.dispatcher.Flush(context.Background())
+	if d = f.delivery(t, d.ID); d.Status != model.DeliverySkipped || recv.count() != 0 {
+		t.Errorf("重新投递后状态 %s、接收方收到 %d 个请求, want skipped、0", d.Status, recv.count())
+	}
+}
+
+func TestDispatcherDoesNotFollowRedirects(t *testing.T) {
+	target := newReceiver(t, http.StatusOK)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	t.Cleanup(redirect.Close)
+	f := newFixture(t, redirect.URL, webhook.Options{MaxAttempts: 1})
+	d := f.enqueue(t)
+
+	f.dispatcher.Flush(context.Background())
+	d = f.delivery(t, d.ID)
+	if target.count() != 0 || len(d.Attempts) != 1 || d.Attempts[0].StatusCode != http.StatusFound {
+		t.Errorf("重定向目标收到 %d 个请求、尝试记录 %+v, want 0 个请求、记录 302", target.count(), d.Attempts)
+	}
+}
+
+func TestDispatcherRefusesPrivateTargets(t *testing.T) {
+	recv := newReceiver(t, http.StatusOK)
+	f := newFixture(t, recv.URL, webhook.Options{})
+	// 默认客户端只连接公网地址
+	f.dispatcher = webhook.NewDispatcher(f.webhooks, f.deliveries, nil, webhook.Options{MaxAttempts: 1})
+	d := f.enqueue(t)
+
+	f.dispatcher.Flush(context.Background())
+	d = f.delivery(t, d.ID)
+	if recv.count() != 0 || d.Status != model.DeliveryDead {
+		t.Fatalf("接收方收到 %d 个请求、状态 %s, want 0、dead", recv.count(), d.Status)
+	}
+	if len(d.Attempts) != 1 || d.Attempts[0].StatusCode != 0 || !strings.Contains(d.Attempts[0].Error, "public") {
+		t.Errorf("尝试记录为 %+v, want 连接被拒绝且没有状态码", d.Attempts)
+	}
+}
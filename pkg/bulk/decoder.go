@@ -0,0 +1,238 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// utf8BOM Excel 导出的 CSV 常带有的字节顺序标记
+const utf8BOM = "\ufeff"
+
+// RowError 单行数据错误，不影响后续行的读取
+type RowError struct {
+	Line  int    // 行号，从 1 开始，CSV 表头为第 1 行
+	Field string // 出错字段的 JSON 名称，无法定位到字段时为空
+	Type  string // 字段期望的 JSON 类型：string、number、boolean、array、object
+	Err   error
+}
+
+func (e *RowError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("第 %d 行字段 %s 应为 %s: %v", e.Line, e.Field, e.Type, e.Err)
+	}
+	return fmt.Sprintf("第 %d 行: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Decoder 逐行读取 CSV 或 NDJSON
+type Decoder struct {
+	format Format
+	line   int
+
+	csv    *csv.Reader
+	header []string
+
+	ndjson *bufio.Reader
+}
+
+// NewDecoder 创建读取 r 的解码器
+func NewDecoder(r io.Reader, format Format) *Decoder {
+	d := &Decoder{format: format}
+	if format == CSV {
+		d.csv = csv.NewReader(r)
+		d.csv.FieldsPerRecord = -1
+		d.csv.TrimLeadingSpace = true
+		d.csv.ReuseRecord = true
+	} else {
+		d.ndjson = bufio.NewReader(r)
+	}
+	return d
+}
+
+// Next 读取下一行到 v（结构体指针），返回该行行号
+// 单行错误返回 *RowError，可继续调用 Next；读取完毕返回 io.EOF；其他错误表示数据无法继续读取
+func (d *Decoder) Next(v interface{}) (int, error) {
+	if d.format == CSV {
+		return d.nextCSV(v)
+	}
+	return d.nextNDJSON(v)
+}
+
+func (d *Decoder) nextNDJSON(v interface{}) (int, error) {
+	for {
+		data, err := d.ndjson.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return d.line, err
+		}
+		d.line++
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return d.line, jsonRowError(d.line, err)
+		}
+		return d.line, nil
+	}
+}
+
+func (d *Decoder) nextCSV(v interface{}) (int, error) {
+	if d.header == nil {
+		if err := d.readHeader(); err != nil {
+			return d.line, err
+		}
+	}
+
+	record, err := d.csv.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			d.line = parseErr.Line
+		}
+		return d.line, err
+	}
+	d.line, _ = d.csv.FieldPos(0)
+	if len(record) != len(d.header) {
+		return d.line, &RowError{Line: d.line, Err: fmt.Errorf("列数为 %d，表头为 %d 列", len(record), len(d.header))}
+	}
+
+	obj, rowErr := cellsToJSON(d.header, record, reflect.TypeOf(v))
+	if rowErr != nil {
+		rowErr.Line = d.line
+		return d.line, rowErr
+	}
+	if err := json.Unmarshal(obj, v); err != nil {
+		return d.line, jsonRowError(d.line, err)
+	}
+	return d.line, nil
+}
+
+// readHeader 读取表头，列名为 JSON 字段名
+func (d *Decoder) readHeader() error {
+	record, err := d.csv.Read()
+	if errors.Is(err, io.EOF) {
+		return errors.New("bulk: CSV 缺少表头")
+	}
+	if err != nil {
+		return err
+	}
+	d.line = 1
+	d.header = make([]string, len(record))
+	for i, name := range record {
+		if i == 0 {
+			name = strings.TrimPrefix(name, utf8BOM)
+		}
+		d.header[i] = strings.TrimSpace(name)
+	}
+	return nil
+}
+
+// cellsToJSON 按目标结构体的字段类型将 CSV 单元格转换为 JSON 对象
+// 空单元格视为未提供，与表头不对应任何字段的列被忽略，与 JSON 请求中的未知字段一致
+func cellsToJSON(header, record []string, target reflect.Type) ([]byte, *RowError) {
+	kinds := fieldKinds(target)
+	obj := make(map[string]json.RawMessage, len(header))
+	for i, name := range header {
+		kind, ok := kinds[name]
+		cell := record[i]
+		if !ok || cell == "" {
+			continue
+		}
+		switch kind {
+		case "string":
+			obj[name], _ = json.Marshal(cell)
+		case "number":
+			if _, err := strconv.ParseFloat(cell, 64); err != nil {
+				return nil, &RowError{Field: name, Type: kind, Err: err}
+			}
+			obj[name] = json.RawMessage(cell)
+		case "boolean":
+			b, err := strconv.ParseBool(cell)
+			if err != nil {
+				return nil, &RowError{Field: name, Type: kind, Err: err}
+			}
+			obj[name] = json.RawMessage(strconv.FormatBool(b))
+		default:
+			if !json.Valid([]byte(cell)) {
+				return nil, &RowError{Field: name, Type: kind, Err: errors.New("不是有效的 JSON")}
+			}
+			obj[name] = json.RawMessage(cell)
+		}
+	}
+	data, _ := json.Marshal(obj)
+	return data, nil
+}
+
+// fieldKinds 返回结构体各 JSON 字段对应的 JSON 类型
+func fieldKinds(t reflect.Type) map[string]string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	kinds := make(map[string]string)
+	if t.Kind() != reflect.Struct {
+		return kinds
+	}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		if name := jsonName(field); name != "" {
+			kinds[name] = jsonKind(field.Type)
+		}
+	}
+	return kinds
+}
+
+// jsonRowError 将 JSON 解析错误转换为行错误，类型不匹配时定位到字段
+func jsonRowError(line int, err error) *RowError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &RowError{Line: line, Field: typeErr.Field, Type: jsonKind(typeErr.Type), Err: err}
+	}
+	return &RowError{Line: line, Err: err}
+}
+
+// jsonName 返回字段的 JSON 名称，json:"-" 的字段返回空
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+// jsonKind 返回 Go 类型对应的 JSON 类型名称
+func jsonKind(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
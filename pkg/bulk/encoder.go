@@ -0,0 +1,141 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// flushEvery 每写入多少行将缓冲区刷新到底层 Writer，使 HTTP 响应可以边生成边发送
+const flushEvery = 100
+
+// flusher 支持刷新的底层 Writer，例如 http.ResponseWriter
+type flusher interface {
+	Flush()
+}
+
+// Encoder 逐行写入 CSV 或 NDJSON
+type Encoder struct {
+	format  Format
+	w       io.Writer
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	columns []string
+	header  bool
+	rows    int
+}
+
+// NewEncoder 创建写入 w 的编码器，sample 为行类型的零值，CSV 的列为其 JSON 字段名
+func NewEncoder(w io.Writer, format Format, sample interface{}) *Encoder {
+	buf := bufio.NewWriter(w)
+	e := &Encoder{format: format, w: w, buf: buf}
+	if format == CSV {
+		e.csv = csv.NewWriter(buf)
+		e.columns = columns(reflect.TypeOf(sample))
+	}
+	return e
+}
+
+// Encode 写入一行
+func (e *Encoder) Encode(v interface{}) error {
+	if e.format == CSV {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+		record, err := jsonToCells(e.columns, v)
+		if err != nil {
+			return err
+		}
+		if err := e.csv.Write(record); err != nil {
+			return err
+		}
+	} else {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := e.buf.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+
+	e.rows++
+	if e.rows%flushEvery == 0 {
+		return e.Flush()
+	}
+	return nil
+}
+
+// Flush 将已写入的行刷新到底层 Writer，CSV 没有数据行时也会写出表头
+func (e *Encoder) Flush() error {
+	if e.format == CSV {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	if f, ok := e.w.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (e *Encoder) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.csv.Write(e.columns)
+}
+
+// jsonToCells 将一行数据按列转换为 CSV 单元格，字符串不带引号，null 为空
+func jsonToCells(columns []string, v interface{}) ([]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(columns))
+	for i, name := range columns {
+		raw, ok := obj[name]
+		switch {
+		case !ok || string(raw) == "null":
+		case len(raw) > 0 && raw[0] == '"':
+			_ = json.Unmarshal(raw, &record[i])
+		default:
+			record[i] = string(raw)
+		}
+	}
+	return record, nil
+}
+
+// columns 返回结构体的 JSON 字段名，按字段声明顺序
+func columns(t reflect.Type) []string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		if name := jsonName(field); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+// Package bulk CSV 与 NDJSON 的流式读写，用于批量导入导出
+//
+// 两种格式都以结构体的 JSON 字段名作为列名：CSV 首行为表头，单元格按目标字段类型转换；
+// NDJSON 每行一个 JSON 对象。读取时逐行解析，单行错误以 *RowError 返回，调用方可继续读取下一行
+package bulk
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Format 数据格式
+type Format string
+
+const (
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+)
+
+// MediaTypes 支持的 MIME 类型，用于接口描述
+var MediaTypes = []string{"text/csv", "application/x-ndjson"}
+
+// ContentType 返回格式对应的 MIME 类型
+func (f Format) ContentType() string {
+	if f == CSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// ParseFormat 解析格式名称 csv 或 ndjson
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case CSV, NDJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("bulk: 不支持的格式 %q", name)
+	}
+}
+
+// FormatFromContentType 根据 Content-Type 或 Accept 判断格式，无法识别时返回 false
+func FormatFromContentType(contentType string) (Format, bool) {
+	for _, part := range strings.Split(contentType, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return CSV, true
+		case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/jsonlines":
+			return NDJSON, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,24 @@
+// Package cache 键值缓存后端
+//
+// Backend 的语义与 Redis 的 GET、SET EX、DEL 一致，值为序列化后的字节，
+// 进程内实现为带过期时间的 LRU，后续可按同一接口接入 Redis
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss 键不存在或已过期
+var ErrMiss = errors.New("cache: miss")
+
+// Backend 缓存后端
+type Backend interface {
+	// Get 返回键对应的值，不存在或已过期时返回 ErrMiss
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set 写入键值，ttl 后过期
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete 删除键，键不存在时不返回错误
+	Delete(ctx context.Context, keys ...string) error
+}
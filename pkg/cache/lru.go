@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU 进程内缓存，超过容量时淘汰最久未使用的条目，过期条目在读取时删除
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // 队首为最近使用
+	now      func() time.Time
+}
+
+// lruEntry LRU 中的条目
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU 创建容量为 capacity 的进程内缓存
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRU{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (c *LRU) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	entry := el.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(el)
+		return nil, ErrMiss
+	}
+	c.order.MoveToFront(el)
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (c *LRU) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{key: key, value: append([]byte(nil), value...), expiresAt: c.now().Add(ttl)}
+	if el, ok := c.items[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return nil
+	}
+	c.items[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *LRU) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+	return nil
+}
+
+// Len 返回当前条目数，包括尚未清理的过期条目
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove 删除条目，调用方需持有锁
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
@@ -0,0 +1,261 @@
+// Package client Rich_GO HTTP API 的 Go 客户端
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"rich_go/pkg/errors"
+)
+
+// DefaultTimeout 默认的单次请求超时
+const DefaultTimeout = 30 * time.Second
+
+// DefaultTenantHeader 指定租户的请求头，与服务端 tenancy.header 的默认值一致
+const DefaultTenantHeader = "X-Tenant-Id"
+
+// Authenticator 为请求添加认证信息
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc 函数形式的 Authenticator
+type AuthenticatorFunc func(req *http.Request) error
+
+// Authenticate 实现 Authenticator
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BearerToken 使用 Authorization: Bearer <token> 认证
+func BearerToken(token string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// Client Rich_GO API 客户端，可并发使用
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	auth         Authenticator
+	retry        RetryPolicy
+	language     string
+	userAgent    string
+	tenant       string
+	tenantHeader string
+
+	Users   *UserService
+	Coupons *CouponService
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的 http.Client
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTransport 使用自定义的 http.RoundTripper，例如测试替身或带链路追踪的 transport
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Transport: rt, Timeout: c.httpClient.Timeout}
+	}
+}
+
+// WithAuth 设置认证方式
+func WithAuth(auth Authenticator) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithRetry 设置重试策略，仅幂等请求（GET、PUT、DELETE）会重试
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithLanguage 设置 Accept-Language，服务端按该语言返回错误消息
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.language = lang
+	}
+}
+
+// WithTenant 指定请求所属的租户，服务端启用多租户且未配置 tenancy.default 时必须设置
+// header 为空时使用 DefaultTenantHeader；token 绑定了租户时无需设置
+func WithTenant(tenantID, header string) Option {
+	return func(c *Client) {
+		if header == "" {
+			header = DefaultTenantHeader
+		}
+		c.tenant, c.tenantHeader = tenantID, header
+	}
+}
+
+// WithUserAgent 设置 User-Agent
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// New 创建客户端，baseURL 为服务地址，例如 http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("无效的服务地址: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("无效的服务地址: %s", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		retry:      DefaultRetryPolicy,
+		userAgent:  "rich_go-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Users = &UserService{client: c}
+	c.Coupons = &CouponService{client: c}
+	return c, nil
+}
+
+// envelope 服务端统一响应结构
+type envelope struct {
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Data    json.RawMessage     `json:"data"`
+	Errors  []errors.FieldError `json:"errors,omitempty"`
+}
+
+// do 发送请求并将响应中的 data 解码到 out
+// 业务错误码非 0 时返回 *errors.BusinessError
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("编码请求失败: %w", err)
+		}
+	}
+
+	resp, err := c.request(ctx, method, path, "application/json", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decode(resp, out)
+}
+
+// request 发送请求，幂等请求按重试策略重试，调用方负责关闭响应体
+func (c *Client) request(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	retry := c.retry
+	if !idempotent(method) || retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, contentType, body)
+		if attempt >= retry.MaxAttempts || !retryable(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			drain(resp)
+		}
+		if werr := retry.wait(ctx, attempt, resp); werr != nil {
+			return nil, werr
+		}
+	}
+}
+
+// send 发送单次请求，每次重试都重新构造请求体
+func (c *Client) send(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.tenant != "" {
+		req.Header.Set(c.tenantHeader, c.tenant)
+	}
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, fmt.Errorf("设置认证信息失败: %w", err)
+		}
+	}
+	return c.httpClient.Do(req)
+}
+
+// decode 解析统一响应结构
+func decode(resp *http.Response, out interface{}) error {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		// 非统一响应结构，例如网关返回的错误页
+		return fmt.Errorf("HTTP %d: 无法解析响应: %w", resp.StatusCode, err)
+	}
+	if env.Code != errors.CodeSuccess {
+		be := errors.NewBusinessError(env.Code, env.Message)
+		be.Fields = env.Errors
+		return be
+	}
+	if out == nil || len(env.Data) == 0 || string(env.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("解码响应数据失败: %w", err)
+	}
+	return nil
+}
+
+// drain 读完并关闭响应体，便于复用连接
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// idempotent 判断请求方法是否幂等
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
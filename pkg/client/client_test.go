@@ -0,0 +1,206 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"rich_go/internal/app"
+	"rich_go/internal/config"
+	"rich_go/pkg/client"
+	"rich_go/pkg/errors"
+)
+
+// newServer 使用真实路由和中间件的测试服务器，启用认证和多租户且没有默认租户
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	cfg := config.Default()
+	cfg.App.Env = "testing"
+	cfg.GRPC.Enabled = false
+	cfg.Auth.Enabled = true
+	cfg.Auth.Tokens = []config.AuthToken{{Name: "admin", Token: "admin-token"}}
+	cfg.Tenancy.Enabled = true
+	cfg.Tenancy.Default = ""
+	cfg.Tenancy.Tenants = []config.TenantConfig{{ID: "acme", Name: "Acme"}, {ID: "globex", Name: "Globex"}}
+
+	a, err := app.New(cfg)
+	if err != nil {
+		t.Fatalf("创建应用: %v", err)
+	}
+	srv := httptest.NewServer(a.HTTPServer.Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newClient(t *testing.T, url string, opts ...client.Option) *client.Client {
+	t.Helper()
+	opts = append([]client.Option{client.WithAuth(client.BearerToken("admin-token")), client.WithRetry(client.NoRetry)}, opts...)
+	c, err := client.New(url, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// wantCode 检查 err 是否为指定错误码的业务错误
+func wantCode(t *testing.T, err error, code int) *errors.BusinessError {
+	t.Helper()
+	be, ok := errors.AsBusinessError(err)
+	if !ok || be.Code != code {
+		t.Fatalf("错误为 %v, want 错误码 %d", err, code)
+	}
+	return be
+}
+
+func TestCouponsCRUD(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv.URL, client.WithTenant("acme", ""))
+	ctx := context.Background()
+
+	created, err := c.Coupons.Create(ctx, &client.CreateCouponRequest{Name: "summer", DiscountType: client.DiscountFixed, DiscountValue: 10, MinAmount: 50})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 || created.Status != client.CouponActive {
+		t.Errorf("Create = %+v, want 有 ID 且状态为 active", created)
+	}
+
+	updated, err := c.Coupons.Update(ctx, created.ID, &client.UpdateCouponRequest{Name: "winter"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Name != "winter" || updated.DiscountValue != 10 {
+		t.Errorf("Update = %+v, want 只修改名称", updated)
+	}
+
+	got, err := c.Coupons.Get(ctx, created.ID)
+	if err != nil || got.Name != "winter" {
+		t.Errorf("Get = %+v, %v", got, err)
+	}
+	list, err := c.Coupons.List(ctx)
+	if err != nil || len(list) != 1 {
+		t.Errorf("List = %d 张, %v, want 1", len(list), err)
+	}
+
+	if err := c.Coupons.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	_, err = c.Coupons.Get(ctx, created.ID)
+	wantCode(t, err, errors.CodeCouponNotFound)
+}
+
+func TestUsersCRUD(t *testing.T) {
+	srv := newServer(t)
+	c := newClient(t, srv.URL, client.WithTenant("acme", ""))
+	ctx := context.Background()
+
+	created, err := c.Users.Create(ctx, &client.CreateUserRequest{Name: "alice", Email: "alice@example.com"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := c.Users.Update(ctx, created.ID, &client.UpdateUserRequest{Email: "alice@example.org"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := c.Users.Get(ctx, created.ID)
+	if err != nil || got.Email != "alice@example.org" || got.Name != "alice" {
+		t.Errorf("Get = %+v, %v", got, err)
+	}
+	if err := c.Users.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	list, err := c.Users.List(ctx)
+	if err != nil || len(list) != 0 {
+		t.Errorf("List = %d 个, %v, want 0", len(list), err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+
+	t.Run("校验错误", func(t *testing.T) {
+		c := newClient(t, srv.URL, client.WithTenant("acme", ""), client.WithLanguage("en-US"))
+		_, err := c.Coupons.Create(ctx, &client.CreateCouponRequest{DiscountType: "bogus"})
+		be := wantCode(t, err, errors.CodeInvalidParam)
+		fields := map[string]bool{}
+		for _, f := range be.Fields {
+			fields[f.Field] = true
+		}
+		for _, f := range []string{"name", "discountType", "discountValue"} {
+			if !fields[f] {
+				t.Errorf("字段错误 %+v 缺少 %s", be.Fields, f)
+			}
+		}
+	})
+	t.Run("未认证", func(t *testing.T) {
+		c, _ := client.New(srv.URL, client.WithTenant("acme", ""))
+		_, err := c.Coupons.List(ctx)
+		wantCode(t, err, errors.CodeUnauthorized)
+	})
+	t.Run("未指定租户", func(t *testing.T) {
+		c := newClient(t, srv.URL)
+		_, err := c.Coupons.List(ctx)
+		wantCode(t, err, errors.CodeTenantRequired)
+	})
+}
+
+func TestWithTenantIsolation(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+	acme := newClient(t, srv.URL, client.WithTenant("acme", ""))
+	globex := newClient(t, srv.URL, client.WithTenant("globex", client.DefaultTenantHeader))
+
+	coupon, err := acme.Coupons.Create(ctx, &client.CreateCouponRequest{Name: "summer", DiscountType: client.DiscountFixed, DiscountValue: 10})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if list, err := globex.Coupons.List(ctx); err != nil || len(list) != 0 {
+		t.Errorf("globex List = %d 张, %v, want 0", len(list), err)
+	}
+	_, err = globex.Coupons.Get(ctx, coupon.ID)
+	wantCode(t, err, errors.CodeCouponNotFound)
+}
+
+// flakyTransport 前 failures 个请求返回 503，之后转发到真实服务器
+type flakyTransport struct {
+	failures int32
+	requests atomic.Int32
+}
+
+func (f *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if f.requests.Add(1) <= f.failures {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusServiceUnavailable)
+		return rec.Result(), nil
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRetry(t *testing.T) {
+	srv := newServer(t)
+	ctx := context.Background()
+	policy := client.RetryPolicy{MaxAttempts: 3}
+
+	t.Run("幂等请求重试", func(t *testing.T) {
+		transport := &flakyTransport{failures: 2}
+		c := newClient(t, srv.URL, client.WithTenant("acme", ""), client.WithTransport(transport), client.WithRetry(policy))
+		if _, err := c.Coupons.List(ctx); err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if n := transport.requests.Load(); n != 3 {
+			t.Errorf("发送 %d 次, want 3", n)
+		}
+	})
+	t.Run("非幂等请求不重试", func(t *testing.T) {
+		transport := &flakyTransport{failures: 1}
+		c := newClient(t, srv.URL, client.WithTenant("acme", ""), client.WithTransport(transport), client.WithRetry(policy))
+		if _, err := c.Coupons.Create(ctx, &client.CreateCouponRequest{Name: "summer", DiscountType: client.DiscountFixed, DiscountValue: 10}); err == nil {
+			t.Error("Create 成功, want 503 错误")
+		}
+		if n := transport.requests.Load(); n != 1 {
+			t.Errorf("发送 %d 次, want 1", n)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"rich_go/pkg/bulk"
+	"rich_go/pkg/errors"
+)
+
+// 导入模式
+const (
+	ImportAtomic     = "atomic"      // 任一行失败则不写入
+	ImportBestEffort = "best_effort" // 跳过失败的行
+)
+
+// 折扣类型
+const (
+	DiscountFixed   = "fixed"
+	DiscountPercent = "percent"
+)
+
+// 优惠券状态
+const (
+	CouponActive   = "active"
+	CouponInactive = "inactive"
+)
+
+// Coupon 优惠券
+type Coupon struct {
+	ID            uint    `json:"id"`
+	Name          string  `json:"name"`
+	Description   string  `json:"description"`
+	DiscountType  string  `json:"discountType"`
+	DiscountValue float64 `json:"discountValue"`
+	MinAmount     float64 `json:"minAmount"`
+	Status        string  `json:"status"`
+}
+
+// CreateCouponRequest 创建优惠券请求
+type CreateCouponRequest struct {
+	Name          string  `json:"name"`
+	Description   string  `json:"description,omitempty"`
+	DiscountType  string  `json:"discountType"`
+	DiscountValue float64 `json:"discountValue"`
+	MinAmount     float64 `json:"minAmount,omitempty"`
+	Status        string  `json:"status,omitempty"`
+}
+
+// UpdateCouponRequest 更新优惠券请求，零值字段表示不修改；MinAmount 为 nil 时不修改
+type UpdateCouponRequest struct {
+	Name          string   `json:"name,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	DiscountType  string   `json:"discountType,omitempty"`
+	DiscountValue float64  `json:"discountValue,omitempty"`
+	MinAmount     *float64 `json:"minAmount,omitempty"`
+	Status        string   `json:"status,omitempty"`
+}
+
+// ImportOptions 导入选项
+type ImportOptions struct {
+	Mode   string // 为空时使用服务端默认的 atomic
+	DryRun bool   // 只校验不写入
+}
+
+// ImportResult 导入结果
+type ImportResult struct {
+	Mode     string           `json:"mode"`
+	DryRun   bool             `json:"dryRun"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ImportRowError 单行导入错误
+type ImportRowError struct {
+	Line    int                 `json:"line"` // 行号，CSV 表头为第 1 行
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Fields  []errors.FieldError `json:"fields,omitempty"`
+}
+
+// CouponFilter 导出条件，空字段表示不限制
+type CouponFilter struct {
+	Name         string // 名称包含，不区分大小写
+	Status       string
+	DiscountType string
+}
+
+// CouponService 优惠券接口
+type CouponService struct {
+	client *Client
+}
+
+// List 获取优惠券列表
+func (s *CouponService) List(ctx context.Context) ([]*Coupon, error) {
+	var out struct {
+		Coupons []*Coupon `json:"coupons"`
+	}
+	if err := s.client.do(ctx, http.MethodGet, "/api/v1/coupons", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Coupons, nil
+}
+
+// Get 获取单个优惠券
+func (s *CouponService) Get(ctx context.Context, id uint) (*Coupon, error) {
+	var coupon Coupon
+	if err := s.client.do(ctx, http.MethodGet, couponPath(id), nil, &coupon); err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// Create 创建优惠券，非幂等请求不会重试
+func (s *CouponService) Create(ctx context.Context, req *CreateCouponRequest) (*Coupon, error) {
+	var coupon Coupon
+	if err := s.client.do(ctx, http.MethodPost, "/api/v1/coupons", req, &coupon); err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// Update 更新优惠券
+func (s *CouponService) Update(ctx context.Context, id uint, req *UpdateCouponRequest) (*Coupon, error) {
+	var coupon Coupon
+	if err := s.client.do(ctx, http.MethodPut, couponPath(id), req, &coupon); err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// Delete 删除优惠券
+func (s *CouponService) Delete(ctx context.Context, id uint) error {
+	return s.client.do(ctx, http.MethodDelete, couponPath(id), nil, nil)
+}
+
+// Import 从 CSV 或 NDJSON 批量导入优惠券，非幂等请求不会重试
+func (s *CouponService) Import(ctx context.Context, r io.Reader, format bulk.Format, opts ImportOptions) (*ImportResult, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取导入数据失败: %w", err)
+	}
+	query := url.Values{"format": {string(format)}}
+	if opts.Mode != "" {
+		query.Set("mode", opts.Mode)
+	}
+	if opts.DryRun {
+		query.Set("dryRun", "true")
+	}
+
+	resp, err := s.client.request(ctx, http.MethodPost, "/api/v1/coupons/import?"+query.Encode(), format.ContentType(), body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var result ImportResult
+	if err := decode(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Export 将符合条件的优惠券以 CSV 或 NDJSON 写入 w
+func (s *CouponService) Export(ctx context.Context, w io.Writer, format bulk.Format, filter CouponFilter) error {
+	query := url.Values{"format": {string(format)}}
+	for key, value := range map[string]string{"name": filter.Name, "status": filter.Status, "discountType": filter.DiscountType} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	resp, err := s.client.request(ctx, http.MethodGet, "/api/v1/coupons/export?"+query.Encode(), "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 导出开始前失败时返回统一响应结构
+	if resp.StatusCode != http.StatusOK {
+		return decode(resp, nil)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("读取导出数据失败: %w", err)
+	}
+	return nil
+}
+
+func couponPath(id uint) string {
+	return "/api/v1/coupons/" + url.PathEscape(strconv.FormatUint(uint64(id), 10))
+}
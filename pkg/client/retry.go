@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy 重试策略，退避时间按 BaseDelay * 2^(n-1) 递增并加入随机抖动
+type RetryPolicy struct {
+	MaxAttempts int           // 最大尝试次数（含首次），1 表示不重试
+	BaseDelay   time.Duration // 首次重试前的等待时间
+	MaxDelay    time.Duration // 单次等待时间上限
+}
+
+// DefaultRetryPolicy 默认重试策略
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// NoRetry 不重试
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// retryable 判断是否需要重试：网络错误、429 和网关类 5xx
+// 调用方取消或超时不重试
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !stderrors.Is(err, context.Canceled) && !stderrors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// wait 等待第 attempt 次重试前的退避时间，优先使用响应的 Retry-After
+func (p RetryPolicy) wait(ctx context.Context, attempt int, resp *http.Response) error {
+	delay := p.backoff(attempt)
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			delay = time.Duration(secs) * time.Second
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff 指数退避，附加最多 50% 的随机抖动
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 { // 溢出
+		delay = p.MaxDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// 用户状态
+const (
+	UserActive   = "active"
+	UserInactive = "inactive"
+)
+
+// User 用户
+type User struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+// CreateUserRequest 创建用户请求
+type CreateUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UpdateUserRequest 更新用户请求，零值字段表示不修改
+type UpdateUserRequest struct {
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// UserService 用户接口
+type UserService struct {
+	client *Client
+}
+
+// List 获取用户列表
+func (s *UserService) List(ctx context.Context) ([]*User, error) {
+	var out struct {
+		Users []*User `json:"users"`
+	}
+	if err := s.client.do(ctx, http.MethodGet, "/api/v1/users", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Users, nil
+}
+
+// Get 获取单个用户
+func (s *UserService) Get(ctx context.Context, id uint) (*User, error) {
+	var user User
+	if err := s.client.do(ctx, http.MethodGet, userPath(id), nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Create 创建用户，非幂等请求不会重试
+func (s *UserService) Create(ctx context.Context, req *CreateUserRequest) (*User, error) {
+	var user User
+	if err := s.client.do(ctx, http.MethodPost, "/api/v1/users", req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Update 更新用户
+func (s *UserService) Update(ctx context.Context, id uint, req *UpdateUserRequest) (*User, error) {
+	var user User
+	if err := s.client.do(ctx, http.MethodPut, userPath(id), req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Delete 删除用户
+func (s *UserService) Delete(ctx context.Context, id uint) error {
+	return s.client.do(ctx, http.MethodDelete, userPath(id), nil, nil)
+}
+
+func userPath(id uint) string {
+	return "/api/v1/users/" + url.PathEscape(strconv.FormatUint(uint64(id), 10))
+}
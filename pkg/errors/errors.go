@@ -3,6 +3,11 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net/http"
+
+	"rich_go/pkg/i18n"
+
+	"google.golang.org/grpc/codes"
 )
 
 // 业务错误码定义
@@ -10,9 +15,17 @@ const (
 	CodeSuccess = 0
 
 	// 通用错误码 1000-1999
-	CodeInvalidParam  = 1001
-	CodeNotFound      = 1002
-	CodeInternalError = 1003
+	CodeInvalidParam      = 1001
+	CodeNotFound          = 1002
+	CodeInternalError     = 1003
+	CodeUnauthorized      = 1004
+	CodeTimeout           = 1005
+	CodeBodyTooLarge      = 1006
+	CodeUnsupportedFormat = 1007
+	CodeInvalidFile       = 1008
+	CodeTooManyRows       = 1009
+	CodeBatchAborted      = 1010
+	CodeForbidden         = 1011
 
 	// 用户相关错误码 2000-2999
 	CodeUserNotFound     = 2001
@@ -24,18 +37,98 @@ const (
 	CodeCouponAlreadyExists = 3002
 	CodeInvalidCouponID     = 3003
 	CodeInvalidDiscountType = 3004
+
+	// webhook 相关错误码 4000-4999
+	CodeWebhookNotFound   = 4001
+	CodeInvalidWebhookID  = 4002
+	CodeDeliveryNotFound  = 4003
+	CodeInvalidDeliveryID = 4004
+
+	// 定时任务相关错误码 5000-5999
+	CodeJobNotFound = 5001
+	CodeJobRunning  = 5002
+
+	// 租户相关错误码 6000-6999
+	CodeTenantNotFound      = 6001
+	CodeTenantAlreadyExists = 6002
+	CodeTenantRequired      = 6003
+	CodeTenantMismatch      = 6004
+	CodeTenantSuspended     = 6005
+	CodeTenantLimitExceeded = 6006
 )
 
 // BusinessError 业务错误
 type BusinessError struct {
-	Code    int
-	Message string
+	Code      int
+	Message   string                 // 默认语言（zh-CN）的错误消息，用于日志及无法本地化时
+	MessageID string                 // 消息目录中的消息 ID，为空时不做本地化
+	Params    map[string]interface{} // 消息插值参数
+	Fields    []FieldError           // 字段级校验错误，仅参数错误时存在
+
+	cause error     // 原始错误，仅用于服务端日志，不会返回给客户端
+	stack []uintptr // 内部错误的调用栈
 }
 
+// Error 返回错误消息，不包含原始错误，可安全返回给客户端
 func (e *BusinessError) Error() string {
 	return e.Message
 }
 
+// Unwrap 返回原始错误，支持 errors.Is/As 沿错误链查找
+func (e *BusinessError) Unwrap() error {
+	return e.cause
+}
+
+// Is 错误码相同即视为同一业务错误，例如 errors.Is(err, ErrUserNotFound)
+func (e *BusinessError) Is(target error) bool {
+	t, ok := target.(*BusinessError)
+	return ok && t.Code == e.Code
+}
+
+// Cause 返回原始错误
+func (e *BusinessError) Cause() error {
+	return e.cause
+}
+
+// WithCause 返回携带原始错误的副本，预定义错误本身不会被修改
+// 内部错误会同时记录调用栈
+func (e *BusinessError) WithCause(cause error) *BusinessError {
+	clone := *e
+	clone.cause = cause
+	if clone.HTTPStatus() >= http.StatusInternalServerError {
+		clone.stack = callers()
+	}
+	return &clone
+}
+
+// Format 实现 fmt.Formatter，%+v 输出原始错误链和调用栈，仅用于服务端日志
+func (e *BusinessError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		fmt.Fprintf(s, "[%d] %s", e.Code, e.Message)
+		for cause := e.cause; cause != nil; cause = errors.Unwrap(cause) {
+			fmt.Fprintf(s, "\n  caused by: %v", cause)
+		}
+		if len(e.stack) > 0 {
+			fmt.Fprintf(s, "\n%s", formatStack(e.stack))
+		}
+	case verb == 'v' || verb == 's':
+		fmt.Fprint(s, e.Message)
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Message)
+	}
+}
+
+// HTTPStatus 返回该错误对应的 HTTP 状态码
+func (e *BusinessError) HTTPStatus() int {
+	return HTTPStatus(e.Code)
+}
+
+// GRPCCode 返回该错误对应的 gRPC 状态码
+func (e *BusinessError) GRPCCode() codes.Code {
+	return GRPCCode(e.Code)
+}
+
 // NewBusinessError 创建业务错误
 func NewBusinessError(code int, message string) *BusinessError {
 	return &BusinessError{
@@ -44,6 +137,36 @@ func NewBusinessError(code int, message string) *BusinessError {
 	}
 }
 
+// NewLocalizedError 创建可本地化的业务错误，消息取自消息目录
+func NewLocalizedError(code int, messageID string, params map[string]interface{}) *BusinessError {
+	return &BusinessError{
+		Code:      code,
+		Message:   i18n.Translate(i18n.DefaultLanguage, messageID, params),
+		MessageID: messageID,
+		Params:    params,
+	}
+}
+
+// Localize 返回指定语言的错误消息和字段错误
+func (e *BusinessError) Localize(lang string) (string, []FieldError) {
+	var fields []FieldError
+	if len(e.Fields) > 0 {
+		fields = make([]FieldError, len(e.Fields))
+		for i, f := range e.Fields {
+			f.Message = f.localize(lang)
+			fields[i] = f
+		}
+		return joinFieldMessages(fields), fields
+	}
+
+	if e.MessageID != "" {
+		if msg, ok := i18n.Lookup(lang, e.MessageID, e.Params); ok {
+			return msg, nil
+		}
+	}
+	return e.Message, nil
+}
+
 // NewBusinessErrorf 创建格式化业务错误
 func NewBusinessErrorf(code int, format string, args ...interface{}) *BusinessError {
 	return &BusinessError{
@@ -52,44 +175,77 @@ func NewBusinessErrorf(code int, format string, args ...interface{}) *BusinessEr
 	}
 }
 
-// 预定义错误
+// 预定义错误，同时在错误码注册表中登记 HTTP 状态码和 gRPC 状态码
 var (
-	ErrInvalidParam  = NewBusinessError(CodeInvalidParam, "参数错误")
-	ErrNotFound      = NewBusinessError(CodeNotFound, "资源不存在")
-	ErrInternalError = NewBusinessError(CodeInternalError, "内部服务器错误")
-
-	ErrUserNotFound     = NewBusinessError(CodeUserNotFound, "用户不存在")
-	ErrUserAlreadyExists = NewBusinessError(CodeUserAlreadyExists, "用户已存在")
-	ErrInvalidUserID    = NewBusinessError(CodeInvalidUserID, "无效的用户ID")
-
-	ErrCouponNotFound      = NewBusinessError(CodeCouponNotFound, "优惠券不存在")
-	ErrCouponAlreadyExists = NewBusinessError(CodeCouponAlreadyExists, "优惠券已存在")
-	ErrInvalidCouponID     = NewBusinessError(CodeInvalidCouponID, "无效的优惠券ID")
-	ErrInvalidDiscountType = NewBusinessError(CodeInvalidDiscountType, "无效的折扣类型")
+	ErrInvalidParam      = Register(CodeInvalidParam, http.StatusBadRequest, codes.InvalidArgument, "参数错误")
+	ErrNotFound          = Register(CodeNotFound, http.StatusNotFound, codes.NotFound, "资源不存在")
+	ErrInternalError     = Register(CodeInternalError, http.StatusInternalServerError, codes.Internal, "内部服务器错误")
+	ErrUnauthorized      = Register(CodeUnauthorized, http.StatusUnauthorized, codes.Unauthenticated, "未认证或认证已失效")
+	ErrTimeout           = Register(CodeTimeout, http.StatusGatewayTimeout, codes.DeadlineExceeded, "请求处理超时")
+	ErrBodyTooLarge      = Register(CodeBodyTooLarge, http.StatusRequestEntityTooLarge, codes.ResourceExhausted, "请求体过大")
+	ErrUnsupportedFormat = Register(CodeUnsupportedFormat, http.StatusUnsupportedMediaType, codes.InvalidArgument, "不支持的数据格式")
+	ErrInvalidFile       = Register(CodeInvalidFile, http.StatusBadRequest, codes.InvalidArgument, "文件无法解析")
+	ErrTooManyRows       = Register(CodeTooManyRows, http.StatusRequestEntityTooLarge, codes.ResourceExhausted, "数据行数超过上限")
+	ErrBatchAborted      = Register(CodeBatchAborted, http.StatusConflict, codes.Aborted, "批量请求中的其他操作失败，本操作未执行")
+	ErrForbidden         = Register(CodeForbidden, http.StatusForbidden, codes.PermissionDenied, "无权访问")
+
+	ErrUserNotFound      = Register(CodeUserNotFound, http.StatusNotFound, codes.NotFound, "用户不存在")
+	ErrUserAlreadyExists = Register(CodeUserAlreadyExists, http.StatusConflict, codes.AlreadyExists, "用户已存在")
+	ErrInvalidUserID     = Register(CodeInvalidUserID, http.StatusBadRequest, codes.InvalidArgument, "无效的用户ID")
+
+	ErrCouponNotFound      = Register(CodeCouponNotFound, http.StatusNotFound, codes.NotFound, "优惠券不存在")
+	ErrCouponAlreadyExists = Register(CodeCouponAlreadyExists, http.StatusConflict, codes.AlreadyExists, "优惠券已存在")
+	ErrInvalidCouponID     = Register(CodeInvalidCouponID, http.StatusBadRequest, codes.InvalidArgument, "无效的优惠券ID")
+	ErrInvalidDiscountType = Register(CodeInvalidDiscountType, http.StatusBadRequest, codes.InvalidArgument, "无效的折扣类型")
+
+	ErrWebhookNotFound   = Register(CodeWebhookNotFound, http.StatusNotFound, codes.NotFound, "webhook 不存在")
+	ErrInvalidWebhookID  = Register(CodeInvalidWebhookID, http.StatusBadRequest, codes.InvalidArgument, "无效的 webhook ID")
+	ErrDeliveryNotFound  = Register(CodeDeliveryNotFound, http.StatusNotFound, codes.NotFound, "投递记录不存在")
+	ErrInvalidDeliveryID = Register(CodeInvalidDeliveryID, http.StatusBadRequest, codes.InvalidArgument, "无效的投递记录 ID")
+
+	ErrJobNotFound = Register(CodeJobNotFound, http.StatusNotFound, codes.NotFound, "定时任务不存在")
+	ErrJobRunning  = Register(CodeJobRunning, http.StatusConflict, codes.FailedPrecondition, "定时任务正在运行")
+
+	ErrTenantNotFound      = Register(CodeTenantNotFound, http.StatusNotFound, codes.NotFound, "租户不存在")
+	ErrTenantAlreadyExists = Register(CodeTenantAlreadyExists, http.StatusConflict, codes.AlreadyExists, "租户已存在")
+	ErrTenantRequired      = Register(CodeTenantRequired, http.StatusBadRequest, codes.InvalidArgument, "未指定租户")
+	ErrTenantMismatch      = Register(CodeTenantMismatch, http.StatusForbidden, codes.PermissionDenied, "请求的租户与凭证或域名对应的租户不一致")
+	ErrTenantSuspended     = Register(CodeTenantSuspended, http.StatusForbidden, codes.PermissionDenied, "租户已停用")
+	ErrTenantLimitExceeded = Register(CodeTenantLimitExceeded, http.StatusConflict, codes.ResourceExhausted, "租户配额已用完")
 )
 
-// IsBusinessError 判断是否为业务错误
+// IsBusinessError 判断错误链中是否包含业务错误
 func IsBusinessError(err error) bool {
-	_, ok := err.(*BusinessError)
+	_, ok := AsBusinessError(err)
 	return ok
 }
 
-// AsBusinessError 转换为业务错误
+// AsBusinessError 从错误链中取出业务错误，支持 fmt.Errorf("%w") 包装的错误
 func AsBusinessError(err error) (*BusinessError, bool) {
-	be, ok := err.(*BusinessError)
+	var be *BusinessError
+	ok := errors.As(err, &be)
 	return be, ok
 }
 
-// WrapError 包装错误
+// WrapError 包装错误，保留原始错误作为 cause
 func WrapError(err error, code int, message string) error {
 	if err == nil {
 		return nil
 	}
-	return NewBusinessError(code, fmt.Sprintf("%s: %v", message, err))
+	return NewBusinessError(code, message).WithCause(err)
 }
 
-// 标准库错误兼容
-var (
-	ErrRecordNotFound = errors.New("record not found")
-)
+// Internal 将未知错误包装为内部错误，记录调用栈，对客户端只暴露通用消息
+func Internal(err error) *BusinessError {
+	return ErrInternalError.WithCause(err)
+}
+
+// Is 等同于标准库 errors.Is
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
 
+// As 等同于标准库 errors.As
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
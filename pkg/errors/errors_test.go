@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"rich_go/pkg/i18n"
+
+	"google.golang.org/grpc/status"
+)
+
+func TestIsAsThroughWrapping(t *testing.T) {
+	cause := &fs.PathError{Op: "open", Path: "/secret/db.conf", Err: fs.ErrNotExist}
+	be := ErrCouponNotFound.WithCause(cause)
+	wrapped := fmt.Errorf("查询优惠券: %w", fmt.Errorf("仓储: %w", be))
+
+	t.Run("Is 匹配业务错误码", func(t *testing.T) {
+		if !Is(wrapped, ErrCouponNotFound) {
+			t.Error("Is(wrapped, ErrCouponNotFound) = false, want true")
+		}
+		if Is(wrapped, ErrUserNotFound) {
+			t.Error("Is(wrapped, ErrUserNotFound) = true, want false")
+		}
+	})
+	t.Run("Is 沿 cause 查找", func(t *testing.T) {
+		if !Is(wrapped, fs.ErrNotExist) {
+			t.Error("Is(wrapped, fs.ErrNotExist) = false, want true")
+		}
+	})
+	t.Run("As 取出业务错误", func(t *testing.T) {
+		var got *BusinessError
+		if !As(wrapped, &got) || got.Code != CodeCouponNotFound {
+			t.Errorf("As 得到 %v, want 错误码 %d", got, CodeCouponNotFound)
+		}
+		if got2, ok := AsBusinessError(wrapped); !ok || got2 != be {
+			t.Errorf("AsBusinessError = %v, %v, want 原业务错误", got2, ok)
+		}
+		if !IsBusinessError(wrapped) {
+			t.Error("IsBusinessError(wrapped) = false, want true")
+		}
+	})
+	t.Run("As 沿 cause 取出原始错误", func(t *testing.T) {
+		var pathErr *fs.PathError
+		if !As(wrapped, &pathErr) || pathErr.Path != cause.Path {
+			t.Errorf("As 得到 %v, want %v", pathErr, cause)
+		}
+	})
+	t.Run("非业务错误", func(t *testing.T) {
+		plain := fmt.Errorf("包装: %w", context.Canceled)
+		if IsBusinessError(plain) {
+			t.Error("IsBusinessError(plain) = true, want false")
+		}
+		if _, ok := AsBusinessError(plain); ok {
+			t.Error("AsBusinessError(plain) ok = true, want false")
+		}
+	})
+	t.Run("WithCause 不修改预定义错误", func(t *testing.T) {
+		if ErrCouponNotFound.Cause() != nil {
+			t.Errorf("ErrCouponNotFound.Cause() = %v, want nil", ErrCouponNotFound.Cause())
+		}
+	})
+}
+
+func TestCauseNotExposed(t *testing.T) {
+	const secret = "dial tcp 10.0.0.5:5432: password authentication failed"
+	cause := errors.New(secret)
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"内部错误", Internal(cause)},
+		{"业务错误", ErrCouponNotFound.WithCause(cause)},
+		{"WrapError", WrapError(cause, CodeInvalidParam, "参数错误")},
+		{"fmt.Errorf 包装", fmt.Errorf("创建优惠券: %w", Internal(cause))},
+		{"非业务错误", cause},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if be, ok := AsBusinessError(tt.err); ok {
+				if strings.Contains(be.Error(), secret) {
+					t.Errorf("Error() = %q, 包含原始错误", be.Error())
+				}
+				if s := fmt.Sprintf("%v %s %q", be, be, be); strings.Contains(s, secret) {
+					t.Errorf("%%v/%%s/%%q 输出 %q, 包含原始错误", s)
+				}
+			}
+
+			for _, lang := range []string{i18n.LangZhCN, i18n.LangEnUS} {
+				st, ok := status.FromError(ToLocalizedGRPCStatus(tt.err, lang))
+				if !ok {
+					t.Fatalf("ToLocalizedGRPCStatus 未返回 gRPC status")
+				}
+				if strings.Contains(st.Message(), secret) {
+					t.Errorf("gRPC 消息 %q 包含原始错误", st.Message())
+				}
+				if details := fmt.Sprint(st.Details()); strings.Contains(details, secret) {
+					t.Errorf("gRPC 详情 %s 包含原始错误", details)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"strconv"
+
+	"rich_go/pkg/i18n"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain gRPC 错误详情中的业务域
+const ErrorDomain = "rich_go"
+
+// ToGRPCStatus 将错误转换为 gRPC status 错误，消息使用默认语言
+func ToGRPCStatus(err error) error {
+	return ToLocalizedGRPCStatus(err, i18n.DefaultLanguage)
+}
+
+// ToLocalizedGRPCStatus 将错误转换为指定语言的 gRPC status 错误
+// 业务错误码通过 ErrorInfo 详情透传，字段错误通过 BadRequest 详情透传
+// 非业务错误统一返回 Internal，不暴露内部信息
+func ToLocalizedGRPCStatus(err error, lang string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	be, ok := AsBusinessError(err)
+	if !ok {
+		be = ErrInternalError
+	}
+
+	message, fields := be.Localize(lang)
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Domain:   ErrorDomain,
+			Reason:   "BUSINESS_ERROR",
+			Metadata: map[string]string{"code": strconv.Itoa(be.Code)},
+		},
+	}
+	if len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fields))
+		for _, f := range fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Message,
+			})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	st := status.New(be.GRPCCode(), message)
+	detailed, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// FromGRPCStatus 将 gRPC 客户端收到的错误还原为业务错误
+// 没有业务错误详情时按 gRPC 状态码归类为通用错误码
+func FromGRPCStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var be *BusinessError
+	var fields []FieldError
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() != ErrorDomain {
+				continue
+			}
+			if code, convErr := strconv.Atoi(d.GetMetadata()["code"]); convErr == nil {
+				be = NewBusinessError(code, st.Message())
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				fields = append(fields, FieldError{Field: v.GetField(), Message: v.GetDescription()})
+			}
+		}
+	}
+	if be != nil {
+		be.Fields = fields
+		return be
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		return NewBusinessError(CodeInvalidParam, st.Message())
+	case codes.NotFound:
+		return NewBusinessError(CodeNotFound, st.Message())
+	case codes.Unauthenticated:
+		return NewBusinessError(CodeUnauthorized, st.Message())
+	default:
+		return NewBusinessError(CodeInternalError, st.Message())
+	}
+}
@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// CodeInfo 错误码元信息，决定业务错误在各传输协议上的表现
+type CodeInfo struct {
+	Code       int
+	HTTPStatus int
+	GRPCCode   codes.Code
+	Message    string // 默认错误消息
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[int]CodeInfo)
+)
+
+// Register 注册错误码并返回对应的预定义错误，重复注册会 panic
+// 预定义错误的本地化消息 ID 为 error.<code>
+func Register(code, httpStatus int, grpcCode codes.Code, message string) *BusinessError {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[code]; exists {
+		panic(fmt.Sprintf("错误码 %d 重复注册", code))
+	}
+	registry[code] = CodeInfo{
+		Code:       code,
+		HTTPStatus: httpStatus,
+		GRPCCode:   grpcCode,
+		Message:    message,
+	}
+	return &BusinessError{
+		Code:      code,
+		Message:   message,
+		MessageID: "error." + strconv.Itoa(code),
+	}
+}
+
+// Lookup 查询错误码元信息
+func Lookup(code int) (CodeInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	info, ok := registry[code]
+	return info, ok
+}
+
+// Codes 返回所有已注册的错误码，按错误码排序
+func Codes() []CodeInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	infos := make([]CodeInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Code < infos[j].Code })
+	return infos
+}
+
+// HTTPStatus 返回错误码对应的 HTTP 状态码，未注册的错误码按内部错误处理
+func HTTPStatus(code int) int {
+	if code == CodeSuccess {
+		return http.StatusOK
+	}
+	if info, ok := Lookup(code); ok {
+		return info.HTTPStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCode 返回错误码对应的 gRPC 状态码，未注册的错误码按内部错误处理
+func GRPCCode(code int) codes.Code {
+	if code == CodeSuccess {
+		return codes.OK
+	}
+	if info, ok := Lookup(code); ok {
+		return info.GRPCCode
+	}
+	return codes.Internal
+}
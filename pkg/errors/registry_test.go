@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"rich_go/pkg/i18n"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusMapping(t *testing.T) {
+	unregistered := NewBusinessError(99999, "未注册")
+	tests := []struct {
+		name     string
+		err      *BusinessError
+		wantHTTP int
+		wantGRPC codes.Code
+	}{
+		{"参数错误", ErrInvalidParam, http.StatusBadRequest, codes.InvalidArgument},
+		{"未认证", ErrUnauthorized, http.StatusUnauthorized, codes.Unauthenticated},
+		{"无权访问", ErrForbidden, http.StatusForbidden, codes.PermissionDenied},
+		{"资源不存在", ErrCouponNotFound, http.StatusNotFound, codes.NotFound},
+		{"资源已存在", ErrUserAlreadyExists, http.StatusConflict, codes.AlreadyExists},
+		{"前置条件不满足", ErrJobRunning, http.StatusConflict, codes.FailedPrecondition},
+		{"配额用完", ErrTenantLimitExceeded, http.StatusConflict, codes.ResourceExhausted},
+		{"批量中止", ErrBatchAborted, http.StatusConflict, codes.Aborted},
+		{"请求体过大", ErrBodyTooLarge, http.StatusRequestEntityTooLarge, codes.ResourceExhausted},
+		{"超时", ErrTimeout, http.StatusGatewayTimeout, codes.DeadlineExceeded},
+		{"内部错误", ErrInternalError, http.StatusInternalServerError, codes.Internal},
+		{"校验错误沿用参数错误码", NewValidationError(NewFieldError("Name", "required", "")), http.StatusBadRequest, codes.InvalidArgument},
+		{"未注册的错误码按内部错误处理", unregistered, http.StatusInternalServerError, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.HTTPStatus(); got != tt.wantHTTP {
+				t.Errorf("HTTPStatus() = %d, want %d", got, tt.wantHTTP)
+			}
+			if got := tt.err.GRPCCode(); got != tt.wantGRPC {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.wantGRPC)
+			}
+
+			// 包装后的错误转换为 gRPC status 再还原，状态码和业务错误码不变
+			st := status.Convert(ToGRPCStatus(fmt.Errorf("wrapped: %w", tt.err)))
+			if st.Code() != tt.wantGRPC {
+				t.Errorf("ToGRPCStatus 状态码 = %v, want %v", st.Code(), tt.wantGRPC)
+			}
+			be, ok := AsBusinessError(FromGRPCStatus(st.Err()))
+			if !ok || be.Code != tt.err.Code {
+				t.Errorf("FromGRPCStatus = %v, want 错误码 %d", be, tt.err.Code)
+			}
+		})
+	}
+}
+
+func TestSuccessCode(t *testing.T) {
+	if got := HTTPStatus(CodeSuccess); got != http.StatusOK {
+		t.Errorf("HTTPStatus(CodeSuccess) = %d, want 200", got)
+	}
+	if got := GRPCCode(CodeSuccess); got != codes.OK {
+		t.Errorf("GRPCCode(CodeSuccess) = %v, want OK", got)
+	}
+}
+
+func TestNonBusinessErrorToGRPC(t *testing.T) {
+	st := status.Convert(ToLocalizedGRPCStatus(fmt.Errorf("connection refused"), i18n.LangEnUS))
+	want, _ := ErrInternalError.Localize(i18n.LangEnUS)
+	if st.Code() != codes.Internal || st.Message() != want {
+		t.Errorf("非业务错误转换为 %v %q, want Internal %q", st.Code(), st.Message(), want)
+	}
+}
+
+func TestValidationFieldsRoundTrip(t *testing.T) {
+	err := ToLocalizedGRPCStatus(NewValidationError(NewFieldError("name", "required", "")), i18n.LangEnUS)
+	be, ok := AsBusinessError(FromGRPCStatus(err))
+	if !ok || be.Code != CodeInvalidParam || len(be.Fields) != 1 || be.Fields[0].Field != "name" || be.Fields[0].Message == "" {
+		t.Errorf("还原的校验错误为 %+v, want 参数错误及 name 字段的本地化信息", be)
+	}
+}
+
+func TestRegisteredCodes(t *testing.T) {
+	for _, info := range Codes() {
+		if info.HTTPStatus < 400 || info.HTTPStatus > 599 {
+			t.Errorf("错误码 %d 的 HTTP 状态码为 %d, want 4xx 或 5xx", info.Code, info.HTTPStatus)
+		}
+		if info.GRPCCode == codes.OK {
+			t.Errorf("错误码 %d 的 gRPC 状态码为 OK", info.Code)
+		}
+		for _, lang := range i18n.Languages() {
+			if _, ok := i18n.Lookup(lang, "error."+strconv.Itoa(info.Code), nil); !ok {
+				t.Errorf("错误码 %d 缺少 %s 的本地化消息", info.Code, lang)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("重复注册错误码未 panic")
+		}
+	}()
+	Register(CodeInvalidParam, http.StatusBadRequest, codes.InvalidArgument, "重复")
+}
@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth 记录的最大调用栈深度
+const maxStackDepth = 32
+
+// callers 记录调用栈，跳过 errors 包自身的帧
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// formatStack 格式化调用栈
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "rich_go/pkg/errors.") {
+			fmt.Fprintf(&b, "  at %s\n      %s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,52 @@
+// 外部测试包：调用栈会过滤 errors 包自身的帧，创建错误的测试函数须在包外
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"rich_go/pkg/errors"
+)
+
+func TestStackRecordedOnCreation(t *testing.T) {
+	cause := stderrors.New("boom")
+	err := errors.Internal(cause)
+
+	detailed := fmt.Sprintf("%+v", err)
+	lines := strings.Split(detailed, "\n")
+	if len(lines) < 3 {
+		t.Fatalf("%%+v 输出没有调用栈:\n%s", detailed)
+	}
+	if lines[1] != "  caused by: boom" {
+		t.Errorf("第二行为 %q, want 原始错误", lines[1])
+	}
+	if !strings.Contains(lines[2], "errors_test.TestStackRecordedOnCreation") {
+		t.Errorf("调用栈第一帧为 %q, want 创建错误的测试函数", lines[2])
+	}
+	if !strings.Contains(lines[3], "stack_test.go:") {
+		t.Errorf("调用栈第一帧位置为 %q, want stack_test.go", lines[3])
+	}
+	if strings.Contains(detailed, "rich_go/pkg/errors.") {
+		t.Errorf("调用栈包含 errors 包自身的帧:\n%s", detailed)
+	}
+	if plain := fmt.Sprintf("%v", err); strings.Contains(plain, "stack_test.go") {
+		t.Errorf("%%v 输出 %q 包含调用栈", plain)
+	}
+
+	tests := []struct {
+		name string
+		err  *errors.BusinessError
+	}{
+		{"非内部错误不记录调用栈", errors.ErrCouponNotFound.WithCause(cause)},
+		{"预定义错误不记录调用栈", errors.ErrInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if s := fmt.Sprintf("%+v", tt.err); strings.Contains(s, "stack_test.go") {
+				t.Errorf("%%+v 输出包含调用栈:\n%s", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"strings"
+
+	"rich_go/pkg/i18n"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field"`           // 字段的 JSON 名称，例如 discountType
+	Rule    string `json:"rule"`            // 未通过的规则，例如 required、oneof
+	Param   string `json:"param,omitempty"` // 规则参数，例如 oneof 的可选值
+	Message string `json:"message"`         // 面向用户的错误描述
+}
+
+// NewFieldError 创建字段错误，错误描述取自消息目录 validation.<rule>
+func NewFieldError(field, rule, param string) FieldError {
+	fe := FieldError{Field: field, Rule: rule, Param: param}
+	fe.Message = fe.localize(i18n.DefaultLanguage)
+	return fe
+}
+
+// localize 返回指定语言的错误描述，消息目录中没有该规则时使用通用描述
+func (f FieldError) localize(lang string) string {
+	params := map[string]interface{}{
+		"field": f.Field,
+		"rule":  f.Rule,
+		"param": strings.ReplaceAll(f.Param, " ", ", "),
+	}
+	if msg, ok := i18n.Lookup(lang, "validation."+f.Rule, params); ok {
+		return msg
+	}
+	if f.Message != "" {
+		return f.Message
+	}
+	return i18n.Translate(lang, "validation.rule", params)
+}
+
+// NewValidationError 创建包含字段错误的参数错误
+func NewValidationError(fields ...FieldError) *BusinessError {
+	be := &BusinessError{
+		Code:      CodeInvalidParam,
+		MessageID: ErrInvalidParam.MessageID,
+		Fields:    fields,
+	}
+	be.Message, _ = be.Localize(i18n.DefaultLanguage)
+	return be
+}
+
+// joinFieldMessages 拼接字段错误描述作为整体错误消息
+func joinFieldMessages(fields []FieldError) string {
+	messages := make([]string, 0, len(fields))
+	for _, f := range fields {
+		messages = append(messages, f.Message)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,173 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 支持的语言
+const (
+	LangZhCN = "zh-CN"
+	LangEnUS = "en-US"
+
+	// DefaultLanguage 默认语言，也是 BusinessError.Message 使用的语言
+	DefaultLanguage = LangZhCN
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	loadOnce sync.Once
+	catalogs map[string]map[string]string // 语言 -> 消息 ID -> 模板
+)
+
+// load 加载内置的消息目录
+func load() {
+	catalogs = make(map[string]map[string]string)
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("读取消息目录失败: %v", err))
+	}
+	for _, entry := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("读取消息目录失败: %v", err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("解析消息目录 %s 失败: %v", entry.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+}
+
+// Languages 返回支持的语言列表
+func Languages() []string {
+	loadOnce.Do(load)
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Lookup 查找消息模板并插值，不存在时返回 false
+// 目标语言缺少该消息时回退到默认语言
+func Lookup(lang, messageID string, params map[string]interface{}) (string, bool) {
+	loadOnce.Do(load)
+
+	tmpl, ok := catalogs[lang][messageID]
+	if !ok {
+		tmpl, ok = catalogs[DefaultLanguage][messageID]
+	}
+	if !ok {
+		return "", false
+	}
+	return interpolate(tmpl, params), true
+}
+
+// Translate 翻译消息，不存在时返回消息 ID
+func Translate(lang, messageID string, params map[string]interface{}) string {
+	if msg, ok := Lookup(lang, messageID, params); ok {
+		return msg
+	}
+	return messageID
+}
+
+// T 使用 context 中的语言翻译消息
+func T(ctx context.Context, messageID string, params map[string]interface{}) string {
+	return Translate(LanguageFromContext(ctx), messageID, params)
+}
+
+// interpolate 将模板中的 {name} 替换为参数值
+func interpolate(tmpl string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return tmpl
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
+
+type languageKey struct{}
+
+// WithLanguage 将语言写入 context
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// LanguageFromContext 从 context 中获取语言，未设置时返回默认语言
+func LanguageFromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok && lang != "" {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// Match 从候选语言中选出第一个受支持的语言，例如 "en" 匹配 en-US
+// 候选语言均不受支持时返回空字符串
+func Match(candidates ...string) string {
+	supported := Languages()
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" || candidate == "*" {
+			continue
+		}
+		for _, lang := range supported {
+			if strings.EqualFold(candidate, lang) {
+				return lang
+			}
+		}
+		base, _, _ := strings.Cut(candidate, "-")
+		for _, lang := range supported {
+			langBase, _, _ := strings.Cut(lang, "-")
+			if strings.EqualFold(base, langBase) {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// ParseAcceptLanguage 解析 Accept-Language 头，按权重从高到低返回语言标签
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{"空", "", []string{}},
+		{"单个语言", "en-US", []string{"en-US"}},
+		{"按权重排序", "zh-CN;q=0.5, en-US;q=0.9, fr", []string{"fr", "en-US", "zh-CN"}},
+		{"权重相同时保持原顺序", "ja, en;q=0.8, zh;q=0.8", []string{"ja", "en", "zh"}},
+		{"忽略权重为 0 的语言", "en-US;q=0, zh-CN", []string{"zh-CN"}},
+		{"无效权重按 1 处理", "en;q=abc, zh;q=0.5", []string{"en", "zh"}},
+		{"忽略空项和空白", " en , ,zh-CN ", []string{"en", "zh-CN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		want       string
+	}{
+		{"完全匹配", []string{"en-US"}, LangEnUS},
+		{"不区分大小写", []string{"ZH-cn"}, LangZhCN},
+		{"按基础语言匹配地区变体", []string{"en-GB"}, LangEnUS},
+		{"只有基础语言", []string{"zh"}, LangZhCN},
+		{"跳过不支持的语言", []string{"fr-FR", "ja", "en"}, LangEnUS},
+		{"跳过通配符", []string{"*", "zh-TW"}, LangZhCN},
+		{"都不支持时返回空", []string{"fr", "de"}, ""},
+		{"没有候选语言", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.candidates...); got != tt.want {
+				t.Errorf("Match(%v) = %q, want %q", tt.candidates, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name      string
+		lang      string
+		messageID string
+		params    map[string]interface{}
+		want      string
+	}{
+		{"英文", LangEnUS, "error.1001", nil, "Invalid parameter"},
+		{"中文", LangZhCN, "error.1001", nil, "参数错误"},
+		{"插值", LangEnUS, "validation.required", map[string]interface{}{"field": "name"}, "name is required"},
+		{"不支持的语言回退到默认语言", "fr-FR", "error.1001", nil, "参数错误"},
+		{"消息不存在时返回消息 ID", LangEnUS, "no.such.message", nil, "no.such.message"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.lang, tt.messageID, tt.params); got != tt.want {
+				t.Errorf("Translate(%q, %q) = %q, want %q", tt.lang, tt.messageID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguageFromContext(t *testing.T) {
+	if got := LanguageFromContext(context.Background()); got != DefaultLanguage {
+		t.Errorf("未设置语言时为 %q, want %q", got, DefaultLanguage)
+	}
+	if got := LanguageFromContext(WithLanguage(context.Background(), LangEnUS)); got != LangEnUS {
+		t.Errorf("设置 en-US 后为 %q, want en-US", got)
+	}
+}
+
+func TestCatalogsHaveSameMessages(t *testing.T) {
+	for _, lang := range Languages() {
+		for id := range catalogs[DefaultLanguage] {
+			if _, ok := catalogs[lang][id]; !ok {
+				t.Errorf("%s 缺少消息 %s", lang, id)
+			}
+		}
+		for id := range catalogs[lang] {
+			if _, ok := catalogs[DefaultLanguage][id]; !ok {
+				t.Errorf("%s 的消息 %s 在默认语言中不存在", lang, id)
+			}
+		}
+	}
+}
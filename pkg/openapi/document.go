@@ -0,0 +1,58 @@
+package openapi
+
+// 本文件为 OpenAPI 文档对象，只包含本项目用到的字段
+
+// Document OpenAPI 文档
+type Document struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       Info                                   `json:"info"`
+	Paths      map[string]map[string]*OperationObject `json:"paths"`
+	Components Components                             `json:"components"`
+}
+
+// Info 文档基本信息
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components 可复用的 Schema
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// OperationObject 单个接口
+type OperationObject struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	OperationID string                     `json:"operationId,omitempty"`
+	Parameters  []*Parameter               `json:"parameters,omitempty"`
+	RequestBody *RequestBody               `json:"requestBody,omitempty"`
+	Responses   map[string]*ResponseObject `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+}
+
+// Parameter 接口参数
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// ResponseObject 响应
+type ResponseObject struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType 媒体类型对应的 Schema
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
@@ -0,0 +1,245 @@
+package openapi
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version 生成文档使用的 OpenAPI 版本
+const Version = "3.1.0"
+
+// Operation 接口描述，与路由按 方法+路径 对应
+type Operation struct {
+	Summary  string
+	Tags     []string
+	Request  interface{} // 请求体类型的零值，nil 表示无请求体
+	Response interface{} // 响应 data 字段类型的零值，nil 表示 data 为 null
+	Errors   []int       // 可能返回的错误 HTTP 状态码
+	Query    interface{} // 查询参数结构体的零值，参数名取自 form 标签
+
+	// Consumes 请求体的媒体类型，为空时为 application/json
+	Consumes []string
+	// Produces 成功时直接返回文件的媒体类型，此时 Response 为每行数据的类型
+	Produces []string
+}
+
+// Spec 收集接口描述，并结合已注册的路由生成 OpenAPI 文档
+type Spec struct {
+	info       Info
+	operations map[string]Operation
+	ignored    map[string]bool
+	deprecated []string
+	envelope   interface{}
+	dataField  string
+	problem    interface{}
+}
+
+// NewSpec 创建文档描述
+func NewSpec(title, version string) *Spec {
+	return &Spec{
+		info:       Info{Title: title, Version: version},
+		operations: make(map[string]Operation),
+		ignored:    make(map[string]bool),
+	}
+}
+
+// Envelope 设置统一响应结构，成功响应的 dataField 字段替换为各接口的响应类型
+func (s *Spec) Envelope(v interface{}, dataField string) *Spec {
+	s.envelope = v
+	s.dataField = dataField
+	return s
+}
+
+// Problem 设置 application/problem+json 错误响应结构
+func (s *Spec) Problem(v interface{}) *Spec {
+	s.problem = v
+	return s
+}
+
+// Handle 描述一个接口，path 使用 Gin 路由格式，例如 /api/v1/users/:id
+func (s *Spec) Handle(method, path string, op Operation) {
+	s.operations[routeKey(method, path)] = op
+}
+
+// Ignore 不纳入文档的路径，例如 /metrics
+func (s *Spec) Ignore(paths ...string) {
+	for _, p := range paths {
+		s.ignored[p] = true
+	}
+}
+
+// Deprecate 将路径以 prefix 开头的接口标记为已弃用
+func (s *Spec) Deprecate(prefix string) {
+	s.deprecated = append(s.deprecated, prefix)
+}
+
+// Build 根据已注册的路由生成文档
+// 路由缺少描述或描述没有对应路由时返回错误，同时返回只包含有描述的路由的文档
+func (s *Spec) Build(routes gin.RoutesInfo) (*Document, error) {
+	gen := newGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    s.info,
+		Paths:   make(map[string]map[string]*OperationObject),
+	}
+
+	var errs []error
+	described := make(map[string]bool, len(s.operations))
+	for _, route := range routes {
+		if s.ignored[route.Path] {
+			continue
+		}
+		key := routeKey(route.Method, route.Path)
+		op, ok := s.operations[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("路由 %s 缺少接口描述", key))
+			continue
+		}
+		described[key] = true
+
+		path, params := convertPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*OperationObject)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = s.operation(gen, op, route, params)
+	}
+	for key := range s.operations {
+		if !described[key] {
+			errs = append(errs, fmt.Errorf("接口描述 %s 没有对应的路由", key))
+		}
+	}
+	doc.Components.Schemas = gen.schemas
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+		return doc, stderrors.Join(errs...)
+	}
+	return doc, nil
+}
+
+// operation 生成单个接口的描述
+func (s *Spec) operation(gen *generator, op Operation, route gin.RouteInfo, params []string) *OperationObject {
+	obj := &OperationObject{
+		Summary:     op.Summary,
+		Tags:        op.Tags,
+		OperationID: operationID(route),
+		Responses:   make(map[string]*ResponseObject),
+	}
+	for _, prefix := range s.deprecated {
+		if strings.HasPrefix(route.Path, prefix) {
+			obj.Deprecated = true
+		}
+	}
+	for _, p := range params {
+		obj.Parameters = append(obj.Parameters, &Parameter{
+			Name:     p,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	if op.Query != nil {
+		obj.Parameters = append(obj.Parameters, gen.queryParameters(op.Query)...)
+	}
+	if op.Request != nil {
+		consumes := op.Consumes
+		if len(consumes) == 0 {
+			consumes = []string{"application/json"}
+		}
+		obj.RequestBody = &RequestBody{
+			Required: true,
+			Content:  fileContent(gen, consumes, op.Request),
+		}
+	}
+
+	ok := &ResponseObject{Description: http.StatusText(http.StatusOK)}
+	if len(op.Produces) > 0 {
+		ok.Content = fileContent(gen, op.Produces, op.Response)
+	} else {
+		ok.Content = map[string]*MediaType{"application/json": {Schema: s.successSchema(gen, op.Response)}}
+	}
+	obj.Responses[strconv.Itoa(http.StatusOK)] = ok
+	for _, status := range op.Errors {
+		obj.Responses[strconv.Itoa(status)] = &ResponseObject{
+			Description: http.StatusText(status),
+			Content:     s.errorContent(gen),
+		}
+	}
+	return obj
+}
+
+// successSchema 成功响应：统一响应结构中的 data 替换为接口响应类型
+func (s *Spec) successSchema(gen *generator, data interface{}) *Schema {
+	dataSchema := gen.schemaOf(data)
+	if s.envelope == nil {
+		return dataSchema
+	}
+	if dataSchema == nil {
+		dataSchema = &Schema{Type: "null"}
+	}
+	return &Schema{AllOf: []*Schema{
+		gen.schemaOf(s.envelope),
+		{Type: "object", Properties: map[string]*Schema{s.dataField: dataSchema}},
+	}}
+}
+
+// errorContent 错误响应，按 Accept 协商统一响应结构或 problem+json
+func (s *Spec) errorContent(gen *generator) map[string]*MediaType {
+	content := make(map[string]*MediaType)
+	if s.envelope != nil {
+		content["application/json"] = &MediaType{Schema: gen.schemaOf(s.envelope)}
+	}
+	if s.problem != nil {
+		content["application/problem+json"] = &MediaType{Schema: gen.schemaOf(s.problem)}
+	}
+	return content
+}
+
+// fileContent 按媒体类型描述内容，JSON 类（含 NDJSON 的每一行）使用 v 的 Schema，其他类型为字符串
+func fileContent(gen *generator, mediaTypes []string, v interface{}) map[string]*MediaType {
+	content := make(map[string]*MediaType, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		if strings.Contains(mt, "json") {
+			content[mt] = &MediaType{Schema: gen.schemaOf(v)}
+		} else {
+			content[mt] = &MediaType{Schema: &Schema{Type: "string"}}
+		}
+	}
+	return content
+}
+
+// routeKey 路由的唯一标识
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// convertPath 将 Gin 路径参数 :id、*path 转换为 OpenAPI 格式 {id}，并返回参数名
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			params = append(params, seg[1:])
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID 由处理函数名生成 operationId，例如 (*UserHandlerV2).CreateUser-fm 生成 CreateUserV2
+// 同名处理函数在不同版本的处理器上，需要带上版本后缀保证唯一
+func operationID(route gin.RouteInfo) string {
+	name := strings.TrimSuffix(route.Handler, "-fm")
+	method := name[strings.LastIndex(name, ".")+1:]
+	receiver := strings.TrimSuffix(name[:len(name)-len(method)], ".")
+	receiver = strings.TrimSuffix(receiver[strings.LastIndex(receiver, ".")+1:], ")")
+	if i := strings.LastIndex(receiver, "Handler"); i >= 0 {
+		method += receiver[i+len("Handler"):]
+	}
+	return method
+}
@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBuildReportsDrift(t *testing.T) {
+	spec := NewSpec("test", "1.0.0")
+	spec.Handle(http.MethodGet, "/things/:id", Operation{Summary: "获取"})
+	spec.Handle(http.MethodDelete, "/things/:id", Operation{Summary: "删除"})
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/things/:id"},
+		{Method: http.MethodPost, Path: "/things"},
+	}
+
+	doc, err := spec.Build(routes)
+	if err == nil {
+		t.Fatal("Build = nil, want 不一致错误")
+	}
+	for _, want := range []string{"路由 POST /things 缺少接口描述", "接口描述 DELETE /things/:id 没有对应的路由"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build 错误 %q 不包含 %q", err, want)
+		}
+	}
+	// 不一致时仍返回有描述的路由
+	if doc == nil || doc.Paths["/things/{id}"]["get"] == nil || len(doc.Paths) != 1 {
+		t.Errorf("Build 文档路径为 %v, want 只有 GET /things/{id}", doc.Paths)
+	}
+}
@@ -0,0 +1,255 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema JSON Schema（OpenAPI 3.1 使用 JSON Schema 2020-12）
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// ruleTags 读取校验规则的结构体标签，服务层使用 validate，Gin 绑定使用 binding
+var ruleTags = []string{"validate", "binding"}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator 根据 Go 类型生成 Schema，具名结构体登记到 components 并以 $ref 引用
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf 返回 v 的类型对应的 Schema，v 为 nil 时返回 nil
+func (g *generator) schemaOf(v interface{}) *Schema {
+	if v == nil {
+		return nil
+	}
+	return g.schemaFor(reflect.TypeOf(v))
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// interface{} 等任意类型
+		return &Schema{}
+	}
+}
+
+// component 登记具名结构体，同名类型使用包名前缀区分
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, exists := g.schemas[name]; exists {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	g.names[t] = name
+	g.schemas[name] = &Schema{} // 先占位，支持递归类型
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema 生成结构体的对象 Schema，字段名取自 json 标签，约束取自校验标签
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := g.structSchema(indirect(f.Type))
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fs := g.schemaFor(f.Type)
+		if applyRules(fs, f) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fs
+	}
+	return s
+}
+
+// queryParameters 生成查询参数，参数名取自 form 标签，约束取自校验标签
+func (g *generator) queryParameters(v interface{}) []*Parameter {
+	t := indirect(reflect.TypeOf(v))
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("form"), ",")
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		schema := g.schemaFor(f.Type)
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: applyRules(schema, f),
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+// applyRules 将校验规则转换为 Schema 约束，返回字段是否必填
+// dive 之后的规则作用于数组元素
+func applyRules(s *Schema, f reflect.StructField) bool {
+	required := false
+	for _, key := range ruleTags {
+		tag := f.Tag.Get(key)
+		if tag == "" {
+			continue
+		}
+		target := s
+		for _, rule := range strings.Split(tag, ",") {
+			name, param, _ := strings.Cut(rule, "=")
+			switch name {
+			case "dive":
+				if target.Items == nil {
+					return required
+				}
+				target = target.Items
+			case "required":
+				if target == s {
+					required = true
+				}
+			case "email":
+				target.Format = "email"
+			case "url", "http_url":
+				target.Format = "uri"
+			case "oneof":
+				for _, v := range strings.Fields(param) {
+					target.Enum = append(target.Enum, enumValue(target.Type, v))
+				}
+			case "gt":
+				target.ExclusiveMinimum = number(param)
+			case "gte":
+				target.Minimum = number(param)
+			case "lt":
+				target.ExclusiveMaximum = number(param)
+			case "lte":
+				target.Maximum = number(param)
+			case "min":
+				switch target.Type {
+				case "string":
+					target.MinLength = length(param)
+				case "array":
+					target.MinItems = length(param)
+				default:
+					target.Minimum = number(param)
+				}
+			case "max":
+				switch target.Type {
+				case "string":
+					target.MaxLength = length(param)
+				case "array":
+					target.MaxItems = length(param)
+				default:
+					target.Maximum = number(param)
+				}
+			}
+		}
+	}
+	return required
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func number(param string) *float64 {
+	v, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func length(param string) *int {
+	v, err := strconv.Atoi(param)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// enumValue 数值类型的枚举值按数字输出
+func enumValue(schemaType, v string) interface{} {
+	if schemaType == "integer" || schemaType == "number" {
+		if n := number(v); n != nil {
+			return *n
+		}
+	}
+	return v
+}
@@ -0,0 +1,30 @@
+package openapi
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed redoc.html
+var redocHTML string
+
+var redocTemplate = template.Must(template.New("redoc").Parse(redocHTML))
+
+// JSONHandler 输出 OpenAPI 文档
+func JSONHandler(doc *Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// UIHandler 输出 Redoc 文档页面，specURL 为 OpenAPI 文档地址
+func UIHandler(title, specURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		c.Status(http.StatusOK)
+		_ = redocTemplate.Execute(c.Writer, map[string]string{"Title": title, "SpecURL": specURL})
+	}
+}
@@ -0,0 +1,48 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"rich_go/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentTypeProblem RFC 7807 问题详情的媒体类型
+const ContentTypeProblem = "application/problem+json"
+
+// problemTypePrefix 问题类型 URI 前缀，后接业务错误码
+const problemTypePrefix = "urn:rich_go:error:"
+
+// Problem RFC 7807 问题详情，code 与 errors 为扩展成员
+type Problem struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Code     int                 `json:"code"`
+	Errors   []errors.FieldError `json:"errors,omitempty"`
+}
+
+// writeProblem 以 application/problem+json 输出错误
+func writeProblem(c *gin.Context, status, code int, message string, fields []errors.FieldError) {
+	problem := Problem{
+		Type:     problemTypePrefix + strconv.Itoa(code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   message,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Errors:   fields,
+	}
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(status, ContentTypeProblem, body)
+}
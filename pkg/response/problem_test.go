@@ -0,0 +1,84 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"rich_go/pkg/errors"
+)
+
+func TestProblemNegotiation(t *testing.T) {
+	validation := errors.NewValidationError(errors.NewFieldError("discountType", "oneof", "fixed percent"))
+	tests := []struct {
+		name        string
+		err         error
+		accept      string
+		wantProblem bool
+		wantStatus  int
+		wantCode    int
+	}{
+		{"默认输出统一响应结构", errors.ErrCouponNotFound, "", false, http.StatusNotFound, errors.CodeCouponNotFound},
+		{"Accept application/json", errors.ErrCouponNotFound, "application/json", false, http.StatusNotFound, errors.CodeCouponNotFound},
+		{"Accept problem+json", errors.ErrCouponNotFound, ContentTypeProblem, true, http.StatusNotFound, errors.CodeCouponNotFound},
+		{"problem+json 优先于 JSON", errors.ErrCouponNotFound, "application/problem+json, application/json", true, http.StatusNotFound, errors.CodeCouponNotFound},
+		{"校验错误", validation, ContentTypeProblem, true, http.StatusBadRequest, errors.CodeInvalidParam},
+		{"租户配额", errors.ErrTenantLimitExceeded, ContentTypeProblem, true, http.StatusConflict, errors.CodeTenantLimitExceeded},
+		{"内部错误", errors.Internal(nil), ContentTypeProblem, true, http.StatusInternalServerError, errors.CodeInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := serveError(t, tt.err, tt.accept)
+			if w.Code != tt.wantStatus {
+				t.Errorf("状态码 = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			contentType := w.Header().Get("Content-Type")
+			if !tt.wantProblem {
+				if contentType == ContentTypeProblem {
+					t.Fatalf("Content-Type = %q, want JSON", contentType)
+				}
+				var resp Response
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("解析响应失败: %v", err)
+				}
+				if resp.Code != tt.wantCode {
+					t.Errorf("code = %d, want %d", resp.Code, tt.wantCode)
+				}
+				return
+			}
+
+			if contentType != ContentTypeProblem {
+				t.Fatalf("Content-Type = %q, want %q", contentType, ContentTypeProblem)
+			}
+			var problem Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("解析响应失败: %v", err)
+			}
+			want := Problem{
+				Type:     "urn:rich_go:error:" + strconv.Itoa(tt.wantCode),
+				Title:    http.StatusText(tt.wantStatus),
+				Status:   tt.wantStatus,
+				Instance: "/coupons/1",
+				Code:     tt.wantCode,
+			}
+			if problem.Type != want.Type || problem.Title != want.Title || problem.Status != want.Status ||
+				problem.Instance != want.Instance || problem.Code != want.Code {
+				t.Errorf("问题详情 = %+v, want %+v", problem, want)
+			}
+			if problem.Detail == "" {
+				t.Error("detail 为空")
+			}
+			be, _ := errors.AsBusinessError(tt.err)
+			if len(problem.Errors) != len(be.Fields) {
+				t.Errorf("errors = %+v, want %d 个字段错误", problem.Errors, len(be.Fields))
+			}
+			for i, f := range problem.Errors {
+				if f.Field != be.Fields[i].Field || f.Rule != be.Fields[i].Rule {
+					t.Errorf("errors[%d] = %+v, want %+v", i, f, be.Fields[i])
+				}
+			}
+		})
+	}
+}
@@ -1,16 +1,21 @@
 package response
 
 import (
+	"context"
 	"net/http"
 
+	"rich_go/pkg/errors"
+	"rich_go/pkg/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
 // Response 统一响应结构
 type Response struct {
-	Code    int         `json:"code"`    // 业务状态码
-	Message string      `json:"message"` // 响应消息
-	Data    interface{} `json:"data"`    // 响应数据
+	Code    int                 `json:"code"`             // 业务状态码
+	Message string              `json:"message"`          // 响应消息
+	Data    interface{}         `json:"data"`             // 响应数据
+	Errors  []errors.FieldError `json:"errors,omitempty"` // 字段级校验错误
 }
 
 // Success 成功响应
@@ -31,39 +36,73 @@ func SuccessWithMessage(c *gin.Context, message string, data interface{}) {
 	})
 }
 
-// Error 错误响应
+// SuccessWithMessageID 成功响应（消息取自消息目录，按请求语言本地化）
+func SuccessWithMessageID(c *gin.Context, messageID string, data interface{}) {
+	SuccessWithMessage(c, i18n.T(c.Request.Context(), messageID, nil), data)
+}
+
+// Error 错误响应，HTTP 状态码由 pkg/errors 错误码注册表决定
 func Error(c *gin.Context, code int, message string) {
-	c.JSON(http.StatusOK, Response{
-		Code:    code,
-		Message: message,
-		Data:    nil,
-	})
+	writeError(c, errors.HTTPStatus(code), code, message, nil)
+}
+
+// ErrorFrom 根据 error 输出错误响应，消息按请求语言本地化并携带字段级校验错误
+// 非业务错误统一返回内部错误；内部错误通过 c.Error 记录，由 ErrorHandler 中间件输出原始错误和调用栈，不暴露给客户端
+func ErrorFrom(c *gin.Context, err error) {
+	be, ok := errors.AsBusinessError(err)
+	switch {
+	case ok:
+	case errors.Is(err, context.DeadlineExceeded):
+		be = errors.ErrTimeout.WithCause(err)
+	default:
+		be = errors.Internal(err)
+	}
+	if be.HTTPStatus() >= http.StatusInternalServerError {
+		_ = c.Error(be)
+	}
+	message, fields := be.Localize(i18n.LanguageFromContext(c.Request.Context()))
+	writeError(c, be.HTTPStatus(), be.Code, message, fields)
 }
 
 // BadRequest 400 错误响应
 func BadRequest(c *gin.Context, message string) {
-	c.JSON(http.StatusBadRequest, Response{
-		Code:    400,
-		Message: message,
-		Data:    nil,
-	})
+	writeError(c, http.StatusBadRequest, 400, message, nil)
+}
+
+// Unauthorized 401 错误响应
+func Unauthorized(c *gin.Context, message string) {
+	writeError(c, http.StatusUnauthorized, 401, message, nil)
 }
 
 // NotFound 404 错误响应
 func NotFound(c *gin.Context, message string) {
-	c.JSON(http.StatusNotFound, Response{
-		Code:    404,
-		Message: message,
-		Data:    nil,
-	})
+	writeError(c, http.StatusNotFound, 404, message, nil)
 }
 
 // InternalServerError 500 错误响应
 func InternalServerError(c *gin.Context, message string) {
-	c.JSON(http.StatusInternalServerError, Response{
-		Code:    500,
+	writeError(c, http.StatusInternalServerError, 500, message, nil)
+}
+
+// ServiceUnavailable 503 错误响应
+func ServiceUnavailable(c *gin.Context, message string, data interface{}) {
+	c.JSON(http.StatusServiceUnavailable, Response{
+		Code:    503,
 		Message: message,
-		Data:    nil,
+		Data:    data,
 	})
 }
 
+// writeError 输出错误响应，客户端通过 Accept 请求 application/problem+json 时按 RFC 7807 输出
+func writeError(c *gin.Context, status, code int, message string, fields []errors.FieldError) {
+	if c.NegotiateFormat(gin.MIMEJSON, ContentTypeProblem) == ContentTypeProblem {
+		writeProblem(c, status, code, message, fields)
+		return
+	}
+	c.JSON(status, Response{
+		Code:    code,
+		Message: message,
+		Data:    nil,
+		Errors:  fields,
+	})
+}
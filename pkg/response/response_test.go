@@ -0,0 +1,68 @@
+package response
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"rich_go/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveError 通过 ErrorFrom 输出 err，返回响应和记录在 gin.Context 上的错误
+func serveError(t *testing.T, err error, accept string) (*httptest.ResponseRecorder, []*gin.Error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	var recorded []*gin.Error
+	engine := gin.New()
+	engine.GET("/coupons/1", func(c *gin.Context) {
+		ErrorFrom(c, err)
+		recorded = c.Errors
+	})
+	req := httptest.NewRequest(http.MethodGet, "/coupons/1", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w, recorded
+}
+
+func TestErrorFromHidesCause(t *testing.T) {
+	const secret = "dial tcp 10.0.0.5:5432: password authentication failed"
+	cause := stderrors.New(secret)
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantLogged bool // 是否通过 c.Error 记录供服务端日志使用
+	}{
+		{"非业务错误", cause, http.StatusInternalServerError, true},
+		{"内部错误", errors.Internal(cause), http.StatusInternalServerError, true},
+		{"fmt.Errorf 包装的内部错误", fmt.Errorf("创建优惠券: %w", errors.Internal(cause)), http.StatusInternalServerError, true},
+		{"带原始错误的业务错误", errors.ErrCouponNotFound.WithCause(cause), http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		for _, accept := range []string{"", ContentTypeProblem} {
+			t.Run(tt.name+" "+accept, func(t *testing.T) {
+				w, recorded := serveError(t, tt.err, accept)
+				if w.Code != tt.wantStatus {
+					t.Errorf("状态码 = %d, want %d", w.Code, tt.wantStatus)
+				}
+				if body := w.Body.String(); strings.Contains(body, secret) || strings.Contains(body, "caused by") {
+					t.Errorf("响应体包含原始错误: %s", body)
+				}
+				if got := len(recorded) > 0; got != tt.wantLogged {
+					t.Errorf("c.Error 记录 = %v, want %v", got, tt.wantLogged)
+				}
+				if tt.wantLogged && !errors.Is(recorded[0].Err, cause) {
+					t.Errorf("记录的错误 %v 不包含原始错误", recorded[0].Err)
+				}
+			})
+		}
+	}
+}
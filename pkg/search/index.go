@@ -0,0 +1,321 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 相关度参数
+const (
+	prefixWeight = 0.5  // 前缀匹配相对完全匹配的权重
+	minPrefixLen = 2    // 查询词至少 2 个字符才做前缀匹配
+	k1           = 1.2  // BM25 词频饱和参数
+	b            = 0.75 // BM25 字段长度归一化参数
+)
+
+// Field 文档字段
+type Field struct {
+	Name  string
+	Value string
+	Boost float64 // 相关度权重，0 时为 1
+}
+
+// Document 被索引的文档，Type 与 ID 唯一确定一个文档
+type Document struct {
+	Type   string
+	ID     string
+	Scope  string // 文档所属的隔离范围，例如租户，只有相同 Scope 的查询能搜到
+	Fields []Field
+}
+
+// Hit 搜索结果，Highlights 为命中字段的 HTML 片段，命中的词以 <em> 包裹
+type Hit struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Score      float64           `json:"score"`
+	Fields     map[string]string `json:"fields"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+type docKey struct {
+	typ string
+	id  string
+}
+
+// indexedDoc 已索引的文档，terms 为词在各字段中的出现次数
+type indexedDoc struct {
+	doc     Document
+	terms   map[string]map[int]int // 词 -> 字段下标 -> 词频
+	lengths []int                  // 各字段的词数
+}
+
+// Index 内存倒排索引，并发安全
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[docKey]*indexedDoc
+	postings map[string]map[docKey]struct{}
+	// 各字段的总词数和文档数，用于计算平均长度
+	fieldTerms map[string]int
+	fieldDocs  map[string]int
+}
+
+// NewIndex 创建空索引
+func NewIndex() *Index {
+	return &Index{
+		docs:       make(map[docKey]*indexedDoc),
+		postings:   make(map[string]map[docKey]struct{}),
+		fieldTerms: make(map[string]int),
+		fieldDocs:  make(map[string]int),
+	}
+}
+
+// Put 写入或替换文档
+func (idx *Index) Put(doc Document) {
+	d := &indexedDoc{doc: doc, terms: make(map[string]map[int]int), lengths: make([]int, len(doc.Fields))}
+	for i, f := range doc.Fields {
+		tokens := Tokenize(f.Value)
+		d.lengths[i] = len(tokens)
+		for _, t := range tokens {
+			if d.terms[t.Term] == nil {
+				d.terms[t.Term] = make(map[int]int)
+			}
+			d.terms[t.Term][i]++
+		}
+	}
+
+	key := docKey{doc.Type, doc.ID}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(key)
+	idx.docs[key] = d
+	for term := range d.terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[docKey]struct{})
+		}
+		idx.postings[term][key] = struct{}{}
+	}
+	for i, f := range doc.Fields {
+		idx.fieldTerms[f.Name] += d.lengths[i]
+		idx.fieldDocs[f.Name]++
+	}
+}
+
+// Delete 删除文档，文档不存在时忽略
+func (idx *Index) Delete(typ, id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(docKey{typ, id})
+}
+
+// Len 返回文档数
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// remove 删除文档，调用方需持有写锁
+func (idx *Index) remove(key docKey) {
+	d, ok := idx.docs[key]
+	if !ok {
+		return
+	}
+	delete(idx.docs, key)
+	for term := range d.terms {
+		delete(idx.postings[term], key)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	for i, f := range d.doc.Fields {
+		idx.fieldTerms[f.Name] -= d.lengths[i]
+		idx.fieldDocs[f.Name]--
+	}
+}
+
+// match 文档的得分及命中的词，用于高亮
+type match struct {
+	score float64
+	terms map[int]map[string]bool // 字段下标 -> 命中的词
+}
+
+// Search 按相关度返回最多 limit 个结果，limit <= 0 时不限制
+// 每个查询词都需命中（指定字段时只在该字段中查找），得分为各词 BM25 得分乘以字段权重之和
+func (idx *Index) Search(q Query, limit int) []Hit {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var matches map[docKey]*match
+	for _, clause := range q.Clauses {
+		for _, term := range queryTerms(clause.Text) {
+			termMatches := idx.matchTerm(term, clause.Field, q)
+			if matches == nil {
+				matches = termMatches
+				continue
+			}
+			for key, m := range matches {
+				tm, ok := termMatches[key]
+				if !ok {
+					delete(matches, key)
+					continue
+				}
+				m.score += tm.score
+				for field, terms := range tm.terms {
+					for t := range terms {
+						m.addTerm(field, t)
+					}
+				}
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(matches))
+	for key, m := range matches {
+		hits = append(hits, idx.hit(key, m))
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Type != hits[j].Type {
+			return hits[i].Type < hits[j].Type
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// matchTerm 查找 q 范围内命中单个查询词的文档，英文等查询词同时匹配以其为前缀的词
+func (idx *Index) matchTerm(term, field string, q Query) map[docKey]*match {
+	result := make(map[docKey]*match)
+	n := float64(len(idx.docs))
+	for indexed, weight := range idx.expand(term) {
+		docs := idx.postings[indexed]
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for key := range docs {
+			if len(q.Types) > 0 && !contains(q.Types, key.typ) {
+				continue
+			}
+			d := idx.docs[key]
+			if d.doc.Scope != q.Scope {
+				continue
+			}
+			for i, tf := range d.terms[indexed] {
+				f := d.doc.Fields[i]
+				if field != "" && !strings.EqualFold(f.Name, field) {
+					continue
+				}
+				boost := f.Boost
+				if boost == 0 {
+					boost = 1
+				}
+				avg := float64(idx.fieldTerms[f.Name]) / float64(max(idx.fieldDocs[f.Name], 1))
+				norm := float64(tf) * (k1 + 1) / (float64(tf) + k1*(1-b+b*float64(d.lengths[i])/max(avg, 1)))
+
+				m := result[key]
+				if m == nil {
+					m = &match{terms: make(map[int]map[string]bool)}
+					result[key] = m
+				}
+				m.score += boost * weight * idf * norm
+				m.addTerm(i, indexed)
+			}
+		}
+	}
+	return result
+}
+
+// expand 返回查询词对应的索引词及权重，调用方需持有读锁
+// 遍历词典做前缀匹配，适用于内存索引的数据规模
+func (idx *Index) expand(term string) map[string]float64 {
+	terms := make(map[string]float64)
+	if _, ok := idx.postings[term]; ok {
+		terms[term] = 1
+	}
+	if isCJKTerm(term) || utf8.RuneCountInString(term) < minPrefixLen {
+		return terms
+	}
+	for indexed := range idx.postings {
+		if indexed != term && strings.HasPrefix(indexed, term) {
+			terms[indexed] = prefixWeight
+		}
+	}
+	return terms
+}
+
+func (m *match) addTerm(field int, term string) {
+	if m.terms[field] == nil {
+		m.terms[field] = make(map[string]bool)
+	}
+	m.terms[field][term] = true
+}
+
+// hit 生成搜索结果及高亮
+func (idx *Index) hit(key docKey, m *match) Hit {
+	d := idx.docs[key]
+	h := Hit{
+		Type:       key.typ,
+		ID:         key.id,
+		Score:      math.Round(m.score*1000) / 1000,
+		Fields:     make(map[string]string, len(d.doc.Fields)),
+		Highlights: make(map[string]string),
+	}
+	for i, f := range d.doc.Fields {
+		h.Fields[f.Name] = f.Value
+		if terms := m.terms[i]; len(terms) > 0 {
+			h.Highlights[f.Name] = highlight(f.Value, terms)
+		}
+	}
+	return h
+}
+
+// highlight 将命中的词以 <em> 包裹，其余内容做 HTML 转义
+func highlight(text string, terms map[string]bool) string {
+	type span struct{ start, end int }
+	var matched []span
+	for _, t := range Tokenize(text) {
+		if terms[t.Term] {
+			matched = append(matched, span{t.Start, t.End})
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].start < matched[j].start })
+
+	// 中文的单字与 bigram 区间重叠，合并后再包裹
+	var spans []span
+	for _, s := range matched {
+		if n := len(spans); n > 0 && s.start <= spans[n-1].end {
+			spans[n-1].end = max(spans[n-1].end, s.end)
+			continue
+		}
+		spans = append(spans, s)
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for _, s := range spans {
+		sb.WriteString(html.EscapeString(text[pos:s.start]))
+		sb.WriteString("<em>")
+		sb.WriteString(html.EscapeString(text[s.start:s.end]))
+		sb.WriteString("</em>")
+		pos = s.end
+	}
+	sb.WriteString(html.EscapeString(text[pos:]))
+	return sb.String()
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
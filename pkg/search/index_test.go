@@ -0,0 +1,160 @@
+package search
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// newTestIndex 优惠券名称权重为 2
+func newTestIndex() *Index {
+	idx := NewIndex()
+	put := func(typ, id, name, description string) {
+		idx.Put(Document{Type: typ, ID: id, Fields: []Field{
+			{Name: "name", Value: name, Boost: 2},
+			{Name: "description", Value: description},
+		}})
+	}
+	put("coupon", "1", "夏季大促优惠券", "全场满100减20")
+	put("coupon", "2", "会员专享券", "夏季新品优惠")
+	put("coupon", "3", "冬季清仓", "季末优惠")
+	put("coupon", "4", "セール", "期間限定")
+	put("user", "1", "Summer Sale", "summer promotion for members")
+	idx.Put(Document{Type: "coupon", ID: "9", Scope: "acme", Fields: []Field{{Name: "name", Value: "夏季大促"}}})
+	return idx
+}
+
+func TestSearchCJK(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []string // 按相关度排序的 type/id
+		ordered bool     // 是否校验顺序
+	}{
+		{"bigram 命中名称的排在前面", "夏季", []string{"coupon/1", "coupon/2"}, true},
+		{"单字匹配所有包含该字的文档", "季", []string{"coupon/1", "coupon/2", "coupon/3"}, false},
+		{"三字词的每个 bigram 都需命中", "优惠券", []string{"coupon/1"}, true},
+		{"不相邻的字不匹配", "夏季优惠", nil, true},
+		{"空格分隔的词分别匹配", "夏季 优惠", []string{"coupon/1", "coupon/2"}, true},
+		{"字段限定", "name:夏季", []string{"coupon/1"}, true},
+		{"类型过滤", "type:user 夏季", nil, true},
+		{"中文不做前缀匹配", "夏", []string{"coupon/1", "coupon/2"}, false},
+		{"片假名", "セール", []string{"coupon/4"}, true},
+		{"英文前缀匹配", "summ", []string{"user/1"}, true},
+		{"没有命中", "冬夏", nil, true},
+	}
+	idx := newTestIndex()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+			}
+			got := keys(idx.Search(q, 0))
+			if !tt.ordered {
+				got, tt.want = sorted(got), sorted(tt.want)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	tests := []struct {
+		name string
+		docs []Document
+		want []string
+	}{
+		{"字段权重高的排在前面", []Document{
+			{Type: "coupon", ID: "a", Fields: []Field{{Name: "name", Value: "普通"}, {Name: "description", Value: "优惠活动"}}},
+			{Type: "coupon", ID: "b", Fields: []Field{{Name: "name", Value: "优惠活动", Boost: 3}, {Name: "description", Value: "普通"}}},
+		}, []string{"coupon/b", "coupon/a"}},
+		{"词频高的排在前面", []Document{
+			{Type: "coupon", ID: "a", Fields: []Field{{Name: "name", Value: "优惠 其他内容"}}},
+			{Type: "coupon", ID: "b", Fields: []Field{{Name: "name", Value: "优惠 优惠 其他"}}},
+		}, []string{"coupon/b", "coupon/a"}},
+		{"字段越短得分越高", []Document{
+			{Type: "coupon", ID: "a", Fields: []Field{{Name: "name", Value: "优惠 会员 新品 清仓 限时"}}},
+			{Type: "coupon", ID: "b", Fields: []Field{{Name: "name", Value: "优惠"}}},
+		}, []string{"coupon/b", "coupon/a"}},
+		{"得分相同时按类型和 ID 排序", []Document{
+			{Type: "user", ID: "1", Fields: []Field{{Name: "name", Value: "优惠"}}},
+			{Type: "coupon", ID: "2", Fields: []Field{{Name: "name", Value: "优惠"}}},
+			{Type: "coupon", ID: "1", Fields: []Field{{Name: "name", Value: "优惠"}}},
+		}, []string{"coupon/1", "coupon/2", "user/1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := NewIndex()
+			for _, d := range tt.docs {
+				idx.Put(d)
+			}
+			q, _ := ParseQuery("优惠")
+			if got := keys(idx.Search(q, 0)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchScopeAndUpdates(t *testing.T) {
+	idx := newTestIndex()
+	q, _ := ParseQuery("大促")
+	if got := keys(idx.Search(q, 0)); !reflect.DeepEqual(got, []string{"coupon/1"}) {
+		t.Errorf("未指定范围时结果为 %v, want 只有 coupon/1", got)
+	}
+	q.Scope = "acme"
+	if got := keys(idx.Search(q, 0)); !reflect.DeepEqual(got, []string{"coupon/9"}) {
+		t.Errorf("范围 acme 的结果为 %v, want 只有 coupon/9", got)
+	}
+
+	idx.Put(Document{Type: "coupon", ID: "1", Fields: []Field{{Name: "name", Value: "秋季特惠"}}})
+	idx.Delete("coupon", "2")
+	q, _ = ParseQuery("夏季")
+	if got := keys(idx.Search(q, 0)); len(got) != 0 {
+		t.Errorf("替换和删除后结果为 %v, want 无", got)
+	}
+	if idx.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", idx.Len())
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"bigram", "大促", "夏季<em>大促</em>优惠券"},
+		{"相邻的 bigram 合并", "季大 优惠", "夏<em>季大</em>促<em>优惠</em>券"},
+		{"单字", "促", "夏季大<em>促</em>优惠券"},
+	}
+	idx := newTestIndex()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := idx.Search(Query{Clauses: []Clause{{Field: "name", Text: tt.query}}}, 1)
+			if len(hits) != 1 {
+				t.Fatalf("Search(%q) 返回 %d 个结果, want 1", tt.query, len(hits))
+			}
+			if got := hits[0].Highlights["name"]; got != tt.want {
+				t.Errorf("高亮为 %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func keys(hits []Hit) []string {
+	var result []string
+	for _, h := range hits {
+		result = append(result, h.Type+"/"+h.ID)
+	}
+	return result
+}
+
+func sorted(s []string) []string {
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TypeField 按文档类型过滤的查询字段，例如 type:coupon
+const TypeField = "type"
+
+// Clause 查询条件，Field 为空时匹配所有字段，字段名不区分大小写
+type Clause struct {
+	Field string
+	Text  string
+}
+
+// Query 解析后的查询，所有条件都需满足
+type Query struct {
+	Clauses []Clause
+	Types   []string // type: 条件，为空时不限制
+	Scope   string   // 只搜索该范围的文档，由调用方设置，不从查询字符串解析
+}
+
+// ParseQuery 解析查询字符串
+// 以空格分隔的条件同时满足；field:value 只匹配指定字段；双引号包含空格，例如 name:"summer sale"
+func ParseQuery(q string) (Query, error) {
+	var query Query
+	for _, part := range splitQuery(q) {
+		field, text := "", part
+		if i := strings.IndexByte(part, ':'); i > 0 && isFieldName(part[:i]) {
+			field, text = part[:i], part[i+1:]
+		}
+		text = strings.Trim(text, `"`)
+		if text == "" {
+			if field != "" {
+				return Query{}, fmt.Errorf("search: 字段 %s 缺少查询内容", field)
+			}
+			continue
+		}
+		if strings.EqualFold(field, TypeField) {
+			query.Types = append(query.Types, strings.ToLower(text))
+			continue
+		}
+		query.Clauses = append(query.Clauses, Clause{Field: field, Text: text})
+	}
+	return query, nil
+}
+
+// Fields 返回查询中指定的字段，不含 type
+func (q Query) Fields() []string {
+	var fields []string
+	for _, c := range q.Clauses {
+		if c.Field != "" {
+			fields = append(fields, c.Field)
+		}
+	}
+	return fields
+}
+
+// splitQuery 按空格切分，双引号内的空格不切分
+func splitQuery(q string) []string {
+	var parts []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if b.Len() > 0 {
+				parts = append(parts, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+// isFieldName 字段名由字母组成，避免把 10:30 之类的内容当作字段
+func isFieldName(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) || r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
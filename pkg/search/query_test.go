@@ -0,0 +1,35 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Query
+		wantErr bool
+	}{
+		{"空", "", Query{}, false},
+		{"多个词", "夏季 优惠", Query{Clauses: []Clause{{Text: "夏季"}, {Text: "优惠"}}}, false},
+		{"字段", "name:夏季", Query{Clauses: []Clause{{Field: "name", Text: "夏季"}}}, false},
+		{"引号包含空格", `name:"summer sale"`, Query{Clauses: []Clause{{Field: "name", Text: "summer sale"}}}, false},
+		{"类型转小写", "type:Coupon 夏季", Query{Clauses: []Clause{{Text: "夏季"}}, Types: []string{"coupon"}}, false},
+		{"时间不是字段", "10:30", Query{Clauses: []Clause{{Text: "10:30"}}}, false},
+		{"中文前缀不是字段", "名称:夏季", Query{Clauses: []Clause{{Text: "名称:夏季"}}}, false},
+		{"字段缺少内容", "name:", Query{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuery(%q) 错误为 %v, want 错误 = %v", tt.query, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
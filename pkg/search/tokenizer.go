@@ -0,0 +1,90 @@
+// Package search 内存倒排索引，用于站内全文搜索
+//
+// 英文、数字按单词切分并转为小写，查询词可前缀匹配；中文、日文、韩文没有空格分词，
+// 索引时按单字和相邻两字（bigram）切分，查询时两字以上的词按 bigram 匹配，单字按单字匹配
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token 文本中的词及其在原文中的字节区间 [Start, End)
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize 按索引规则切分文本
+func Tokenize(text string) []Token {
+	return tokenize(text, false)
+}
+
+// queryTerms 按查询规则切分文本，结果去重并保持顺序
+func queryTerms(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, t := range tokenize(text, true) {
+		if !seen[t.Term] {
+			seen[t.Term] = true
+			terms = append(terms, t.Term)
+		}
+	}
+	return terms
+}
+
+// tokenize 切分文本，query 为 true 时中文等连续字符只在单字时输出单字
+func tokenize(text string, query bool) []Token {
+	var tokens []Token
+	wordStart := -1
+	var cjk []Token // 当前连续的中日韩字符，每个字符一个 Token
+
+	flushWord := func(end int) {
+		if wordStart >= 0 {
+			tokens = append(tokens, Token{Term: strings.ToLower(text[wordStart:end]), Start: wordStart, End: end})
+			wordStart = -1
+		}
+	}
+	flushCJK := func() {
+		if len(cjk) == 1 || !query {
+			tokens = append(tokens, cjk...)
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, Token{Term: cjk[i].Term + cjk[i+1].Term, Start: cjk[i].Start, End: cjk[i+1].End})
+		}
+		cjk = cjk[:0]
+	}
+
+	for i, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord(i)
+			cjk = append(cjk, Token{Term: string(r), Start: i, End: i + utf8.RuneLen(r)})
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			if wordStart < 0 {
+				wordStart = i
+			}
+		default:
+			flushWord(i)
+			flushCJK()
+		}
+	}
+	flushWord(len(text))
+	flushCJK()
+	return tokens
+}
+
+// isCJK 判断是否为不以空格分词的中日韩字符
+// 片假名长音符号 ー 属于 Common 字符集，需单独判断，否则 セール 会被切成三个词
+func isCJK(r rune) bool {
+	return r == 'ー' || unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// isCJKTerm 判断词是否由中日韩字符组成，这类词不做前缀匹配
+func isCJKTerm(term string) bool {
+	r, _ := utf8.DecodeRuneInString(term)
+	return isCJK(r)
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"英文转小写", "Summer SALE", []string{"summer", "sale"}},
+		{"数字与标点", "满100-减20!", []string{"满", "100", "减", "20"}},
+		{"中文输出单字和 bigram", "夏季大促", []string{"夏", "季", "大", "促", "夏季", "季大", "大促"}},
+		{"中英混排", "VIP会员", []string{"vip", "会", "员", "会员"}},
+		{"日文假名", "セール", []string{"セ", "ー", "ル", "セー", "ール"}},
+		{"单个汉字", "券", []string{"券"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range Tokenize(tt.text) {
+				got = append(got, tok.Term)
+				if strings.ToLower(tt.text[tok.Start:tok.End]) != tok.Term {
+					t.Errorf("词 %q 的区间 [%d,%d) 不正确", tok.Term, tok.Start, tok.End)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"两字以上的中文只按 bigram 查询", "夏季大促", []string{"夏季", "季大", "大促"}},
+		{"单字按单字查询", "券", []string{"券"}},
+		{"重复的词去重", "sale SALE", []string{"sale"}},
+		{"中英混排", "vip会员", []string{"vip", "会员"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryTerms(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("queryTerms(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"reflect"
+
+	"rich_go/pkg/errors"
+)
+
+// DecodeJSON 解析 JSON，失败时返回与 HTTP 请求体绑定一致的参数错误
+func DecodeJSON(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+	if be := FromJSONError(err); be != nil {
+		return be
+	}
+	return errors.ErrInvalidParam.WithCause(err)
+}
+
+// FromJSONError 将 JSON 解析错误转换为参数错误，类型错误带字段详情，不暴露解析器的原始信息
+// 非 JSON 解析错误返回 nil
+func FromJSONError(err error) *errors.BusinessError {
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) {
+		return errors.NewValidationError(errors.NewFieldError(typeErr.Field, "type", jsonTypeName(typeErr.Type)))
+	}
+
+	var syntaxErr *json.SyntaxError
+	if stderrors.As(err, &syntaxErr) || stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.NewLocalizedError(errors.CodeInvalidParam, "validation.invalid_json", nil)
+	}
+	return nil
+}
+
+// jsonTypeName 返回 Go 类型对应的 JSON 类型名称
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
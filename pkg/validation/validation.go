@@ -0,0 +1,110 @@
+package validation
+
+import (
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"rich_go/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// 校验规则通过结构体字段的 validate 标签声明，跨字段规则通过 RegisterStructRule 注册
+// 校验失败返回带字段错误的参数错误，HTTP 与 gRPC 均可直接输出
+
+var (
+	once     sync.Once
+	validate *validator.Validate
+)
+
+// engine 返回全局校验器，首次调用时初始化
+func engine() *validator.Validate {
+	once.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(JSONFieldName)
+	})
+	return validate
+}
+
+// StructRule 跨字段校验规则，通过 Reporter 报告字段错误
+type StructRule func(r Reporter)
+
+// Reporter 跨字段规则中报告字段错误
+type Reporter interface {
+	// Current 返回正在校验的结构体
+	Current() interface{}
+	// Report 报告字段错误，field 为 Go 字段名，错误中使用其 JSON 名称
+	Report(field, rule, param string)
+}
+
+// reporter 适配 validator.StructLevel
+type reporter struct {
+	sl validator.StructLevel
+}
+
+func (r reporter) Current() interface{} {
+	return r.sl.Current().Interface()
+}
+
+func (r reporter) Report(field, rule, param string) {
+	value := r.sl.Current().FieldByName(field)
+	name := field
+	if sf, ok := r.sl.Current().Type().FieldByName(field); ok {
+		if n := JSONFieldName(sf); n != "" {
+			name = n
+		}
+	}
+	var v interface{}
+	if value.IsValid() {
+		v = value.Interface()
+	}
+	r.sl.ReportError(v, name, field, rule, param)
+}
+
+// RegisterStructRule 为请求类型注册跨字段校验规则，应在 init 中调用
+// types 传入结构体零值，例如 CreateCouponRequest{}
+func RegisterStructRule(rule StructRule, types ...interface{}) {
+	engine().RegisterStructValidation(func(sl validator.StructLevel) {
+		rule(reporter{sl: sl})
+	}, types...)
+}
+
+// Struct 按声明的规则校验请求结构体，通过时返回 nil
+func Struct(v interface{}) error {
+	err := engine().Struct(v)
+	if err == nil {
+		return nil
+	}
+	if be := FromError(err); be != nil {
+		return be
+	}
+	return errors.Internal(err)
+}
+
+// FromError 将校验器错误转换为带字段详情的参数错误，非校验错误返回 nil
+func FromError(err error) *errors.BusinessError {
+	var validationErrs validator.ValidationErrors
+	if !stderrors.As(err, &validationErrs) {
+		return nil
+	}
+	fields := make([]errors.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, errors.NewFieldError(fe.Field(), fe.Tag(), fe.Param()))
+	}
+	return errors.NewValidationError(fields...)
+}
+
+// JSONFieldName 返回结构体字段的 JSON 名称，错误中使用 discountType 而不是 DiscountType
+func JSONFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
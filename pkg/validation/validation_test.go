@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"rich_go/pkg/errors"
+)
+
+// testAddress 嵌套结构体，字段错误同样使用 JSON 名称
+type testAddress struct {
+	PostCode string `json:"postCode" validate:"required,len=6"`
+}
+
+// testCouponRequest 覆盖 JSON 名称、忽略的字段和无 json 标签的字段
+type testCouponRequest struct {
+	Name          string       `json:"name" validate:"required"`
+	DiscountType  string       `json:"discountType,omitempty" validate:"required,oneof=fixed percent"`
+	DiscountValue float64      `json:"discountValue" validate:"gt=0"`
+	MinAmount     float64      `json:"minAmount" validate:"gte=0"`
+	Internal      string       `json:"-" validate:"omitempty,max=1"`
+	Remark        string       `validate:"max=3"`
+	Address       *testAddress `json:"address" validate:"omitempty"`
+}
+
+// testRangeRequest 注册了跨字段规则的请求
+type testRangeRequest struct {
+	StartAt int `json:"startAt"`
+	EndAt   int `json:"endAt,omitempty"`
+	Limit   int `json:"limit"`
+}
+
+func init() {
+	RegisterStructRule(func(r Reporter) {
+		req := r.Current().(testRangeRequest)
+		if req.EndAt < req.StartAt {
+			r.Report("EndAt", "gtfield", "startAt")
+		}
+		if req.Limit > 10 {
+			r.Report("Limit", "lte", "10")
+		}
+	}, testRangeRequest{})
+}
+
+// fieldRules 返回字段错误的 "字段:规则" 列表
+func fieldRules(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	be, ok := errors.AsBusinessError(err)
+	if !ok || be.Code != errors.CodeInvalidParam {
+		t.Fatalf("错误为 %v, want 参数错误", err)
+	}
+	var got []string
+	for _, f := range be.Fields {
+		got = append(got, f.Field+":"+f.Rule)
+	}
+	return got
+}
+
+func TestStructUsesJSONFieldNames(t *testing.T) {
+	valid := testCouponRequest{Name: "满减", DiscountType: "fixed", DiscountValue: 10}
+	tests := []struct {
+		name string
+		req  func(r *testCouponRequest)
+		want []string
+	}{
+		{"通过校验", func(r *testCouponRequest) {}, nil},
+		{"使用 JSON 名称", func(r *testCouponRequest) {
+			r.Name, r.DiscountType, r.DiscountValue, r.MinAmount = "", "gift", 0, -1
+		}, []string{"name:required", "discountType:oneof", "discountValue:gt", "minAmount:gte"}},
+		{"json 标签带选项", func(r *testCouponRequest) { r.DiscountType = "" }, []string{"discountType:required"}},
+		{"没有 json 标签时使用字段名", func(r *testCouponRequest) { r.Remark = "太长的备注" }, []string{"Remark:max"}},
+		{"嵌套结构体", func(r *testCouponRequest) { r.Address = &testAddress{PostCode: "1"} }, []string{"postCode:len"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.req(&req)
+			got := fieldRules(t, Struct(&req))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("字段错误 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructRuleReportsJSONField(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       testRangeRequest
+		want      []string
+		wantParam string
+	}{
+		{"通过校验", testRangeRequest{StartAt: 1, EndAt: 2, Limit: 10}, nil, ""},
+		{"结束早于开始", testRangeRequest{StartAt: 5, EndAt: 1}, []string{"endAt:gtfield"}, "startAt"},
+		{"只报告对应字段", testRangeRequest{StartAt: 0, EndAt: 200, Limit: 20}, []string{"limit:lte"}, "10"},
+		{"多个字段", testRangeRequest{StartAt: 5, EndAt: 1, Limit: 20}, []string{"endAt:gtfield", "limit:lte"}, "startAt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.req)
+			got := fieldRules(t, err)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("字段错误 = %v, want %v", got, tt.want)
+			}
+			if len(tt.want) > 0 {
+				be, _ := errors.AsBusinessError(err)
+				if be.Fields[0].Param != tt.wantParam {
+					t.Errorf("param = %q, want %q", be.Fields[0].Param, tt.wantParam)
+				}
+				if be.Fields[0].Message == "" {
+					t.Error("字段错误没有描述")
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeJSONFieldNames(t *testing.T) {
+	var req testCouponRequest
+	got := fieldRules(t, DecodeJSON([]byte(`{"name":"满减","discountValue":"十"}`), &req))
+	if want := []string{"discountValue:type"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("字段错误 = %v, want %v", got, want)
+	}
+}
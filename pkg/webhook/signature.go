@@ -0,0 +1,72 @@
+// Package webhook webhook 签名与校验，供发送方和接收方共用
+//
+// 签名内容为 "{timestamp}.{body}"，使用 HMAC-SHA256 计算，签名头格式为 "sha256={hex}"。
+// 接收方应校验签名并拒绝时间戳偏差过大的请求，以防重放
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 投递请求头
+const (
+	HeaderDelivery  = "X-Webhook-Delivery"  // 投递 ID，重新投递时不变
+	HeaderEvent     = "X-Webhook-Event"     // 事件类型
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix 秒
+	HeaderSignature = "X-Webhook-Signature" // sha256={hex}
+)
+
+// DefaultTolerance 默认允许的时间戳偏差
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature or timestamp")
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrTimestampExpired = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Sign 计算签名，返回签名头的值
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetHeaders 为投递请求设置时间戳和签名头
+func SetHeaders(h http.Header, secret string, now time.Time, body []byte) {
+	ts := now.Unix()
+	h.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	h.Set(HeaderSignature, Sign(secret, ts, body))
+}
+
+// Verify 校验请求头中的签名和时间戳，tolerance <= 0 时使用 DefaultTolerance
+func Verify(secret string, h http.Header, body []byte, tolerance time.Duration) error {
+	sig := h.Get(HeaderSignature)
+	tsHeader := h.Get(HeaderTimestamp)
+	if sig == "" || tsHeader == "" {
+		return ErrMissingSignature
+	}
+	ts, err := strconv.ParseInt(tsHeader, 10, 64)
+	if err != nil {
+		return ErrMissingSignature
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	if d := time.Since(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+		return ErrTimestampExpired
+	}
+	if !strings.HasPrefix(sig, "sha256=") || !hmac.Equal([]byte(sig), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "0123456789abcdef"
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+
+	signed := func(secret string, at time.Time) http.Header {
+		h := http.Header{}
+		SetHeaders(h, secret, at, body)
+		return h
+	}
+	tampered := signed(secret, now)
+	tampered.Set(HeaderSignature, "sha256=00")
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   []byte
+		want   error
+	}{
+		{"签名有效", signed(secret, now), body, nil},
+		{"缺少签名", http.Header{}, body, ErrMissingSignature},
+		{"密钥不同", signed("another-secret-value", now), body, ErrInvalidSignature},
+		{"请求体被修改", signed(secret, now), []byte(`{"id":"2"}`), ErrInvalidSignature},
+		{"签名被修改", tampered, body, ErrInvalidSignature},
+		{"时间戳过期", signed(secret, now.Add(-DefaultTolerance-time.Minute)), body, ErrTimestampExpired},
+		{"时间戳超前", signed(secret, now.Add(DefaultTolerance+time.Minute)), body, ErrTimestampExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(secret, tt.header, tt.body, 0); !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// 与 openssl dgst -sha256 -hmac secret 对 "1700000000.hello" 的结果一致
+	const want = "sha256=47b1df0ab12338b2685470b0d2b37033add7c3b2bc8172f313e77413f1bb78c8"
+	if got := Sign("secret", 1700000000, []byte("hello")); got != want {
+		t.Errorf("Sign = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// 回调地址只允许指向公网：创建订阅时校验 URL 解析出的地址，发送时在建立连接前再次校验，
+// 以防 DNS 记录在创建后被改为内网地址；投递请求不跟随重定向
+
+// ErrForbiddenTarget 回调地址不是公网 http/https 地址
+var ErrForbiddenTarget = errors.New("webhook: target must be a public http or https address")
+
+// nonPublicPrefixes IsPrivate、IsLoopback 等方法未覆盖的非公网地址段
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // 本网络
+	netip.MustParsePrefix("100.64.0.0/10"), // 运营商级 NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF 协议分配
+	netip.MustParsePrefix("198.18.0.0/15"), // 基准测试
+	netip.MustParsePrefix("240.0.0.0/4"),   // 保留
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64，可映射到任意 IPv4 地址
+}
+
+// IsPublicAddr 判断地址是否为公网单播地址，环回、私有、链路本地（含 169.254.169.254 元数据地址）等均不是
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	if addr.Is4() && addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}) {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL 校验回调 URL：只允许 http 和 https，主机解析出的地址必须全部是公网地址
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenTarget, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme %q", ErrForbiddenTarget, u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrForbiddenTarget)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: resolve %s: %v", ErrForbiddenTarget, host, err)
+	}
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenTarget, host, addr)
+		}
+	}
+	return nil
+}
+
+// NewClient 创建发送投递请求的 HTTP 客户端：只连接公网地址，不跟随重定向，不使用环境变量中的代理
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrForbiddenTarget, err)
+			}
+			if !IsPublicAddr(ap.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenTarget, ap.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
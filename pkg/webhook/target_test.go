@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := IsPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("IsPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://93.184.215.14/hook", nil},
+		{"http://[2606:4700:4700::1111]:8080/hook", nil},
+		{"ftp://93.184.215.14/hook", ErrForbiddenTarget},
+		{"file:///etc/passwd", ErrForbiddenTarget},
+		{"http:///hook", ErrForbiddenTarget},
+		{"http://127.0.0.1:8080/hook", ErrForbiddenTarget},
+		{"http://localhost/hook", ErrForbiddenTarget},
+		{"http://10.0.0.5/hook", ErrForbiddenTarget},
+		{"http://169.254.169.254/latest/meta-data/", ErrForbiddenTarget},
+		{"http://[::1]/hook", ErrForbiddenTarget},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if err := CheckURL(context.Background(), tt.url); !errors.Is(err, tt.want) {
+				t.Errorf("CheckURL = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientRefusesPrivateAddr(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+	defer srv.Close()
+
+	resp, err := NewClient(time.Second).Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, ErrForbiddenTarget) || called {
+		t.Errorf("请求环回地址返回 %v、接收方收到请求 %v, want ErrForbiddenTarget 且未收到", err, called)
+	}
+}